@@ -0,0 +1,171 @@
+package bench
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/convert"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+var (
+	gureguSample = GureguRecord{
+		Name:    null.StringFrom(SampleName),
+		Count:   null.IntFrom(SampleCount),
+		Updated: null.TimeFrom(SampleUpdated),
+	}
+	pgtypeSample = PgtypeRecord{
+		Name:    pgtype.Text{String: SampleName, Valid: true},
+		Count:   pgtype.Int8{Int64: SampleCount, Valid: true},
+		Updated: pgtype.Timestamptz{Time: SampleUpdated, Valid: true},
+	}
+	pointerSample = PointerRecord{Name: &SampleName, Count: &SampleCount, Updated: &SampleUpdated}
+	nullSample    = NullRecord{
+		Name:    nullable.From(SampleName),
+		Count:   nullable.From(SampleCount),
+		Updated: nullable.From(SampleUpdated),
+	}
+	optionalSample = OptionalRecord{
+		Name:    nullable.OptionalFrom(SampleName),
+		Count:   nullable.OptionalFrom(SampleCount),
+		Updated: nullable.OptionalFrom(SampleUpdated),
+	}
+)
+
+func benchmarkMarshal[T any](b *testing.B, v T) {
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := json.Marshal(v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	b.Run("guregu", func(b *testing.B) { benchmarkMarshal(b, gureguSample) })
+	b.Run("pgtype", func(b *testing.B) { benchmarkMarshal(b, pgtypeSample) })
+	b.Run("pointer", func(b *testing.B) { benchmarkMarshal(b, pointerSample) })
+	b.Run("Null", func(b *testing.B) { benchmarkMarshal(b, nullSample) })
+	b.Run("Optional", func(b *testing.B) { benchmarkMarshal(b, optionalSample) })
+}
+
+func benchmarkUnmarshal[T any](b *testing.B) {
+	b.ReportAllocs()
+	for b.Loop() {
+		var v T
+		if err := json.Unmarshal(SampleJSON, &v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	b.Run("guregu", benchmarkUnmarshal[GureguRecord])
+	b.Run("pgtype", benchmarkUnmarshal[PgtypeRecord])
+	b.Run("pointer", benchmarkUnmarshal[PointerRecord])
+	b.Run("Null", benchmarkUnmarshal[NullRecord])
+	b.Run("Optional", benchmarkUnmarshal[OptionalRecord])
+}
+
+// benchmarkScan feeds driver values to the Scanners returned by fields,
+// as database/sql does for every row.
+func benchmarkScan[T any](b *testing.B, fields func(*T) []sql.Scanner) {
+	b.ReportAllocs()
+	for b.Loop() {
+		var v T
+		dst := fields(&v)
+		if err := dst[0].Scan(SampleName); err != nil {
+			b.Fatal(err)
+		}
+		if err := dst[1].Scan(SampleCount); err != nil {
+			b.Fatal(err)
+		}
+		if err := dst[2].Scan(SampleUpdated); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// pointerScanner scans into a pointer field the way database/sql does for *T destinations.
+type pointerScanner[T any] struct {
+	p **T
+}
+
+func (s pointerScanner[T]) Scan(src any) error {
+	if src == nil {
+		*s.p = nil
+		return nil
+	}
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+	*s.p = &n.V
+	return nil
+}
+
+func BenchmarkScan(b *testing.B) {
+	b.Run("guregu", func(b *testing.B) {
+		benchmarkScan(b, func(r *GureguRecord) []sql.Scanner { return []sql.Scanner{&r.Name, &r.Count, &r.Updated} })
+	})
+	b.Run("pgtype", func(b *testing.B) {
+		benchmarkScan(b, func(r *PgtypeRecord) []sql.Scanner { return []sql.Scanner{&r.Name, &r.Count, &r.Updated} })
+	})
+	b.Run("pointer", func(b *testing.B) {
+		benchmarkScan(b, func(r *PointerRecord) []sql.Scanner {
+			return []sql.Scanner{pointerScanner[string]{&r.Name}, pointerScanner[int64]{&r.Count}, pointerScanner[time.Time]{&r.Updated}}
+		})
+	})
+	b.Run("Null", func(b *testing.B) {
+		benchmarkScan(b, func(r *NullRecord) []sql.Scanner { return []sql.Scanner{&r.Name, &r.Count, &r.Updated} })
+	})
+	b.Run("Optional", func(b *testing.B) {
+		benchmarkScan(b, func(r *OptionalRecord) []sql.Scanner { return []sql.Scanner{&r.Name, &r.Count, &r.Updated} })
+	})
+}
+
+// BenchmarkConvert compares hand-written conversions into pgtype with the
+// reflection-based convert.Struct.
+func BenchmarkConvert(b *testing.B) {
+	b.Run("guregu/manual", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = PgtypeRecord{
+				Name:    convert.NullStringToPgText(gureguSample.Name),
+				Count:   convert.NullIntToPgInt8(gureguSample.Count),
+				Updated: convert.NullTimeToPgTimestamptz(gureguSample.Updated),
+			}
+		}
+	})
+	b.Run("pointer/manual", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = PgtypeRecord{
+				Name:    convert.PgTextFromPtr(pointerSample.Name),
+				Count:   convert.PgInt8FromPtr(pointerSample.Count),
+				Updated: convert.PgTimestamptzFromPtr(pointerSample.Updated),
+			}
+		}
+	})
+	for name, src := range map[string]any{
+		"guregu/Struct":   gureguSample,
+		"pointer/Struct":  pointerSample,
+		"Null/Struct":     nullSample,
+		"Optional/Struct": optionalSample,
+	} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				var dst PgtypeRecord
+				if err := convert.Struct(src, &dst); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
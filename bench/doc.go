@@ -0,0 +1,57 @@
+// Package bench compares the nullable representations a DTO can be built on:
+// github.com/guregu/null, pgtype, plain pointers and this module's generic types,
+// for JSON encoding and decoding, database scanning and struct conversion.
+//
+// It lives in its own module so the main module does not depend on it. Run it with
+//
+//	cd bench && go test -bench . -benchmem
+package bench
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Each record holds the same three columns: a name, a count and a timestamp.
+
+type GureguRecord struct {
+	Name    null.String `json:"name"`
+	Count   null.Int    `json:"count"`
+	Updated null.Time   `json:"updated"`
+}
+
+type PgtypeRecord struct {
+	Name    pgtype.Text        `json:"name"`
+	Count   pgtype.Int8        `json:"count"`
+	Updated pgtype.Timestamptz `json:"updated"`
+}
+
+type PointerRecord struct {
+	Name    *string    `json:"name"`
+	Count   *int64     `json:"count"`
+	Updated *time.Time `json:"updated"`
+}
+
+type NullRecord struct {
+	Name    nullable.Null[string]    `json:"name"`
+	Count   nullable.Null[int64]     `json:"count"`
+	Updated nullable.Null[time.Time] `json:"updated"`
+}
+
+type OptionalRecord struct {
+	Name    nullable.Optional[string]    `json:"name"`
+	Count   nullable.Optional[int64]     `json:"count"`
+	Updated nullable.Optional[time.Time] `json:"updated"`
+}
+
+// Sample values shared by every benchmark.
+var (
+	SampleName    = "Tan Ah Kow"
+	SampleCount   = int64(42)
+	SampleUpdated = time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	SampleJSON    = []byte(`{"name":"Tan Ah Kow","count":42,"updated":"2024-05-06T07:08:09Z"}`)
+)
@@ -0,0 +1,19 @@
+// Command nullcheck runs the analyzers of this module: nullcheck, reporting nullable
+// values read without checking Valid and struct literals of nullable types missing
+// Valid: true, and zerowrite, reporting updates that overwrite columns from DTO fields
+// that cannot be undefined. It runs standalone or as a vet tool:
+//
+//	go run github.com/nadhifikbarw/x-go-painless-null/cmd/nullcheck ./...
+//	go vet -vettool=$(which nullcheck) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/multichecker"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/analysis/nullcheck"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/analysis/zerowrite"
+)
+
+func main() {
+	multichecker.Main(nullcheck.Analyzer, zerowrite.Analyzer)
+}
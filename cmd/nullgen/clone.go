@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+)
+
+// clone emits a Clone method for def returning a deep copy, so DTOs can be snapshotted
+// before merges without sharing slices, maps or pointers with the original.
+func (g *generator) clone(def structDef) {
+	g.printf("// Clone returns a deep copy of f.\n")
+	g.printf("func (f %s) Clone() %s {\n", def.name, def.name)
+	g.printf("c := f\n")
+	for _, f := range def.fields {
+		g.cloneInto("c."+f.name, "f."+f.name, f.typ, 1)
+	}
+	g.printf("return c\n}\n\n")
+}
+
+// cloneInto emits statements replacing the references held by dst, which starts out
+// as a shallow copy of src, with copies. It emits nothing for types without references.
+func (g *generator) cloneInto(dst, src string, typ ast.Expr, depth int) {
+	if !g.needsClone(typ) {
+		return
+	}
+	if expr, ok := g.cloneExpr(src, typ); ok {
+		g.printf("%s = %s\n", dst, expr)
+		return
+	}
+	switch t := typ.(type) {
+	case *ast.ParenExpr:
+		g.cloneInto(dst, src, t.X, depth)
+	case *ast.StarExpr:
+		v := fmt.Sprintf("v%d", depth)
+		g.printf("if %s != nil {\n", src)
+		if _, ok := t.X.(*ast.Ident); ok && g.needsClone(t.X) {
+			// Clone has a value receiver, so it can be called through the pointer.
+			g.printf("%s := %s.Clone()\n", v, src)
+		} else if expr, ok := g.cloneExpr("(*"+src+")", t.X); ok {
+			g.printf("%s := %s\n", v, expr)
+		} else {
+			g.printf("%s := *%s\n", v, src)
+			g.cloneInto(v, v, t.X, depth+1)
+		}
+		g.printf("%s = &%s\n}\n", dst, v)
+	case *ast.ArrayType:
+		i := fmt.Sprintf("i%d", depth)
+		if t.Len != nil {
+			g.printf("for %s := range %s {\n", i, src)
+			g.cloneInto(dst+"["+i+"]", src+"["+i+"]", t.Elt, depth+1)
+			g.printf("}\n")
+			return
+		}
+		g.printf("if %s != nil {\n%s = make(%s, len(%s))\n", src, dst, g.typeString(t), src)
+		g.printf("for %s := range %s {\n", i, src)
+		if expr, ok := g.cloneExpr(src+"["+i+"]", t.Elt); ok {
+			g.printf("%s[%s] = %s\n", dst, i, expr)
+		} else {
+			g.printf("%s[%s] = %s[%s]\n", dst, i, src, i)
+			g.cloneInto(dst+"["+i+"]", src+"["+i+"]", t.Elt, depth+1)
+		}
+		g.printf("}\n}\n")
+	case *ast.MapType:
+		k, v := fmt.Sprintf("k%d", depth), fmt.Sprintf("v%d", depth)
+		g.printf("if %s != nil {\n%s = make(%s, len(%s))\n", src, dst, g.typeString(t), src)
+		g.printf("for %s, %s := range %s {\n", k, v, src)
+		g.cloneInto(v, v, t.Value, depth+1)
+		g.printf("%s[%s] = %s\n}\n}\n", dst, k, v)
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		name, args := nullableGeneric(typ, g.src.imports)
+		switch name {
+		case "Slice":
+			g.cloneInto(dst+".V", src+".V", &ast.ArrayType{Elt: args[0]}, depth)
+		case "MapOf":
+			g.cloneInto(dst+".V", src+".V", &ast.MapType{Key: args[0], Value: args[1]}, depth)
+		case "JSONOf":
+			g.cloneInto(dst+".V", src+".V", args[0], depth)
+		}
+	}
+}
+
+// cloneExpr returns an expression copying src when a single call does, as for
+// generated structs and for slices and maps whose elements hold no references.
+func (g *generator) cloneExpr(src string, typ ast.Expr) (string, bool) {
+	if !g.needsClone(typ) {
+		return "", false
+	}
+	switch t := typ.(type) {
+	case *ast.ParenExpr:
+		return g.cloneExpr(src, t.X)
+	case *ast.Ident:
+		return src + ".Clone()", true
+	case *ast.ArrayType:
+		if t.Len == nil && !g.needsClone(t.Elt) {
+			return g.usePath("slices") + ".Clone(" + src + ")", true
+		}
+	case *ast.MapType:
+		if !g.needsClone(t.Value) {
+			return g.usePath("maps") + ".Clone(" + src + ")", true
+		}
+	case *ast.SelectorExpr:
+		// nullable.JSON keeps its document in a byte slice.
+		return fmt.Sprintf("%s.JSON{RawMessage: %s.Clone(%s.RawMessage), Valid: %s.Valid}", g.use(t.X.(*ast.Ident).Name), g.usePath("slices"), src, src), true
+	}
+	return "", false
+}
+
+// needsClone reports whether values of typ hold references that a plain copy would share.
+// Struct types are deep-copied through their Clone method when they are also being
+// generated; other named types, such as nullable.Null or time.Time, are copied as is.
+func (g *generator) needsClone(typ ast.Expr) bool {
+	switch t := typ.(type) {
+	case *ast.ParenExpr:
+		return g.needsClone(t.X)
+	case *ast.Ident:
+		for _, def := range g.src.structs {
+			if def.name == t.Name {
+				return true
+			}
+		}
+		return false
+	case *ast.StarExpr, *ast.MapType:
+		return true
+	case *ast.ArrayType:
+		return t.Len == nil || g.needsClone(t.Elt)
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		return ok && g.src.imports[pkg.Name] == nullablePath && t.Sel.Name == "JSON"
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		name, args := nullableGeneric(typ, g.src.imports)
+		switch name {
+		case "Slice", "MapOf":
+			return true
+		case "JSONOf":
+			return g.needsClone(args[0])
+		}
+	}
+	return false
+}
+
+// nullableGeneric returns the name and type arguments of an instantiated nullable generic type,
+// such as Slice and [string] for nullable.Slice[string].
+func nullableGeneric(typ ast.Expr, imports map[string]string) (string, []ast.Expr) {
+	var x ast.Expr
+	var args []ast.Expr
+	switch t := typ.(type) {
+	case *ast.IndexExpr:
+		x, args = t.X, []ast.Expr{t.Index}
+	case *ast.IndexListExpr:
+		x, args = t.X, t.Indices
+	default:
+		return "", nil
+	}
+	sel, ok := x.(*ast.SelectorExpr)
+	if !ok {
+		return "", nil
+	}
+	if pkg, ok := sel.X.(*ast.Ident); !ok || imports[pkg.Name] != nullablePath {
+		return "", nil
+	}
+	return sel.Sel.Name, args
+}
+
+// typeString renders typ as source, recording the imports it refers to.
+func (g *generator) typeString(typ ast.Expr) string {
+	for _, pkg := range usedPackages(typ) {
+		g.use(pkg)
+	}
+	return types.ExprString(typ)
+}
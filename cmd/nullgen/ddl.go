@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// elemColumnTypes maps Go element types to Postgres column types.
+var elemColumnTypes = map[string]string{
+	"string":    "text",
+	"int":       "int8",
+	"int64":     "int8",
+	"int32":     "int4",
+	"int16":     "int2",
+	"float64":   "float8",
+	"float32":   "float4",
+	"bool":      "boolean",
+	"[]byte":    "bytea",
+	"time.Time": "timestamptz",
+}
+
+// nullableColumnTypes maps the concrete and generic types of package nullable to Postgres column types.
+// Generic types holding collections map to jsonb, except Slice which maps to an array of its element.
+var nullableColumnTypes = map[string]string{
+	"Time":    "timestamptz",
+	"Date":    "date",
+	"Decimal": "numeric",
+	"UUID":    "uuid",
+	"JSON":    "jsonb",
+	"JSONOf":  "jsonb",
+	"MapOf":   "jsonb",
+	"Int16":   "int2",
+	"Int32":   "int4",
+	"Int64":   "int8",
+	"Bool":    "boolean",
+	"Uinfin":  "text",
+	"Enum":    "text",
+}
+
+// pgtypeColumnTypes maps pgtype types to Postgres column types.
+var pgtypeColumnTypes = map[string]string{
+	"Text":        "text",
+	"Int2":        "int2",
+	"Int4":        "int4",
+	"Int8":        "int8",
+	"Float4":      "float4",
+	"Float8":      "float8",
+	"Bool":        "boolean",
+	"Numeric":     "numeric",
+	"Date":        "date",
+	"Timestamp":   "timestamp",
+	"Timestamptz": "timestamptz",
+	"UUID":        "uuid",
+}
+
+// rangeColumnTypes maps the element types of nullable.Range to Postgres range types.
+var rangeColumnTypes = map[string]string{
+	"int32":     "int4range",
+	"int64":     "int8range",
+	"time.Time": "tstzrange",
+}
+
+// runDDL implements the ddl subcommand, which prints CREATE TABLE statements for the
+// requested structs so the schema follows the nullability of the DTOs.
+func runDDL(args []string) error {
+	fs := flag.NewFlagSet("nullgen ddl", flag.ExitOnError)
+	typeNames := fs.String("type", "", "comma-separated list of struct names; required")
+	output := fs.String("output", "", "output file name; default standard output")
+	table := fs.String("table", "", "table name, with a single -type; default the snake_cased struct name")
+	fs.Parse(args)
+
+	if *typeNames == "" {
+		return errors.New("-type is required")
+	}
+	names := strings.Split(*typeNames, ",")
+	if *table != "" && len(names) > 1 {
+		return errors.New("-table requires a single -type")
+	}
+	filename, err := inputFile(fs.Args())
+	if err != nil {
+		return err
+	}
+	src, err := parseSource(filename, names)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("-- Code generated by nullgen ddl. DO NOT EDIT.\n")
+	for _, def := range src.structs {
+		name := *table
+		if name == "" {
+			name = snakeCase(def.name)
+		}
+		if err := createTable(&buf, name, def, src.imports); err != nil {
+			return err
+		}
+	}
+
+	if *output == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(*output, buf.Bytes(), 0o644)
+}
+
+// createTable writes the CREATE TABLE statement of def to buf.
+// Columns are named after the `db` tag or the snake_cased field name and `db:"-"`
+// skips a field, like package sqlbuild does. A `ddl` tag replaces the column type and
+// nullability, e.g. `ddl:"uuid PRIMARY KEY"`.
+func createTable(buf *bytes.Buffer, table string, def structDef, imports map[string]string) error {
+	var cols []string
+	for _, f := range def.fields {
+		tag := f.tagValue("db")
+		if tag == "-" {
+			continue
+		}
+		col, _, _ := strings.Cut(tag, ",")
+		if col == "" {
+			col = snakeCase(f.name)
+		}
+		if override := f.tagValue("ddl"); override != "" {
+			cols = append(cols, fmt.Sprintf("    %s %s", col, override))
+			continue
+		}
+		typ, nullable, ok := columnType(f.typ, imports)
+		if !ok {
+			return fmt.Errorf("%s.%s: no Postgres type for %s, set one with a ddl tag", def.name, f.name, types.ExprString(f.typ))
+		}
+		null := "NOT NULL"
+		if nullable {
+			null = "NULL"
+		}
+		cols = append(cols, fmt.Sprintf("    %s %s %s", col, typ, null))
+	}
+	fmt.Fprintf(buf, "\nCREATE TABLE %s (\n%s\n);\n", table, strings.Join(cols, ",\n"))
+	return nil
+}
+
+// columnType returns the Postgres column type of a field of type expr and whether it is nullable.
+func columnType(expr ast.Expr, imports map[string]string) (typ string, nullable, ok bool) {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		typ, _, ok := columnType(t.X, imports)
+		return typ, true, ok
+	case *ast.SelectorExpr:
+		pkg, isIdent := t.X.(*ast.Ident)
+		if !isIdent {
+			break
+		}
+		switch imports[pkg.Name] {
+		case gureguPath:
+			if g, found := gureguTypes[t.Sel.Name]; found {
+				typ, ok = elemColumnTypes[g.elem]
+				return typ, true, ok
+			}
+		case nullablePath:
+			typ, ok = nullableColumnTypes[t.Sel.Name]
+			return typ, true, ok
+		case pgtypePath:
+			typ, ok = pgtypeColumnTypes[t.Sel.Name]
+			return typ, true, ok
+		}
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		name, args := nullableGeneric(expr, imports)
+		if name == "" {
+			break
+		}
+		elem := types.ExprString(args[0])
+		switch name {
+		case "Null", "Optional":
+			typ, ok = elemColumnTypes[elem]
+			if !ok {
+				typ, _, ok = columnType(args[0], imports)
+			}
+		case "Slice":
+			if typ, _, ok = columnType(args[0], imports); ok {
+				typ += "[]"
+			}
+		case "Range":
+			typ, ok = rangeColumnTypes[elem]
+		default:
+			typ, ok = nullableColumnTypes[name]
+		}
+		return typ, true, ok
+	}
+	typ, ok = elemColumnTypes[types.ExprString(expr)]
+	return typ, false, ok
+}
+
+// snakeCase converts a Go identifier such as OrgID or HanyupinName into org_id or hanyupin_name.
+// It matches the column names package sqlbuild derives.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])
+			if prevLower || nextLower {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		if unicode.IsDigit(r) && i > 0 && unicode.IsLetter(runes[i-1]) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// runFactories generates fixture factories for the requested structs into a separate package.
+func runFactories(args []string) error {
+	fs := flag.NewFlagSet("nullgen factories", flag.ExitOnError)
+	typeNames := fs.String("type", "", "comma-separated list of struct names; required")
+	output := fs.String("output", "fixtures/fixtures_nullgen.go", "output file name")
+	pkg := fs.String("package", "", "package name of the generated file; default the output directory name")
+	importPath := fs.String("import", "", "import path of the input package; default resolved with go list")
+	fs.Parse(args)
+
+	if *typeNames == "" {
+		return errors.New("-type is required")
+	}
+	filename, err := inputFile(fs.Args())
+	if err != nil {
+		return err
+	}
+	src, err := parseSource(filename, strings.Split(*typeNames, ","))
+	if err != nil {
+		return err
+	}
+	if *importPath == "" {
+		if *importPath, err = packagePath(filepath.Dir(filename)); err != nil {
+			return err
+		}
+	}
+	if *pkg == "" {
+		*pkg = filepath.Base(filepath.Dir(*output))
+	}
+
+	g := newGenerator(src)
+	g.pkg = *pkg
+	dtoPkg := g.usePath(*importPath)
+	g.printf("// Option customizes a DTO built by a factory of this package.\n")
+	g.printf("type Option[T any] func(*T)\n\n")
+	for _, def := range src.structs {
+		g.factory(def, dtoPkg)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(*output), 0o755); err != nil {
+		return err
+	}
+	return writeSource(*output, append(g.header(), g.buf.Bytes()...))
+}
+
+// packagePath returns the import path of the package in dir.
+func packagePath(dir string) (string, error) {
+	out, err := exec.Command("go", "list", "-f", "{{.ImportPath}}", dir).Output()
+	if err != nil {
+		return "", fmt.Errorf("resolving import path of %s, pass -import: %w", dir, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// factory emits a fully populated and a sparse factory for def.
+func (g *generator) factory(def structDef, dtoPkg string) {
+	typ := dtoPkg + "." + def.name
+
+	g.printf("// %s returns a %s with every field set to a deterministic value,\n", def.name, typ)
+	g.printf("// then applies overrides in order.\n")
+	g.printf("func %s(overrides ...Option[%s]) %s {\n", def.name, typ, typ)
+	g.printf("f := %s{\n", typ)
+	for i, f := range def.fields {
+		if expr, ok := g.fixtureValue(f, i); ok {
+			g.printf("%s: %s,\n", f.name, expr)
+		}
+	}
+	g.printf("}\n")
+	g.printf("for _, o := range overrides {\no(&f)\n}\nreturn f\n}\n\n")
+
+	g.printf("// Sparse%s returns a %s with its nullable fields null or undefined\n", def.name, typ)
+	g.printf("// and its other fields set like %s does, then applies overrides in order.\n", def.name)
+	g.printf("func Sparse%s(overrides ...Option[%s]) %s {\n", def.name, typ, typ)
+	g.printf("f := %s{\n", typ)
+	for i, f := range def.fields {
+		if fixtureFamily(f.typ, g.src.imports) != plain {
+			continue
+		}
+		if expr, ok := g.fixtureValue(f, i); ok {
+			g.printf("%s: %s,\n", f.name, expr)
+		}
+	}
+	g.printf("}\n")
+	g.printf("for _, o := range overrides {\no(&f)\n}\nreturn f\n}\n\n")
+}
+
+// fixtureFamily resolves the nullable family of expr like classify does, without
+// requiring a pgtype counterpart for the element type.
+func fixtureFamily(expr ast.Expr, imports map[string]string) family {
+	k := fixtureKind(expr, imports)
+	return k.family
+}
+
+// fixtureKind is the fieldKind of expr, leaving pg unset.
+func fixtureKind(expr ast.Expr, imports map[string]string) fieldKind {
+	switch t := expr.(type) {
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok && imports[pkg.Name] == gureguPath {
+			if g, ok := gureguTypes[t.Sel.Name]; ok {
+				return fieldKind{family: guregu, elem: g.elem, null: t.Sel.Name}
+			}
+		}
+		if pkg, ok := t.X.(*ast.Ident); ok && imports[pkg.Name] == nullablePath {
+			// Concrete nullable types such as nullable.Decimal are null when zero.
+			return fieldKind{family: nullableNull, null: t.Sel.Name}
+		}
+	case *ast.IndexExpr:
+		sel, ok := t.X.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+		if pkg, ok := sel.X.(*ast.Ident); !ok || imports[pkg.Name] != nullablePath {
+			break
+		}
+		switch sel.Sel.Name {
+		case "Null":
+			return fieldKind{family: nullableNull, elem: types.ExprString(t.Index)}
+		case "Optional":
+			return fieldKind{family: nullableOptional, elem: types.ExprString(t.Index)}
+		}
+	}
+	return fieldKind{family: plain, elem: types.ExprString(expr)}
+}
+
+// fixtureValue returns the expression populating f, the i-th field. Fields of element
+// types without a known literal are left out and keep their zero value.
+func (g *generator) fixtureValue(f fieldDef, i int) (string, bool) {
+	k := fixtureKind(f.typ, g.src.imports)
+	if k.family == nullableNull && k.null != "" {
+		sample, ok := nullableSamples[k.null]
+		if !ok {
+			return "", false
+		}
+		return g.usePath(nullablePath) + "." + sample, true
+	}
+	lit, ok := g.literal(k.elem, f.name, i)
+	if !ok {
+		return "", false
+	}
+	switch k.family {
+	case guregu:
+		return fmt.Sprintf("%s.%sFrom(%s)", g.usePath(gureguPath), k.null, lit), true
+	case nullableNull:
+		return fmt.Sprintf("%s.From(%s)", g.usePath(nullablePath), lit), true
+	case nullableOptional:
+		return fmt.Sprintf("%s.OptionalFrom(%s)", g.usePath(nullablePath), lit), true
+	}
+	return lit, true
+}
+
+// nullableSamples holds constructor calls producing a valid value of concrete nullable types,
+// for those whose values must pass a check.
+var nullableSamples = map[string]string{
+	"Uinfin": `UinfinFrom("S1234567D")`,
+}
+
+// literal returns a typed literal of elem that is distinct per field.
+func (g *generator) literal(elem, field string, i int) (string, bool) {
+	n := strconv.Itoa(i + 1)
+	switch elem {
+	case "string":
+		return strconv.Quote(field), true
+	case "int":
+		return n, true
+	case "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "byte":
+		return elem + "(" + n + ")", true
+	case "float32", "float64":
+		return elem + "(" + n + ".5)", true
+	case "bool":
+		return "true", true
+	case "time.Time":
+		t := g.usePath("time")
+		return fmt.Sprintf("%s.Date(2024, 1, %s, 0, 0, 0, 0, %s.UTC)", t, n, t), true
+	}
+	return "", false
+}
@@ -0,0 +1,109 @@
+// Command nullgen generates pgtype mirror structs for DTOs built on nullable types.
+//
+// It is meant to be invoked through go:generate:
+//
+//	//go:generate go run github.com/nadhifikbarw/x-go-painless-null/cmd/nullgen -type UinfinNamesForm
+//
+// For each requested struct it emits a Pg-prefixed mirror whose nullable fields use
+// pgtype.XxX types, plus ToPg and FromPg methods converting between the two.
+// With -clone it also emits Clone methods deep-copying the structs, including the
+// slices, maps and JSON documents held by their fields, to snapshot form state.
+//
+// The sqlc-overrides subcommand instead prints an overrides block for sqlc.yaml,
+// so sqlc-generated models use this module's nullable types instead of pgtype:
+//
+//	nullgen sqlc-overrides -indent 6 >> sqlc.yaml
+//
+// The factories subcommand emits test fixture factories into a separate package,
+// one returning a fully populated DTO and one leaving its nullable fields unset:
+//
+//	//go:generate go run github.com/nadhifikbarw/x-go-painless-null/cmd/nullgen factories -type UinfinNamesForm
+//
+//	form := fixtures.UinfinNamesForm(func(f *dtos.UinfinNamesForm) { f.Name = null.String{} })
+//
+// The ddl subcommand prints CREATE TABLE statements whose columns are NULL exactly
+// where the DTO fields are nullable, keeping migrations and DTOs from drifting apart:
+//
+//	nullgen ddl -type UinfinNamesForm -table applicant_names dtos/invidiual.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "nullgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	if len(args) > 0 {
+		switch args[0] {
+		case "sqlc-overrides":
+			return runSQLCOverrides(args[1:])
+		case "factories":
+			return runFactories(args[1:])
+		case "ddl":
+			return runDDL(args[1:])
+		}
+	}
+	fs := flag.NewFlagSet("nullgen", flag.ExitOnError)
+	typeNames := fs.String("type", "", "comma-separated list of struct names; required")
+	output := fs.String("output", "", "output file name; default <file>_nullgen.go")
+	prefix := fs.String("prefix", "Pg", "name prefix of generated mirror structs")
+	clone := fs.Bool("clone", false, "also generate deep-copying Clone methods")
+	fs.Parse(args)
+
+	if *typeNames == "" {
+		return errors.New("-type is required")
+	}
+	filename, err := inputFile(fs.Args())
+	if err != nil {
+		return err
+	}
+	src, err := parseSource(filename, strings.Split(*typeNames, ","))
+	if err != nil {
+		return err
+	}
+
+	g := newGenerator(src)
+	for _, def := range src.structs {
+		g.mirror(def, *prefix)
+	}
+	if *clone {
+		for _, def := range src.structs {
+			g.clone(def)
+		}
+	}
+
+	if *output == "" {
+		*output = strings.TrimSuffix(filename, ".go") + "_nullgen.go"
+	}
+	return writeSource(*output, append(g.header(), g.buf.Bytes()...))
+}
+
+// inputFile returns the file named on the command line, falling back to $GOFILE set by go:generate.
+func inputFile(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if f := os.Getenv("GOFILE"); f != "" {
+		return f, nil
+	}
+	return "", errors.New("no input file; pass one or run through go:generate")
+}
+
+func writeSource(filename string, src []byte) error {
+	formatted, err := format.Source(src)
+	if err != nil {
+		return fmt.Errorf("formatting generated code: %w", err)
+	}
+	return os.WriteFile(filename, formatted, 0o644)
+}
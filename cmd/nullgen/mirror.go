@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// generator accumulates generated declarations and the imports they need.
+type generator struct {
+	src     *source
+	pkg     string            // package name of the generated file
+	imports map[string]string // local name -> import path
+	buf     bytes.Buffer
+}
+
+func newGenerator(src *source) *generator {
+	return &generator{src: src, pkg: src.pkg, imports: make(map[string]string)}
+}
+
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// use records that the generated code refers to the source file's import name.
+func (g *generator) use(name string) string {
+	if p, ok := g.src.imports[name]; ok {
+		g.imports[name] = p
+	}
+	return name
+}
+
+// usePath records an import by path, reusing the source file's name for it when there is one.
+func (g *generator) usePath(p string) string {
+	for name, ip := range g.src.imports {
+		if ip == p {
+			g.imports[name] = p
+			return name
+		}
+	}
+	name := importName(p)
+	g.imports[name] = p
+	return name
+}
+
+func (g *generator) header() []byte {
+	var h bytes.Buffer
+	fmt.Fprintf(&h, "// Code generated by nullgen. DO NOT EDIT.\n\npackage %s\n\n", g.pkg)
+	if len(g.imports) > 0 {
+		names := make([]string, 0, len(g.imports))
+		for name := range g.imports {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			pi, pj := g.imports[names[i]], g.imports[names[j]]
+			if isStdlib(pi) != isStdlib(pj) {
+				return isStdlib(pi)
+			}
+			return pi < pj
+		})
+		h.WriteString("import (\n")
+		for i, name := range names {
+			if i > 0 && isStdlib(g.imports[names[i-1]]) && !isStdlib(g.imports[name]) {
+				h.WriteString("\n")
+			}
+			if importName(g.imports[name]) == name {
+				fmt.Fprintf(&h, "%q\n", g.imports[name])
+			} else {
+				fmt.Fprintf(&h, "%s %q\n", name, g.imports[name])
+			}
+		}
+		h.WriteString(")\n\n")
+	}
+	return h.Bytes()
+}
+
+// isStdlib reports whether p looks like a standard library import path.
+func isStdlib(p string) bool {
+	first, _, _ := strings.Cut(p, "/")
+	return !strings.Contains(first, ".")
+}
+
+// mirror emits a pgtype mirror struct for def along with ToPg and FromPg methods.
+func (g *generator) mirror(def structDef, prefix string) {
+	name := prefix + def.name
+	pgtype := g.usePath(pgtypePath)
+
+	g.printf("// %s mirrors %s using pgtype types.\n", name, def.name)
+	g.printf("type %s struct {\n", name)
+	for _, f := range def.fields {
+		k := classify(f.typ, g.src.imports)
+		typ := types.ExprString(f.typ)
+		if k.family == plain {
+			for _, pkg := range usedPackages(f.typ) {
+				g.use(pkg)
+			}
+		} else {
+			typ = pgtype + "." + k.pg.typ
+		}
+		if f.tag != "" {
+			g.printf("%s %s `%s`\n", f.name, typ, f.tag)
+		} else {
+			g.printf("%s %s\n", f.name, typ)
+		}
+	}
+	g.printf("}\n\n")
+
+	g.printf("// ToPg converts f into %s.\n", name)
+	g.printf("func (f %s) ToPg() %s {\n", def.name, name)
+	g.printf("return %s{\n", name)
+	for _, f := range def.fields {
+		k := classify(f.typ, g.src.imports)
+		g.printf("%s: %s,\n", f.name, g.toPg(k, "f."+f.name, pgtype))
+	}
+	g.printf("}\n}\n\n")
+
+	g.printf("// FromPg populates f from %s.\n", name)
+	g.printf("func (f *%s) FromPg(pg %s) {\n", def.name, name)
+	for _, f := range def.fields {
+		k := classify(f.typ, g.src.imports)
+		g.printf("f.%s = %s\n", f.name, g.fromPg(k, "pg."+f.name, pgtype))
+	}
+	g.printf("}\n\n")
+}
+
+func (g *generator) toPg(k fieldKind, expr, pgtype string) string {
+	switch k.family {
+	case guregu:
+		return fmt.Sprintf("%s.%s{%s: %s.%s, Valid: %s.Valid}", pgtype, k.pg.typ, k.pg.field, expr, gureguTypes[k.null].field, expr)
+	case nullableNull:
+		return fmt.Sprintf("%s.%s{%s: %s.V, Valid: %s.Valid}", pgtype, k.pg.typ, k.pg.field, expr, expr)
+	case nullableOptional:
+		return fmt.Sprintf("%s.%s{%s: %s.V, Valid: %s.IsPresent()}", pgtype, k.pg.typ, k.pg.field, expr, expr)
+	}
+	return expr
+}
+
+func (g *generator) fromPg(k fieldKind, expr, pgtype string) string {
+	if k.family == plain {
+		return expr
+	}
+	valid := expr + ".Valid"
+	if k.pg.typ == "Timestamptz" {
+		valid = fmt.Sprintf("%s.Valid && %s.InfinityModifier == %s.Finite", expr, expr, pgtype)
+	}
+	switch k.family {
+	case guregu:
+		return fmt.Sprintf("%s.New%s(%s.%s, %s)", g.usePath(gureguPath), k.null, expr, k.pg.field, valid)
+	case nullableNull:
+		return fmt.Sprintf("%s.New(%s.%s, %s)", g.usePath(nullablePath), expr, k.pg.field, valid)
+	case nullableOptional:
+		pkg := g.usePath(nullablePath)
+		return fmt.Sprintf("%s.OptionalOf(%s.New(%s.%s, %s))", pkg, pkg, expr, k.pg.field, valid)
+	}
+	return expr
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// source holds the structs nullgen was asked to process, along with the
+// imports of the file they were declared in.
+type source struct {
+	pkg     string
+	imports map[string]string // local name -> import path
+	structs []structDef
+}
+
+type structDef struct {
+	name   string
+	fields []fieldDef
+}
+
+type fieldDef struct {
+	name string
+	typ  ast.Expr
+	tag  string
+}
+
+func (f fieldDef) tagValue(key string) string {
+	return reflect.StructTag(f.tag).Get(key)
+}
+
+// parseSource reads filename and collects the named struct types, preserving the order requested.
+func parseSource(filename string, names []string) (*source, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	src := &source{pkg: file.Name.Name, imports: make(map[string]string)}
+	for _, imp := range file.Imports {
+		p, _ := strconv.Unquote(imp.Path.Value)
+		name := importName(p)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		src.imports[name] = p
+	}
+
+	found := make(map[string]structDef)
+	ast.Inspect(file, func(n ast.Node) bool {
+		ts, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		st, ok := ts.Type.(*ast.StructType)
+		if !ok {
+			return false
+		}
+		def := structDef{name: ts.Name.Name}
+		for _, f := range st.Fields.List {
+			var tag string
+			if f.Tag != nil {
+				tag, _ = strconv.Unquote(f.Tag.Value)
+			}
+			for _, name := range f.Names {
+				if name.IsExported() {
+					def.fields = append(def.fields, fieldDef{name: name.Name, typ: f.Type, tag: tag})
+				}
+			}
+		}
+		found[def.name] = def
+		return false
+	})
+
+	for _, name := range names {
+		def, ok := found[name]
+		if !ok {
+			return nil, fmt.Errorf("struct %s not found in %s", name, filename)
+		}
+		src.structs = append(src.structs, def)
+	}
+	return src, nil
+}
+
+// importName guesses the package name of an import path, skipping major version suffixes.
+func importName(p string) string {
+	base := path.Base(p)
+	if len(base) > 1 && base[0] == 'v' && strings.Trim(base[1:], "0123456789") == "" {
+		base = path.Base(path.Dir(p))
+	}
+	return base
+}
+
+// usedPackages returns the local package names referenced by expr.
+func usedPackages(expr ast.Expr) []string {
+	var names []string
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok {
+				names = append(names, id.Name)
+			}
+			return false
+		}
+		return true
+	})
+	return names
+}
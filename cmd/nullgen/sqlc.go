@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// sqlcOverride maps a nullable Postgres column type to a type of the nullable package.
+type sqlcOverride struct {
+	dbType string
+	goType string
+}
+
+// sqlcOverrides lists the column types nullgen knows, under every name sqlc may report for them.
+// Timestamps map to nullable.Time rather than Null[time.Time], so generated models
+// do not need an import of package time that sqlc would not add.
+var sqlcOverrides = []sqlcOverride{
+	{"text", "Null[string]"},
+	{"pg_catalog.varchar", "Null[string]"},
+	{"pg_catalog.bpchar", "Null[string]"},
+	{"citext", "Null[string]"},
+	{"pg_catalog.int2", "Null[int16]"},
+	{"pg_catalog.int4", "Null[int32]"},
+	{"pg_catalog.int8", "Null[int64]"},
+	{"pg_catalog.float4", "Null[float32]"},
+	{"pg_catalog.float8", "Null[float64]"},
+	{"pg_catalog.bool", "Null[bool]"},
+	{"pg_catalog.numeric", "Decimal"},
+	{"pg_catalog.timestamp", "Time"},
+	{"timestamp", "Time"},
+	{"pg_catalog.timestamptz", "Time"},
+	{"timestamptz", "Time"},
+	{"date", "Date"},
+	{"uuid", "UUID"},
+	{"json", "JSON"},
+	{"pg_catalog.json", "JSON"},
+	{"jsonb", "JSON"},
+	{"pg_catalog.jsonb", "JSON"},
+}
+
+// runSQLCOverrides implements the sqlc-overrides subcommand, which prints an overrides
+// block for sqlc.yaml making sqlc generate nullable types for nullable columns.
+func runSQLCOverrides(args []string) error {
+	fs := flag.NewFlagSet("nullgen sqlc-overrides", flag.ExitOnError)
+	output := fs.String("output", "", "output file name; default standard output")
+	indent := fs.Int("indent", 0, "number of spaces to indent the block by, to nest it in an existing sqlc.yaml")
+	fs.Parse(args)
+
+	pad := bytes.Repeat([]byte(" "), *indent)
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s# Code generated by nullgen sqlc-overrides. DO NOT EDIT.\n", pad)
+	fmt.Fprintf(&buf, "%soverrides:\n", pad)
+	for _, o := range sqlcOverrides {
+		fmt.Fprintf(&buf, "%s  - db_type: %q\n", pad, o.dbType)
+		fmt.Fprintf(&buf, "%s    nullable: true\n", pad)
+		fmt.Fprintf(&buf, "%s    go_type:\n", pad)
+		fmt.Fprintf(&buf, "%s      import: %q\n", pad, nullablePath)
+		fmt.Fprintf(&buf, "%s      package: \"nullable\"\n", pad)
+		fmt.Fprintf(&buf, "%s      type: %q\n", pad, o.goType)
+	}
+
+	if *output == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(*output, buf.Bytes(), 0o644)
+}
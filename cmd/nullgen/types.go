@@ -0,0 +1,98 @@
+package main
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+const (
+	gureguPath   = "github.com/guregu/null/v6"
+	nullablePath = "github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+	pgtypePath   = "github.com/jackc/pgx/v5/pgtype"
+)
+
+// family tells how a field's nullability is represented.
+type family int
+
+const (
+	plain family = iota
+	guregu
+	nullableNull
+	nullableOptional
+)
+
+// pgMapping describes the pgtype counterpart of a nullable Go type.
+type pgMapping struct {
+	typ   string // pgtype type name, e.g. Text
+	field string // pgtype value field, e.g. String
+}
+
+// fieldKind is the resolved shape of a struct field type.
+type fieldKind struct {
+	family family
+	elem   string // Go element type, e.g. string or time.Time
+	null   string // guregu type name, e.g. String
+	pg     pgMapping
+}
+
+var gureguTypes = map[string]struct {
+	field string
+	elem  string
+}{
+	"String": {"String", "string"},
+	"Int":    {"Int64", "int64"},
+	"Int64":  {"Int64", "int64"},
+	"Int32":  {"Int32", "int32"},
+	"Int16":  {"Int16", "int16"},
+	"Float":  {"Float64", "float64"},
+	"Bool":   {"Bool", "bool"},
+	"Time":   {"Time", "time.Time"},
+}
+
+var pgMappings = map[string]pgMapping{
+	"string":    {"Text", "String"},
+	"int64":     {"Int8", "Int64"},
+	"int32":     {"Int4", "Int32"},
+	"int16":     {"Int2", "Int16"},
+	"float64":   {"Float8", "Float64"},
+	"float32":   {"Float4", "Float32"},
+	"bool":      {"Bool", "Bool"},
+	"time.Time": {"Timestamptz", "Time"},
+}
+
+// classify resolves the nullable family of expr using the imports of the source file.
+func classify(expr ast.Expr, imports map[string]string) fieldKind {
+	switch t := expr.(type) {
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok || imports[pkg.Name] != gureguPath {
+			break
+		}
+		g, ok := gureguTypes[t.Sel.Name]
+		if !ok {
+			break
+		}
+		return fieldKind{family: guregu, elem: g.elem, null: t.Sel.Name, pg: pgMappings[g.elem]}
+	case *ast.IndexExpr:
+		sel, ok := t.X.(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+		pkg, ok := sel.X.(*ast.Ident)
+		if !ok || imports[pkg.Name] != nullablePath {
+			break
+		}
+		elem := types.ExprString(t.Index)
+		pg, ok := pgMappings[elem]
+		if !ok {
+			break
+		}
+		switch sel.Sel.Name {
+		case "Null":
+			return fieldKind{family: nullableNull, elem: elem, pg: pg}
+		case "Optional":
+			return fieldKind{family: nullableOptional, elem: elem, pg: pg}
+		}
+	}
+	return fieldKind{family: plain, elem: types.ExprString(expr)}
+}
@@ -0,0 +1,234 @@
+// Package nullcheck defines an analyzer reporting the two most common misuses of
+// nullable values: reading the value of a nullable without checking Valid first, and
+// struct literals setting a value but forgetting Valid: true, which silently produce null.
+//
+// Nullable types are the struct types with a Valid field from database/sql, pgtype,
+// guregu/null (but not its zero package, where zero values are null) and package nullable.
+package nullcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// Analyzer reports unchecked reads and incomplete literals of nullable types.
+//
+// A read of a value field such as s.String is considered checked if s.Valid, or a
+// method such as s.IsZero() telling null apart, appears earlier in the same function
+// or in the same statement, as when copying both with New(s.String, s.Valid).
+// Writes and addresses taken, such as scan targets, are not reads.
+var Analyzer = &analysis.Analyzer{
+	Name:     "nullcheck",
+	Doc:      "report nullable values read without checking Valid and literals missing Valid: true",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// nullablePackages lists the import paths whose struct types with a Valid field are checked.
+var nullablePackages = []string{
+	"database/sql",
+	"github.com/jackc/pgx/v5/pgtype",
+	"github.com/guregu/null",
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable",
+}
+
+// stateFields are the fields of nullable types that carry their state rather than their value.
+var stateFields = map[string]bool{"Valid": true, "Defined": true}
+
+// guardNames are the selectors telling null apart from a value.
+var guardNames = map[string]bool{"Valid": true, "IsZero": true, "IsNull": true, "IsSet": true, "IsPresent": true}
+
+func run(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.Preorder([]ast.Node{(*ast.FuncDecl)(nil), (*ast.CompositeLit)(nil)}, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			if n.Body != nil {
+				checkReads(pass, n.Body)
+			}
+		case *ast.CompositeLit:
+			checkLiteral(pass, n)
+		}
+	})
+	return nil, nil
+}
+
+// IsNullable reports whether t is a nullable type from another package than pkg,
+// whose struct has a boolean Valid field.
+func IsNullable(t types.Type, pkg *types.Package) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg() == pkg {
+		return false
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return false
+	}
+	path := named.Obj().Pkg().Path()
+	known := false
+	for _, p := range nullablePackages {
+		if path == p || strings.HasPrefix(path, p+"/v") {
+			known = true
+		}
+	}
+	if !known || strings.HasSuffix(path, "/zero") {
+		return false
+	}
+	obj, _, _ := types.LookupFieldOrMethod(named, false, named.Obj().Pkg(), "Valid")
+	v, ok := obj.(*types.Var)
+	return ok && v.IsField() && types.Identical(v.Type(), types.Typ[types.Bool])
+}
+
+// checkReads reports the unchecked value reads in body.
+func checkReads(pass *analysis.Pass, body *ast.BlockStmt) {
+	skip := make(map[*ast.SelectorExpr]bool) // writes and addresses taken
+	guards := make(map[string][]token.Pos)   // rendered operand -> guard positions
+	type read struct {
+		se   *ast.SelectorExpr
+		stmt ast.Node // innermost statement holding the read
+	}
+	var reads []read
+	var stmts []ast.Node
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			stmts = stmts[:len(stmts)-1]
+			return true
+		}
+		stmts = append(stmts, currentStmt(n, stmts))
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if se, ok := ast.Unparen(lhs).(*ast.SelectorExpr); ok {
+					skip[se] = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if se, ok := ast.Unparen(n.X).(*ast.SelectorExpr); ok {
+				skip[se] = true
+			}
+		case *ast.UnaryExpr:
+			if se, ok := ast.Unparen(n.X).(*ast.SelectorExpr); ok && n.Op == token.AND {
+				skip[se] = true
+			}
+		case *ast.SelectorExpr:
+			name := n.Sel.Name
+			if guardNames[name] {
+				for _, key := range guardKeys(pass, n) {
+					guards[key] = append(guards[key], n.Pos())
+				}
+				return true
+			}
+			t := deref(pass.TypesInfo.TypeOf(n.X))
+			if t == nil || !IsNullable(t, pass.Pkg) || stateFields[name] {
+				return true
+			}
+			if sel := pass.TypesInfo.Selections[n]; sel != nil && sel.Kind() == types.FieldVal {
+				reads = append(reads, read{n, stmts[len(stmts)-1]})
+			}
+		}
+		return true
+	})
+
+	for _, r := range reads {
+		se := r.se
+		if skip[se] || guarded(guards[types.ExprString(se.X)], se.Pos(), r.stmt) {
+			continue
+		}
+		pass.ReportRangef(se, "%s read without checking %s.Valid first", types.ExprString(se), types.ExprString(se.X))
+	}
+}
+
+// guardKeys returns the rendered operands checked by the guard se: its operand and, when
+// the guard is promoted from embedded fields, each embedded field on the way, so that
+// b.Valid also checks b.Bool for a b embedding pgtype.Bool.
+func guardKeys(pass *analysis.Pass, se *ast.SelectorExpr) []string {
+	key := types.ExprString(se.X)
+	keys := []string{key}
+	sel := pass.TypesInfo.Selections[se]
+	if sel == nil {
+		return keys
+	}
+	t := sel.Recv()
+	for _, i := range sel.Index()[:len(sel.Index())-1] {
+		st, ok := deref(t).Underlying().(*types.Struct)
+		if !ok {
+			break
+		}
+		f := st.Field(i)
+		key += "." + f.Name()
+		keys = append(keys, key)
+		t = f.Type()
+	}
+	return keys
+}
+
+func deref(t types.Type) types.Type {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem()
+	}
+	return t
+}
+
+// currentStmt returns n if it is a statement, or else the innermost statement
+// on stmts, the statements enclosing n.
+func currentStmt(n ast.Node, stmts []ast.Node) ast.Node {
+	if _, ok := n.(ast.Stmt); ok || len(stmts) == 0 {
+		return n
+	}
+	return stmts[len(stmts)-1]
+}
+
+// guarded reports whether one of guards precedes pos or lies within stmt.
+func guarded(guards []token.Pos, pos token.Pos, stmt ast.Node) bool {
+	for _, g := range guards {
+		if g < pos || stmt.Pos() <= g && g < stmt.End() {
+			return true
+		}
+	}
+	return false
+}
+
+// checkLiteral reports keyed literals of nullable types setting a value without Valid,
+// and Optional literals setting Valid without Defined.
+func checkLiteral(pass *analysis.Pass, lit *ast.CompositeLit) {
+	t := pass.TypesInfo.TypeOf(lit)
+	if t == nil || !IsNullable(t, pass.Pkg) {
+		return
+	}
+	keys := make(map[string]bool)
+	value := false
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return // unkeyed literals are left to go vet's composites check
+		}
+		id, ok := kv.Key.(*ast.Ident)
+		if !ok {
+			return
+		}
+		keys[id.Name] = true
+		// Embedded nullables such as null.String's sql.NullString are checked on their own.
+		if !stateFields[id.Name] && !IsNullable(pass.TypesInfo.TypeOf(kv.Value), pass.Pkg) {
+			value = true
+		}
+	}
+	name := types.TypeString(t, func(p *types.Package) string { return p.Name() })
+	if value && !keys["Valid"] {
+		pass.ReportRangef(lit, "%s literal sets a value but not Valid: true, so it is null", name)
+		return
+	}
+	if keys["Valid"] && !keys["Defined"] && hasField(t, "Defined") {
+		pass.ReportRangef(lit, "%s literal sets Valid but not Defined: true, so it is undefined", name)
+	}
+}
+
+func hasField(t types.Type, name string) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, false, nil, name)
+	v, ok := obj.(*types.Var)
+	return ok && v.IsField()
+}
@@ -0,0 +1,59 @@
+package a
+
+import (
+	"database/sql"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+func guarded(s sql.NullString, n nullable.Null[int32]) (string, int32) {
+	if !s.Valid {
+		return "", 0
+	}
+	if n.IsZero() {
+		return s.String, 0
+	}
+	return s.String, n.V
+}
+
+func unguarded(s sql.NullString, p *nullable.Optional[string]) string {
+	_ = p.V         // want `p.V read without checking p.Valid first`
+	return s.String // want `s.String read without checking s.Valid first`
+}
+
+func guardedAfter(s sql.NullInt64) int64 {
+	v := s.Int64 // want `s.Int64 read without checking s.Valid first`
+	if s.Valid {
+		return v
+	}
+	return 0
+}
+
+func sameStatement(s sql.NullBool) nullable.Null[bool] {
+	return nullable.Null[bool]{V: s.Bool, Valid: s.Valid}
+}
+
+func writes(rows *sql.Rows) (s sql.NullString, err error) {
+	s.String = "x"
+	err = rows.Scan(&s.String, &s.Valid)
+	return
+}
+
+// Bool embeds sql.NullBool, whose Valid is promoted and checks b.NullBool.
+type Bool struct{ sql.NullBool }
+
+func promoted(b Bool) bool {
+	return b.Valid && b.NullBool.Bool
+}
+
+func literals() []any {
+	return []any{
+		sql.NullString{String: "x"}, // want `sql.NullString literal sets a value but not Valid: true, so it is null`
+		sql.NullString{String: "x", Valid: true},
+		sql.NullString{},
+		nullable.Null[int32]{V: 1},                     // want `nullable.Null\[int32\] literal sets a value but not Valid: true, so it is null`
+		nullable.Optional[string]{V: "x", Valid: true}, // want `nullable.Optional\[string\] literal sets Valid but not Defined: true, so it is undefined`
+		nullable.Optional[string]{V: "x", Valid: true, Defined: true},
+		nullable.Optional[string]{Defined: true},
+	}
+}
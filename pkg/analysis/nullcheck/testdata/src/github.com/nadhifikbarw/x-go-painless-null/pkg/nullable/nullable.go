@@ -0,0 +1,17 @@
+// Package nullable is a stub of the real package, declaring the types nullcheck checks.
+package nullable
+
+type Null[T comparable] struct {
+	V     T
+	Valid bool
+}
+
+func (n Null[T]) IsZero() bool { return !n.Valid }
+
+type Optional[T comparable] struct {
+	V       T
+	Valid   bool
+	Defined bool
+}
+
+func (o Optional[T]) IsZero() bool { return !o.Valid }
@@ -0,0 +1,48 @@
+package a
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/pgargs"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/sqlbuild"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/sqlxmap"
+)
+
+type patch struct {
+	Name  nullable.Optional[string] `db:"name"`
+	Email nullable.Optional[string] `db:"email"`
+}
+
+type fullPatch struct {
+	Name  nullable.Optional[string] `db:"name"`
+	Email sql.NullString            `db:"email"`
+	Age   nullable.Null[int32]      `db:"age"`
+	Notes string                    `db:"-"`
+	patch
+	internal string
+}
+
+func builders(p patch, f *fullPatch) {
+	sqlbuild.UpdateSet("people", p)
+	sqlbuild.UpdateSet("people", f)                           // want `sqlbuild.UpdateSet writes every field of a.fullPatch that cannot be undefined, overwriting columns the client did not send: Email sql.NullString, Age nullable.Null\[int32\]; use nullable.Optional`
+	sqlbuild.UpdateSetWithVersion("people", *f, "version", 1) // want `sqlbuild.UpdateSetWithVersion writes every field of a.fullPatch`
+	pgargs.FromStruct(f)                                      // want `pgargs.FromStruct writes every field of a.fullPatch`
+	sqlxmap.NamedArgs(struct{ Name string }{})                // want `sqlxmap.NamedArgs writes every field of struct\{Name string\} that cannot be undefined, overwriting columns the client did not send: Name string`
+	sqlxmap.NamedArgs(p)
+}
+
+type conn struct{}
+
+func (conn) Exec(ctx context.Context, sql string, args ...any) (int64, error) { return 0, nil }
+
+func execs(ctx context.Context, c conn, f fullPatch, args []any) {
+	c.Exec(ctx, "UPDATE people SET name = $1, email = $2 WHERE id = $3", f.Name, f.Email, 1) // want `f.Email is a sql.NullString, which cannot tell a field the client did not send from null, so this UPDATE overwrites the column; use nullable.Optional`
+	c.Exec(ctx, "  update people SET age = $1", f.Age)                                       // want `f.Age is a nullable.Null\[int32\]`
+	c.Exec(ctx, "INSERT INTO people (email) VALUES ($1)", f.Email)
+	c.Exec(ctx, "UPDATE people SET notes = $1", f.Notes)
+	c.Exec(ctx, "UPDATE people SET email = $1", args...)
+	var db *sql.DB
+	db.ExecContext(ctx, "UPDATE people SET email = $1", f.Email)
+}
@@ -0,0 +1,15 @@
+// Package nullable is a stub of the real package, declaring the types zerowrite checks.
+package nullable
+
+type Null[T comparable] struct {
+	V     T
+	Valid bool
+}
+
+type Optional[T comparable] struct {
+	V       T
+	Valid   bool
+	Defined bool
+}
+
+func (o Optional[T]) IsDefined() bool { return o.Defined }
@@ -0,0 +1,4 @@
+// Package pgargs is a stub of the real package, declaring the functions zerowrite checks.
+package pgargs
+
+func FromStruct(dto any) map[string]any { return nil }
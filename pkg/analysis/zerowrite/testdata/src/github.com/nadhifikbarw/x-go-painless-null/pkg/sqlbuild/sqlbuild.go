@@ -0,0 +1,8 @@
+// Package sqlbuild is a stub of the real package, declaring the builders zerowrite checks.
+package sqlbuild
+
+func UpdateSet(table string, dto any) (sql string, args []any) { return "", nil }
+
+func UpdateSetWithVersion(table string, dto any, versionCol string, expectedVersion int64) (sql string, args []any) {
+	return "", nil
+}
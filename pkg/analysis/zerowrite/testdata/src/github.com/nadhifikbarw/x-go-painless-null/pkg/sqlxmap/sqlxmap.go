@@ -0,0 +1,4 @@
+// Package sqlxmap is a stub of the real package, declaring the functions zerowrite checks.
+package sqlxmap
+
+func NamedArgs(dto any) map[string]any { return nil }
@@ -0,0 +1,143 @@
+// Package zerowrite defines an analyzer reporting DTOs that silently overwrite columns
+// in updates. UPDATE builders skip undefined fields, but fields that cannot be undefined,
+// such as plain pgtype.Text or string, are always written, so a field the client never
+// sent overwrites its column with NULL or an empty string.
+package zerowrite
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/analysis/nullcheck"
+)
+
+// Analyzer reports updates written from fields that cannot be undefined.
+//
+// It checks the DTOs passed to sqlbuild.UpdateSet, sqlbuild.UpdateSetWithVersion,
+// pgargs.FromStruct and sqlxmap.NamedArgs, and the nullable struct fields passed as
+// arguments of Exec calls running a constant UPDATE statement, as with pgx.Conn.Exec.
+var Analyzer = &analysis.Analyzer{
+	Name:     "zerowrite",
+	Doc:      "report updates writing DTO fields that cannot be undefined",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+const modulePath = "github.com/nadhifikbarw/x-go-painless-null/pkg/"
+
+// dtoFuncs maps the functions writing every defined field of a DTO to the index of that argument.
+var dtoFuncs = map[string]int{
+	modulePath + "sqlbuild.UpdateSet":            1,
+	modulePath + "sqlbuild.UpdateSetWithVersion": 1,
+	modulePath + "pgargs.FromStruct":             0,
+	modulePath + "sqlxmap.NamedArgs":             0,
+}
+
+// definer is the interface of types telling undefined apart from null, such as nullable.Optional.
+var definer = types.NewInterfaceType([]*types.Func{
+	types.NewFunc(0, nil, "IsDefined", types.NewSignatureType(nil, nil, nil, nil,
+		types.NewTuple(types.NewParam(0, nil, "", types.Typ[types.Bool])), false)),
+}, nil).Complete()
+
+func run(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok {
+			return
+		}
+		if i, ok := dtoFuncs[fn.FullName()]; ok && i < len(call.Args) {
+			checkDTO(pass, call, fn, call.Args[i])
+			return
+		}
+		if fn.Name() == "Exec" && isExecSignature(fn) {
+			checkExec(pass, call)
+		}
+	})
+	return nil, nil
+}
+
+// checkDTO reports the fields of the DTO passed as arg to fn that cannot be undefined.
+func checkDTO(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func, arg ast.Expr) {
+	t := pass.TypesInfo.TypeOf(arg)
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return
+	}
+	var fields []string
+	for i := range st.NumFields() {
+		f := st.Field(i)
+		if !f.Exported() || f.Embedded() || reflect.StructTag(st.Tag(i)).Get("db") == "-" {
+			continue
+		}
+		if !canBeUndefined(f.Type()) {
+			fields = append(fields, f.Name()+" "+types.TypeString(f.Type(), qualifier))
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+	pass.ReportRangef(arg, "%s.%s writes every field of %s that cannot be undefined, overwriting columns the client did not send: %s; use nullable.Optional",
+		fn.Pkg().Name(), fn.Name(), types.TypeString(t, qualifier), strings.Join(fields, ", "))
+}
+
+// checkExec reports nullable struct fields that cannot be undefined passed to an Exec
+// call running a constant UPDATE statement.
+func checkExec(pass *analysis.Pass, call *ast.CallExpr) {
+	if len(call.Args) < 3 || call.Ellipsis.IsValid() {
+		return
+	}
+	tv := pass.TypesInfo.Types[call.Args[1]]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		return
+	}
+	stmt := strings.TrimSpace(constant.StringVal(tv.Value))
+	if len(stmt) < 6 || !strings.EqualFold(stmt[:6], "UPDATE") {
+		return
+	}
+	for _, arg := range call.Args[2:] {
+		se, ok := ast.Unparen(arg).(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		if sel := pass.TypesInfo.Selections[se]; sel == nil || sel.Kind() != types.FieldVal {
+			continue
+		}
+		t := pass.TypesInfo.TypeOf(se)
+		if nullcheck.IsNullable(t, pass.Pkg) && !canBeUndefined(t) {
+			pass.ReportRangef(arg, "%s is a %s, which cannot tell a field the client did not send from null, so this UPDATE overwrites the column; use nullable.Optional",
+				types.ExprString(se), types.TypeString(t, qualifier))
+		}
+	}
+}
+
+// isExecSignature reports whether fn is shaped like pgx's Exec(ctx, sql string, args ...any).
+func isExecSignature(fn *types.Func) bool {
+	sig := fn.Type().(*types.Signature)
+	params := sig.Params()
+	if !sig.Variadic() || params.Len() != 3 {
+		return false
+	}
+	basic, ok := params.At(1).Type().(*types.Basic)
+	return ok && basic.Kind() == types.String
+}
+
+func canBeUndefined(t types.Type) bool {
+	return types.Implements(t, definer)
+}
+
+func qualifier(p *types.Package) string {
+	return p.Name()
+}
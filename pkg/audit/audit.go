@@ -0,0 +1,84 @@
+// Package audit turns changes between two versions of a nullable DTO into audit events,
+// one per changed field, ready to be inserted into an audit table.
+//
+// Run DTOs through package redact first if they carry sensitive fields.
+package audit
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Action describes how a field changed.
+type Action string
+
+const (
+	// ActionSet means the field went from null to a value.
+	ActionSet Action = "set"
+	// ActionCleared means the field went from a value to null.
+	ActionCleared Action = "cleared"
+	// ActionChanged means the field went from one value to another.
+	ActionChanged Action = "changed"
+)
+
+// Event records the change of a single field. Old and New hold the values in text
+// form and are null on the null side of a set or cleared transition.
+type Event struct {
+	Field  string                `db:"field" json:"field"`
+	Action Action                `db:"action" json:"action"`
+	Old    nullable.Null[string] `db:"old_value" json:"old"`
+	New    nullable.Null[string] `db:"new_value" json:"new"`
+	Actor  string                `db:"actor" json:"actor"`
+	At     time.Time             `db:"changed_at" json:"at"`
+}
+
+// Events compares oldDTO and newDTO with forms.Diff and returns an event for every
+// changed field, sorted by field name. Fields undefined on either side are not reported.
+func Events(oldDTO, newDTO any, actor string, at time.Time) ([]Event, error) {
+	changes, err := forms.Diff(oldDTO, newDTO)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]Event, 0, len(changes))
+	for _, field := range changes.Fields() {
+		c := changes[field]
+		action := ActionChanged
+		switch {
+		case c.Set():
+			action = ActionSet
+		case c.Cleared():
+			action = ActionCleared
+		}
+		events = append(events, Event{
+			Field:  field,
+			Action: action,
+			Old:    render(c.Old),
+			New:    render(c.New),
+			Actor:  actor,
+			At:     at,
+		})
+	}
+	return events, nil
+}
+
+// render formats a driver value as text, nil being null.
+func render(v driver.Value) nullable.Null[string] {
+	switch v := v.(type) {
+	case nil:
+		return nullable.Null[string]{}
+	case string:
+		return nullable.From(v)
+	case []byte:
+		return nullable.From(string(v))
+	case time.Time:
+		return nullable.From(v.Format(time.RFC3339Nano))
+	case float64:
+		return nullable.From(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return nullable.From(fmt.Sprint(v))
+}
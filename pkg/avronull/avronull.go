@@ -0,0 +1,126 @@
+// Package avronull derives Avro schemas from nullable DTOs and encodes them in
+// Avro's binary format, for publishing form changes to Avro-based topics.
+//
+// Fields of nullable types and pointers become ["null", T] unions defaulting to
+// null, other fields plain Avro types. Avro has no notion of undefined: undefined
+// fields are encoded as null and decoded as explicit nulls. Fields are named after
+// the `avro` tag or the snake_cased field name; `avro:"-"` skips a field.
+//
+// Marshal produces the bare record encoding; framing such as a schema registry
+// header is left to the producer.
+package avronull
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// field describes how a struct field maps to an Avro record field.
+type field struct {
+	name     string
+	index    []int
+	kind     string // Avro primitive type
+	micros   bool   // long holding timestamp-micros
+	optional bool
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+func fields(t reflect.Type) ([]field, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("avronull: %s is not a struct", t)
+	}
+	var out []field
+	for _, f := range nullreflect.Fields(t) {
+		tag := f.Tag.Get("avro")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = nullreflect.SnakeCase(f.Name)
+		}
+		elem, optional := f.Type, false
+		if inner, ok := nullreflect.Inner(f.Type); ok {
+			elem, optional = inner, true
+		} else if f.Type.Kind() == reflect.Pointer {
+			elem, optional = f.Type.Elem(), true
+		}
+		kind, err := avroType(elem)
+		if err != nil {
+			return nil, fmt.Errorf("avronull: field %s: %w", f.Name, err)
+		}
+		out = append(out, field{name: name, index: f.Index, kind: kind, micros: elem == timeType, optional: optional})
+	}
+	return out, nil
+}
+
+// avroType returns the Avro primitive type holding values of t.
+func avroType(t reflect.Type) (string, error) {
+	if t == timeType {
+		return "long", nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string", nil
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return "int", nil
+	case reflect.Int, reflect.Int64, reflect.Uint32:
+		return "long", nil
+	case reflect.Float32:
+		return "float", nil
+	case reflect.Float64:
+		return "double", nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes", nil
+		}
+	}
+	return "", fmt.Errorf("unsupported type %s", t)
+}
+
+// SchemaFor returns the Avro schema of T, a struct, as JSON. The record is named
+// after T and namespaced with namespace, which may be empty.
+func SchemaFor[T any](namespace string) (string, error) {
+	t := reflect.TypeFor[T]()
+	fs, err := fields(t)
+	if err != nil {
+		return "", err
+	}
+	type schemaField struct {
+		Name    string `json:"name"`
+		Type    any    `json:"type"`
+		Default any    `json:"default,omitempty"`
+	}
+	type record struct {
+		Type      string        `json:"type"`
+		Name      string        `json:"name"`
+		Namespace string        `json:"namespace,omitempty"`
+		Fields    []schemaField `json:"fields"`
+	}
+	r := record{Type: "record", Name: t.Name(), Namespace: namespace, Fields: []schemaField{}}
+	for _, f := range fs {
+		var typ any = f.kind
+		if f.micros {
+			typ = map[string]string{"type": "long", "logicalType": "timestamp-micros"}
+		}
+		sf := schemaField{Name: f.name, Type: typ}
+		if f.optional {
+			sf.Type = []any{"null", typ}
+			sf.Default = json.RawMessage("null")
+		}
+		r.Fields = append(r.Fields, sf)
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("avronull: %w", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,171 @@
+package avronull
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+var errShort = errors.New("unexpected end of data")
+
+// Marshal encodes v, a struct or pointer to one, in Avro's binary format
+// following the schema SchemaFor returns for its type.
+func Marshal(v any) ([]byte, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	fs, err := fields(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	var b []byte
+	for _, f := range fs {
+		dv, state, err := nullreflect.Read(rv.FieldByIndex(f.index))
+		if err != nil {
+			return nil, fmt.Errorf("avronull: field %s: %w", f.name, err)
+		}
+		if f.optional {
+			if state != nullable.StatePresent {
+				b = binary.AppendVarint(b, 0)
+				continue
+			}
+			b = binary.AppendVarint(b, 1)
+		} else if state != nullable.StatePresent {
+			return nil, fmt.Errorf("avronull: field %s: null value for non-nullable field", f.name)
+		}
+		if b, err = appendValue(b, f, dv); err != nil {
+			return nil, fmt.Errorf("avronull: field %s: %w", f.name, err)
+		}
+	}
+	return b, nil
+}
+
+func appendValue(b []byte, f field, dv any) ([]byte, error) {
+	switch x := dv.(type) {
+	case string:
+		if f.kind == "string" {
+			b = binary.AppendVarint(b, int64(len(x)))
+			return append(b, x...), nil
+		}
+	case []byte:
+		if f.kind == "bytes" {
+			b = binary.AppendVarint(b, int64(len(x)))
+			return append(b, x...), nil
+		}
+	case bool:
+		if f.kind == "boolean" {
+			if x {
+				return append(b, 1), nil
+			}
+			return append(b, 0), nil
+		}
+	case int64:
+		switch f.kind {
+		case "int":
+			if x < math.MinInt32 || x > math.MaxInt32 {
+				return b, fmt.Errorf("value %d overflows int", x)
+			}
+			return binary.AppendVarint(b, x), nil
+		case "long":
+			return binary.AppendVarint(b, x), nil
+		}
+	case float64:
+		switch f.kind {
+		case "float":
+			return binary.LittleEndian.AppendUint32(b, math.Float32bits(float32(x))), nil
+		case "double":
+			return binary.LittleEndian.AppendUint64(b, math.Float64bits(x)), nil
+		}
+	case time.Time:
+		if f.micros {
+			return binary.AppendVarint(b, x.UnixMicro()), nil
+		}
+	}
+	return b, fmt.Errorf("cannot encode %T as %s", dv, f.kind)
+}
+
+// Unmarshal decodes data, encoded with the schema SchemaFor returns for the type
+// of dst, into dst, a non-nil pointer to a struct.
+func Unmarshal(data []byte, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("avronull: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	rv = rv.Elem()
+	fs, err := fields(rv.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range fs {
+		fv := rv.FieldByIndex(f.index)
+		if f.optional {
+			branch, n := binary.Varint(data)
+			if n <= 0 {
+				return fmt.Errorf("avronull: field %s: %w", f.name, errShort)
+			}
+			data = data[n:]
+			switch branch {
+			case 0:
+				if err := nullreflect.Write(fv, nil, nullable.StateNull); err != nil {
+					return fmt.Errorf("avronull: field %s: %w", f.name, err)
+				}
+				continue
+			case 1:
+			default:
+				return fmt.Errorf("avronull: field %s: invalid union branch %d", f.name, branch)
+			}
+		}
+		var x any
+		if x, data, err = readValue(data, f); err != nil {
+			return fmt.Errorf("avronull: field %s: %w", f.name, err)
+		}
+		if err := nullreflect.Write(fv, x, nullable.StatePresent); err != nil {
+			return fmt.Errorf("avronull: field %s: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+func readValue(data []byte, f field) (any, []byte, error) {
+	switch f.kind {
+	case "string", "bytes":
+		l, n := binary.Varint(data)
+		if n <= 0 || l < 0 || int64(len(data)-n) < l {
+			return nil, data, errShort
+		}
+		b := data[n : n+int(l)]
+		if f.kind == "string" {
+			return string(b), data[n+int(l):], nil
+		}
+		return append([]byte(nil), b...), data[n+int(l):], nil
+	case "boolean":
+		if len(data) < 1 {
+			return nil, data, errShort
+		}
+		return data[0] != 0, data[1:], nil
+	case "int", "long":
+		x, n := binary.Varint(data)
+		if n <= 0 {
+			return nil, data, errShort
+		}
+		if f.micros {
+			return time.UnixMicro(x).UTC(), data[n:], nil
+		}
+		return x, data[n:], nil
+	case "float":
+		if len(data) < 4 {
+			return nil, data, errShort
+		}
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(data))), data[4:], nil
+	case "double":
+		if len(data) < 8 {
+			return nil, data, errShort
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(data)), data[8:], nil
+	}
+	return nil, data, fmt.Errorf("unsupported Avro type %s", f.kind)
+}
@@ -0,0 +1,68 @@
+// Package echobind adapts package bind to echo's echo.Binder interface.
+//
+//	e := echo.New()
+//	e.Binder = echobind.New()
+package echobind
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/bind"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+)
+
+// Binder binds form-encoded and multipart bodies with bind's tri-state semantics
+// and hands every other request to Default.
+type Binder struct {
+	// Default binds requests that are not form submissions.
+	Default echo.Binder
+	// Options are passed to bind.Form and bind.Multipart.
+	Options []bind.Option
+	// MaxMemory is passed to http.Request.ParseMultipartForm; zero uses 32 MiB like echo does.
+	MaxMemory int64
+}
+
+// New creates a Binder falling back to echo.DefaultBinder.
+func New(opts ...bind.Option) *Binder {
+	return &Binder{Default: &echo.DefaultBinder{}, Options: opts}
+}
+
+// Bind implements echo.Binder.
+func (b *Binder) Bind(i any, c echo.Context) error {
+	req := c.Request()
+	ctype := req.Header.Get(echo.HeaderContentType)
+	switch {
+	case strings.HasPrefix(ctype, echo.MIMEApplicationForm), strings.HasPrefix(ctype, echo.MIMEMultipartForm):
+	default:
+		return b.Default.Bind(i, c)
+	}
+
+	maxMemory := b.MaxMemory
+	if maxMemory == 0 {
+		maxMemory = 32 << 20
+	}
+	if err := req.ParseMultipartForm(maxMemory); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+	var err error
+	if req.MultipartForm != nil {
+		form := *req.MultipartForm
+		form.Value = req.Form
+		err = bind.Multipart(&form, i, b.Options...)
+	} else {
+		err = bind.Form(req.Form, i, b.Options...)
+	}
+	var fe forms.FieldErrors
+	if errors.As(err, &fe) {
+		// FieldErrors marshals itself, so echo renders {"errors": {...}}.
+		return echo.NewHTTPError(http.StatusBadRequest, fe).SetInternal(err)
+	}
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Package bind binds classic HTTP form submissions and query strings into nullable DTOs
+// with tri-state semantics: absent keys are undefined, empty values are null
+// and everything else is parsed into the field's type. Fields that cannot be undefined,
+// such as nullable.Null or plain values, keep what dst held for absent keys, as they
+// do when decoding JSON.
+package bind
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/observe"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type options struct {
+	emptyAsNull bool
+}
+
+// Option configures binding.
+type Option func(*options)
+
+// EmptyAsNull controls whether an empty value binds as null (the default) or as
+// an empty value of the field's type, which only makes sense for string fields.
+func EmptyAsNull(enabled bool) Option {
+	return func(o *options) {
+		o.emptyAsNull = enabled
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{emptyAsNull: true}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Form binds values into dst, a non-nil pointer to a struct.
+// Keys are taken from the `form` tag or the field name; `form:"-"` skips a field.
+// Only the first value of each key is used. Values that cannot be parsed are
+// reported together as forms.FieldErrors keyed by form key, with messages such as
+// cannot parse "abc" as int32.
+func Form(values url.Values, dst any, opts ...Option) error {
+	o := newOptions(opts)
+	v, err := structValue(dst)
+	if err != nil {
+		return err
+	}
+	var fe forms.FieldErrors
+	for _, f := range nullreflect.Fields(v.Type()) {
+		key, ok := formKey(f)
+		if !ok {
+			continue
+		}
+		vals, present := values[key]
+		if err := bindValue(v.FieldByIndex(f.Index), vals, present, o); err != nil {
+			fe.Add(key, err.Error())
+		}
+	}
+	err = fe.Err()
+	observe.Decoded(v, "form", err)
+	return err
+}
+
+func structValue(dst any) (reflect.Value, error) {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("bind: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	return v.Elem(), nil
+}
+
+func formKey(f nullreflect.Field) (string, bool) {
+	tag := f.Tag.Get("form")
+	if tag == "-" {
+		return "", false
+	}
+	key, _, _ := strings.Cut(tag, ",")
+	if key == "" {
+		key = f.Name
+	}
+	return key, true
+}
+
+// bindValue applies the tri-state rules to a single field.
+func bindValue(fv reflect.Value, vals []string, present bool, o options) error {
+	if !present || len(vals) == 0 {
+		return bindAbsent(fv)
+	}
+	if vals[0] == "" && o.emptyAsNull {
+		return nullreflect.Write(fv, nil, nullable.StateNull)
+	}
+	return nullreflect.WriteString(fv, vals[0])
+}
+
+// bindAbsent makes fv undefined for a key missing from the input, leaving fields
+// that cannot be undefined untouched.
+func bindAbsent(fv reflect.Value) error {
+	if !nullreflect.CanBeUndefined(fv.Type()) {
+		return nil
+	}
+	return nullreflect.Write(fv, nil, nullable.StateUndefined)
+}
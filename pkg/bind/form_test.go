@@ -0,0 +1,123 @@
+package bind
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type testForm struct {
+	Name     nullable.Optional[string] `form:"name"`
+	Age      nullable.Optional[int32]  `form:"age"`
+	Birthday nullable.Optional[nullable.Date]
+	Email    nullable.Null[string] `form:"email"`
+	Phone    null.String           `form:"phone"`
+	Count    int                   `form:"count"`
+	Internal string                `form:"-"`
+}
+
+func TestForm(t *testing.T) {
+	tests := []struct {
+		name   string
+		values url.Values
+		dst    testForm
+		opts   []Option
+		want   testForm
+	}{
+		{
+			name:   "absent keys are undefined",
+			values: url.Values{"name": {"Tan"}},
+			dst:    testForm{Age: nullable.OptionalFrom[int32](3)},
+			want:   testForm{Name: nullable.OptionalFrom("Tan")},
+		},
+		{
+			name:   "absent keys keep fields that cannot be undefined",
+			values: url.Values{},
+			dst:    testForm{Email: nullable.From("a@b.c"), Phone: null.StringFrom("+65"), Count: 2, Internal: "x"},
+			want:   testForm{Email: nullable.From("a@b.c"), Phone: null.StringFrom("+65"), Count: 2, Internal: "x"},
+		},
+		{
+			name:   "empty values are null",
+			values: url.Values{"name": {""}, "age": {""}, "email": {""}, "phone": {""}},
+			dst:    testForm{Email: nullable.From("a@b.c")},
+			want:   testForm{Name: nullable.OptionalNull[string](), Age: nullable.OptionalNull[int32](), Email: nullable.Null[string]{}},
+		},
+		{
+			name:   "values are parsed",
+			values: url.Values{"name": {"Tan", "ignored"}, "age": {"30"}, "Birthday": {"2000-01-31"}, "email": {"a@b.c"}, "count": {"7"}, "Internal": {"x"}},
+			want: testForm{
+				Name:     nullable.OptionalFrom("Tan"),
+				Age:      nullable.OptionalFrom[int32](30),
+				Birthday: nullable.OptionalFrom(nullable.NewDate(2000, time.January, 31)),
+				Email:    nullable.From("a@b.c"),
+				Count:    7,
+			},
+		},
+		{
+			name:   "EmptyAsNull disabled",
+			values: url.Values{"name": {""}},
+			opts:   []Option{EmptyAsNull(false)},
+			want:   testForm{Name: nullable.OptionalFrom("")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.dst
+			if err := Form(tt.values, &got, tt.opts...); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Form = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormErrors(t *testing.T) {
+	var dst testForm
+	err := Form(url.Values{"age": {"abc"}, "Birthday": {"tomorrow"}, "name": {"Tan"}}, &dst)
+	var fe forms.FieldErrors
+	if !errors.As(err, &fe) {
+		t.Fatalf("Form = %v, want forms.FieldErrors", err)
+	}
+	if got, want := fe.Fields(), []string{"Birthday", "age"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("fields with errors = %q, want %q", got, want)
+	}
+	if dst.Name != nullable.OptionalFrom("Tan") {
+		t.Errorf("Name = %#v, want valid fields bound despite errors", dst.Name)
+	}
+	if err := Form(url.Values{}, dst); err == nil {
+		t.Error("Form into a struct value: want an error")
+	}
+}
+
+func TestFormTimeLocation(t *testing.T) {
+	defer func(loc *time.Location) { nullable.TimeLocation = loc }(nullable.TimeLocation)
+	nullable.TimeLocation = time.FixedZone("SGT", 8*60*60)
+	var dst struct {
+		Optional nullable.Optional[time.Time] `form:"optional"`
+		Plain    time.Time                    `form:"plain"`
+		Stamp    pgtype.Timestamptz           `form:"stamp"`
+	}
+	values := url.Values{"optional": {"2024-03-01 09:30:00"}, "plain": {"2024-03-01 09:30:00"}, "stamp": {"2024-03-01 09:30:00"}}
+	if err := Form(values, &dst); err != nil {
+		t.Fatal(err)
+	}
+	if !dst.Optional.Valid || !dst.Stamp.Valid {
+		t.Fatalf("Form = %+v, want every time present", dst)
+	}
+	want := time.Date(2024, time.March, 1, 1, 30, 0, 0, time.UTC)
+	for name, got := range map[string]time.Time{"optional": dst.Optional.V, "plain": dst.Plain, "stamp": dst.Stamp.Time} {
+		if !got.Equal(want) {
+			t.Errorf("%s = %v, want %v parsed in nullable.TimeLocation", name, got, want)
+		}
+	}
+}
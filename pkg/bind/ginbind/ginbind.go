@@ -0,0 +1,50 @@
+// Package ginbind adapts package bind to gin's binding.Binding interface.
+//
+//	var form dtos.UinfinNamesPatch
+//	if err := c.ShouldBindWith(&form, ginbind.NullForm); err != nil {
+//		// ...
+//	}
+package ginbind
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/bind"
+)
+
+// NullForm binds application/x-www-form-urlencoded and multipart/form-data bodies,
+// along with the query string, using bind's tri-state semantics.
+var NullForm binding.Binding = Form{}
+
+// Form is a binding.Binding backed by bind.Form and bind.Multipart.
+type Form struct {
+	// Options are passed to bind.Form and bind.Multipart.
+	Options []bind.Option
+	// MaxMemory is passed to http.Request.ParseMultipartForm; zero uses 32 MiB like gin does.
+	MaxMemory int64
+}
+
+// Name implements binding.Binding.
+func (Form) Name() string {
+	return "nullform"
+}
+
+// Bind implements binding.Binding.
+func (b Form) Bind(req *http.Request, obj any) error {
+	maxMemory := b.MaxMemory
+	if maxMemory == 0 {
+		maxMemory = 32 << 20
+	}
+	if err := req.ParseMultipartForm(maxMemory); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return err
+	}
+	if req.MultipartForm != nil {
+		form := *req.MultipartForm
+		form.Value = req.Form
+		return bind.Multipart(&form, obj, b.Options...)
+	}
+	return bind.Form(req.Form, obj, b.Options...)
+}
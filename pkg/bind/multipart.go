@@ -0,0 +1,70 @@
+package bind
+
+import (
+	"mime/multipart"
+	"reflect"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/observe"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+var fileHeaderType = reflect.TypeFor[*multipart.FileHeader]()
+
+// Multipart binds a parsed multipart/form-data body into dst, a non-nil pointer to a struct.
+// Text fields follow the same rules as Form. Fields of type *multipart.FileHeader,
+// or a nullable.Null or nullable.Optional of it, are bound from uploaded files:
+// a missing part is undefined, an empty part (no file selected) is null.
+// Errors are reported like Form does.
+func Multipart(form *multipart.Form, dst any, opts ...Option) error {
+	o := newOptions(opts)
+	v, err := structValue(dst)
+	if err != nil {
+		return err
+	}
+	var fe forms.FieldErrors
+	for _, f := range nullreflect.Fields(v.Type()) {
+		key, ok := formKey(f)
+		if !ok {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		if isFileField(f.Type) {
+			err = bindFile(fv, form, key)
+		} else {
+			vals, present := form.Value[key]
+			err = bindValue(fv, vals, present, o)
+		}
+		if err != nil {
+			fe.Add(key, err.Error())
+		}
+	}
+	err = fe.Err()
+	observe.Decoded(v, "multipart", err)
+	return err
+}
+
+func isFileField(t reflect.Type) bool {
+	if t == fileHeaderType {
+		return true
+	}
+	inner, ok := nullreflect.Inner(t)
+	return ok && inner == fileHeaderType
+}
+
+func bindFile(fv reflect.Value, form *multipart.Form, key string) error {
+	if headers := form.File[key]; len(headers) > 0 {
+		fh := headers[0]
+		if fh.Filename == "" && fh.Size == 0 {
+			return nullreflect.Write(fv, nil, nullable.StateNull)
+		}
+		return nullreflect.Write(fv, fh, nullable.StatePresent)
+	}
+	// mime/multipart stores parts without a file name as values,
+	// which is how browsers submit a file input left empty.
+	if vals, ok := form.Value[key]; ok && (len(vals) == 0 || vals[0] == "") {
+		return nullreflect.Write(fv, nil, nullable.StateNull)
+	}
+	return bindAbsent(fv)
+}
@@ -0,0 +1,101 @@
+package bind
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"testing"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type testUpload struct {
+	Name   nullable.Optional[string]                `form:"name"`
+	Photo  nullable.Optional[*multipart.FileHeader] `form:"photo"`
+	Resume nullable.Null[*multipart.FileHeader]     `form:"resume"`
+	Extra  *multipart.FileHeader                    `form:"extra"`
+	Other  nullable.Optional[*multipart.FileHeader] `form:"other"`
+}
+
+// part is a multipart/form-data part; file parts have a file name, possibly empty.
+type part struct {
+	name, filename, content string
+	file                    bool
+}
+
+func parseMultipart(t *testing.T, parts []part) *multipart.Form {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for _, p := range parts {
+		var err error
+		if p.file {
+			var fw io.Writer
+			fw, err = w.CreateFormFile(p.name, p.filename)
+			if err == nil {
+				_, err = fw.Write([]byte(p.content))
+			}
+		} else {
+			err = w.WriteField(p.name, p.content)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	form, err := multipart.NewReader(&buf, w.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { form.RemoveAll() })
+	return form
+}
+
+func TestMultipart(t *testing.T) {
+	form := parseMultipart(t, []part{
+		{name: "name", content: "Tan"},
+		{name: "photo", filename: "me.png", content: "png", file: true},
+		{name: "resume", filename: "", content: "", file: true},
+		{name: "extra", filename: "cv.pdf", content: "pdf", file: true},
+	})
+	dst := testUpload{Other: nullable.OptionalNull[*multipart.FileHeader]()}
+	if err := Multipart(form, &dst); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		got         interface{ Get() (any, bool) }
+		wantFile    string
+		wantPresent bool
+	}{
+		{name: "uploaded file", got: dst.Photo, wantFile: "me.png", wantPresent: true},
+		{name: "empty file input is null", got: dst.Resume},
+		{name: "missing part is undefined", got: dst.Other},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, ok := tt.got.Get()
+			if ok != tt.wantPresent {
+				t.Fatalf("Get() = %v, %v, want present %v", v, ok, tt.wantPresent)
+			}
+			if ok && v.(*multipart.FileHeader).Filename != tt.wantFile {
+				t.Errorf("file name = %q, want %q", v.(*multipart.FileHeader).Filename, tt.wantFile)
+			}
+		})
+	}
+	if dst.Other.IsDefined() {
+		t.Errorf("Other = %#v, want undefined", dst.Other)
+	}
+	if !dst.Photo.IsDefined() {
+		t.Errorf("Photo = %#v, want defined", dst.Photo)
+	}
+	if dst.Extra == nil || dst.Extra.Filename != "cv.pdf" {
+		t.Errorf("Extra = %#v, want cv.pdf", dst.Extra)
+	}
+	if dst.Name != nullable.OptionalFrom("Tan") {
+		t.Errorf("Name = %#v, want Tan", dst.Name)
+	}
+}
@@ -0,0 +1,101 @@
+package bind
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/observe"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Query binds a query string into dst, a non-nil pointer to a filter struct, so the
+// result feeds straight into sqlbuild.WhereDefined: a missing key is undefined,
+// an empty value such as ?name= is null and everything else is parsed like Form does.
+// Keys are taken from the `query` tag, then the `form` tag, then the field name.
+//
+// Slice fields, such as nullable.Slice[string] or []int, collect every value of a
+// repeated key, as in ?status=open&status=closed, skipping empty ones; a key with
+// only empty values is null. Errors are reported like Form does.
+func Query(q url.Values, dst any, opts ...Option) error {
+	o := newOptions(opts)
+	v, err := structValue(dst)
+	if err != nil {
+		return err
+	}
+	var fe forms.FieldErrors
+	for _, f := range nullreflect.Fields(v.Type()) {
+		key, ok := queryKey(f)
+		if !ok {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		vals, present := q[key]
+		if isSliceField(f.Type) {
+			err = bindSlice(fv, vals, present)
+		} else {
+			err = bindValue(fv, vals, present, o)
+		}
+		if err != nil {
+			fe.Add(key, err.Error())
+		}
+	}
+	err = fe.Err()
+	observe.Decoded(v, "query", err)
+	return err
+}
+
+func queryKey(f nullreflect.Field) (string, bool) {
+	tag, ok := f.Tag.Lookup("query")
+	if !ok {
+		return formKey(f)
+	}
+	if tag == "-" {
+		return "", false
+	}
+	key, _, _ := strings.Cut(tag, ",")
+	if key == "" {
+		key = f.Name
+	}
+	return key, true
+}
+
+// isSliceField reports whether t is a slice, or a nullable type holding one, other than []byte.
+func isSliceField(t reflect.Type) bool {
+	if inner, ok := nullreflect.Inner(t); ok {
+		t = inner
+	}
+	return t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8
+}
+
+func bindSlice(fv reflect.Value, vals []string, present bool) error {
+	if !present || len(vals) == 0 {
+		return bindAbsent(fv)
+	}
+	target := fv
+	_, wrapped := nullreflect.Inner(fv.Type())
+	if wrapped {
+		target = fv.FieldByName("V")
+	}
+	s := reflect.MakeSlice(target.Type(), 0, len(vals))
+	for _, val := range vals {
+		if val == "" {
+			continue
+		}
+		elem := reflect.New(target.Type().Elem()).Elem()
+		if err := nullreflect.WriteString(elem, val); err != nil {
+			return err
+		}
+		s = reflect.Append(s, elem)
+	}
+	if s.Len() == 0 {
+		return nullreflect.Write(fv, nil, nullable.StateNull)
+	}
+	target.Set(s)
+	if wrapped {
+		fv.FieldByName("Valid").SetBool(true)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package bind
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type testFilter struct {
+	Name   nullable.Optional[string] `query:"q" form:"name"`
+	Status nullable.Slice[string]    `form:"status"`
+	IDs    []int                     `query:"id"`
+	Page   nullable.Null[int32]      `form:"page"`
+	Skip   string                    `query:"-" form:"skip"`
+}
+
+func TestQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		dst   testFilter
+		want  testFilter
+	}{
+		{
+			name:  "nothing set",
+			query: "",
+			dst:   testFilter{Page: nullable.From[int32](1)},
+			want:  testFilter{Page: nullable.From[int32](1)},
+		},
+		{
+			name:  "query tag wins over form tag",
+			query: "q=tan&name=ignored&skip=x",
+			want:  testFilter{Name: nullable.OptionalFrom("tan")},
+		},
+		{
+			name:  "empty value is null",
+			query: "q=&page=",
+			dst:   testFilter{Page: nullable.From[int32](1)},
+			want:  testFilter{Name: nullable.OptionalNull[string]()},
+		},
+		{
+			name:  "repeated keys collect",
+			query: "status=open&status=&status=closed&id=1&id=2",
+			want:  testFilter{Status: nullable.SliceFrom([]string{"open", "closed"}), IDs: []int{1, 2}},
+		},
+		{
+			name:  "only empty values is null",
+			query: "status=&status=",
+			dst:   testFilter{Status: nullable.SliceFrom([]string{"old"})},
+			want:  testFilter{Status: nullable.Slice[string]{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := tt.dst
+			if err := Query(q, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Query(%q) = %#v, want %#v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	var dst testFilter
+	err := Query(url.Values{"id": {"1", "two"}}, &dst)
+	var fe forms.FieldErrors
+	if !errors.As(err, &fe) || len(fe["id"]) != 1 {
+		t.Errorf("Query = %v, want an error for id", err)
+	}
+}
@@ -0,0 +1,373 @@
+// Package cache encodes nullable DTOs into a compact binary form for caches such as Redis.
+//
+// Unlike JSON, the encoding records the state of every field, so a cached DTO comes back
+// with the same nullable.Optional states it was stored with: a field the user was not
+// asked about yet stays undefined, and one they explicitly cleared stays null.
+//
+//	rdb.AddHook(cache.Hook{})
+//	rdb.Set(ctx, key, patch, time.Hour)
+//	// later
+//	var patch dtos.UinfinNamesPatch
+//	err := cache.Get(ctx, rdb, key, &patch)
+package cache
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// ErrCorrupt is returned by Unmarshal for data it cannot decode.
+var ErrCorrupt = errors.New("cache: corrupt data")
+
+const version = 1
+
+// Field states.
+const (
+	stateUndefined byte = iota
+	stateNull
+	statePresent
+)
+
+// Kinds of present values.
+const (
+	kindInt64 byte = iota
+	kindFloat64
+	kindBool
+	kindString
+	kindBytes
+	kindTime
+	kindJSON   // the field's JSON encoding, for opaque fields that aren't driver.Valuers
+	kindStruct // the fields of a nested DTO, encoded like the top-level struct
+	kindSlice  // the elements of a slice of nested DTOs, each encoded like a field
+)
+
+// Marshal encodes dto, a struct or pointer to one. Every exported field is stored under
+// its Go name along with its state; present values are stored as their driver value,
+// falling back to their JSON encoding for opaque types that aren't driver.Valuers.
+//
+// Nested DTOs, such as an AddressForm field, and slices of them are stored field by
+// field, so the states of their fields survive too; nil pointers and nil slices are
+// stored as null.
+func Marshal(dto any) ([]byte, error) {
+	v := reflect.Indirect(reflect.ValueOf(dto))
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cache: dto must be a struct, got %T", dto)
+	}
+	return appendStruct([]byte{version}, v, "")
+}
+
+func appendStruct(b []byte, v reflect.Value, path string) ([]byte, error) {
+	fields := nullreflect.Fields(v.Type())
+	b = binary.AppendUvarint(b, uint64(len(fields)))
+	for _, f := range fields {
+		b = appendBytes(b, []byte(f.Name))
+		var err error
+		if b, err = appendField(b, v.FieldByIndex(f.Index), fieldPath(path, f.Name)); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func appendField(b []byte, fv reflect.Value, path string) ([]byte, error) {
+	switch {
+	case nullreflect.IsNested(fv.Type()):
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				return append(b, stateNull), nil
+			}
+			fv = fv.Elem()
+		}
+		return appendStruct(append(b, statePresent, kindStruct), fv, path)
+	case isNestedSlice(fv.Type()):
+		if fv.IsNil() {
+			return append(b, stateNull), nil
+		}
+		b = binary.AppendUvarint(append(b, statePresent, kindSlice), uint64(fv.Len()))
+		for i := range fv.Len() {
+			var err error
+			if b, err = appendField(b, fv.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	val, state, err := nullreflect.Read(fv)
+	if err != nil {
+		data, err := json.Marshal(fv.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("cache: field %s: %w", path, err)
+		}
+		b = append(b, statePresent, kindJSON)
+		return appendBytes(b, data), nil
+	}
+	switch state {
+	case nullstate.Undefined:
+		return append(b, stateUndefined), nil
+	case nullstate.Null:
+		return append(b, stateNull), nil
+	}
+	if b, err = appendValue(append(b, statePresent), val); err != nil {
+		return nil, fmt.Errorf("cache: field %s: %w", path, err)
+	}
+	return b, nil
+}
+
+func appendValue(b []byte, val any) ([]byte, error) {
+	switch val := val.(type) {
+	case int64:
+		return binary.AppendVarint(append(b, kindInt64), val), nil
+	case float64:
+		return binary.LittleEndian.AppendUint64(append(b, kindFloat64), math.Float64bits(val)), nil
+	case bool:
+		if val {
+			return append(b, kindBool, 1), nil
+		}
+		return append(b, kindBool, 0), nil
+	case string:
+		return appendBytes(append(b, kindString), []byte(val)), nil
+	case []byte:
+		return appendBytes(append(b, kindBytes), val), nil
+	case time.Time:
+		data, err := val.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		return appendBytes(append(b, kindTime), data), nil
+	}
+	return nil, fmt.Errorf("unsupported driver value %T", val)
+}
+
+func appendBytes(b, data []byte) []byte {
+	return append(binary.AppendUvarint(b, uint64(len(data))), data...)
+}
+
+// Unmarshal decodes data produced by Marshal into dst, a non-nil pointer to a struct.
+// Fields missing from data are reset to their zero value, which is undefined for
+// nullable.Optional; fields in data that dst doesn't have are ignored. Nested DTOs and
+// slices of them are decoded field by field, allocating pointers as needed.
+func Unmarshal(data []byte, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("cache: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+
+	r := reader{data: data}
+	if r.byte() != version {
+		return ErrCorrupt
+	}
+	if err := r.structInto(v.Elem(), ""); err != nil {
+		return err
+	}
+	if r.err != nil || len(r.data) > 0 {
+		return ErrCorrupt
+	}
+	return nil
+}
+
+// structInto decodes the fields of a struct into v, or skips them if v is the zero Value.
+func (r *reader) structInto(v reflect.Value, path string) error {
+	n := r.uvarint()
+	var byName map[string]reflect.Value
+	if v.IsValid() {
+		byName = make(map[string]reflect.Value)
+		for _, f := range nullreflect.Fields(v.Type()) {
+			fv := v.FieldByIndex(f.Index)
+			fv.SetZero()
+			byName[f.Name] = fv
+		}
+	}
+	for i := uint64(0); i < n && r.err == nil; i++ {
+		name := string(r.bytes())
+		if err := r.field(byName[name], fieldPath(path, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// field decodes a field into fv, or skips it if fv is the zero Value.
+func (r *reader) field(fv reflect.Value, path string) error {
+	state := r.byte()
+	if state > statePresent {
+		r.err = ErrCorrupt
+	}
+	if r.err != nil {
+		return nil
+	}
+	nested := fv.IsValid() && (nullreflect.IsNested(fv.Type()) || isNestedSlice(fv.Type()))
+	if state != statePresent {
+		switch {
+		case !fv.IsValid():
+			return nil
+		case nested:
+			// Nil pointers and slices were stored as null.
+			fv.SetZero()
+			return nil
+		}
+		st := nullstate.Null
+		if state == stateUndefined {
+			st = nullstate.Undefined
+		}
+		if err := nullreflect.Write(fv, nil, st); err != nil {
+			return fmt.Errorf("cache: field %s: %w", path, err)
+		}
+		return nil
+	}
+
+	kind := r.byte()
+	switch kind {
+	case kindStruct:
+		var target reflect.Value
+		if fv.IsValid() {
+			if !nullreflect.IsNested(fv.Type()) {
+				return fmt.Errorf("cache: field %s: stored as a nested DTO, cannot decode into %s", path, fv.Type())
+			}
+			if fv.Kind() == reflect.Pointer {
+				fv.Set(reflect.New(fv.Type().Elem()))
+				fv = fv.Elem()
+			}
+			target = fv
+		}
+		return r.structInto(target, path)
+	case kindSlice:
+		n := r.uvarint()
+		// Every element takes at least a byte, which bounds the allocation by the data.
+		if r.err != nil || n > uint64(len(r.data)) {
+			r.err = ErrCorrupt
+			return nil
+		}
+		var s reflect.Value
+		if fv.IsValid() {
+			if !isNestedSlice(fv.Type()) {
+				return fmt.Errorf("cache: field %s: stored as a slice of nested DTOs, cannot decode into %s", path, fv.Type())
+			}
+			s = reflect.MakeSlice(fv.Type(), int(n), int(n))
+			fv.Set(s)
+		}
+		for i := range int(n) {
+			var elem reflect.Value
+			if s.IsValid() {
+				elem = s.Index(i)
+			}
+			if err := r.field(elem, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	val := r.value(kind)
+	if r.err != nil || !fv.IsValid() {
+		return nil
+	}
+	var err error
+	if kind == kindJSON {
+		err = json.Unmarshal(val.([]byte), fv.Addr().Interface())
+	} else {
+		err = nullreflect.Write(fv, val, nullstate.Present)
+	}
+	if err != nil {
+		return fmt.Errorf("cache: field %s: %w", path, err)
+	}
+	return nil
+}
+
+// fieldPath joins the path of a nested DTO and the name of one of its fields.
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func isNestedSlice(t reflect.Type) bool {
+	_, ok := nullreflect.NestedSlice(t)
+	return ok
+}
+
+// reader consumes data, recording the first error instead of returning it.
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) byte() byte {
+	if r.err != nil || len(r.data) == 0 {
+		r.err = ErrCorrupt
+		return 0
+	}
+	c := r.data[0]
+	r.data = r.data[1:]
+	return c
+}
+
+func (r *reader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	x, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = ErrCorrupt
+		return 0
+	}
+	r.data = r.data[n:]
+	return x
+}
+
+func (r *reader) bytes() []byte {
+	n := r.uvarint()
+	if r.err != nil || n > uint64(len(r.data)) {
+		r.err = ErrCorrupt
+		return nil
+	}
+	b := r.data[:n:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *reader) value(kind byte) any {
+	switch kind {
+	case kindInt64:
+		if r.err != nil {
+			return nil
+		}
+		x, n := binary.Varint(r.data)
+		if n <= 0 {
+			r.err = ErrCorrupt
+			return nil
+		}
+		r.data = r.data[n:]
+		return x
+	case kindFloat64:
+		if r.err != nil || len(r.data) < 8 {
+			r.err = ErrCorrupt
+			return nil
+		}
+		x := math.Float64frombits(binary.LittleEndian.Uint64(r.data))
+		r.data = r.data[8:]
+		return x
+	case kindBool:
+		return r.byte() != 0
+	case kindString:
+		return string(r.bytes())
+	case kindBytes, kindJSON:
+		return r.bytes()
+	case kindTime:
+		var t time.Time
+		if err := t.UnmarshalBinary(r.bytes()); err != nil && r.err == nil {
+			r.err = ErrCorrupt
+		}
+		return t
+	}
+	r.err = ErrCorrupt
+	return nil
+}
@@ -0,0 +1,223 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/redis/go-redis/v9"
+	"github.com/shopspring/decimal"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type testAddress struct {
+	ID     nullable.Optional[int64]
+	Street nullable.Optional[string]
+}
+
+type testForm struct {
+	Name      nullable.Optional[string]
+	Married   nullable.Optional[string]
+	Alias     nullable.Optional[string]
+	Age       nullable.Null[int32]
+	Score     nullable.Null[float64]
+	Consent   nullable.Bool
+	Submitted nullable.Time
+	Birthday  nullable.Date
+	Income    nullable.Decimal
+	Email     null.String
+	Meta      nullable.JSONOf[map[string]int]
+	Current   *testAddress
+	Mailing   testAddress
+	Previous  []testAddress
+	Plain     string
+	unwritten string
+}
+
+func TestRoundTrip(t *testing.T) {
+	submitted := time.Date(2024, time.March, 1, 9, 30, 0, 123, time.UTC)
+	tests := []struct {
+		name string
+		dto  testForm
+	}{
+		{name: "zero", dto: testForm{}},
+		{
+			name: "states survive",
+			dto: testForm{
+				Name:    nullable.OptionalFrom("Tan"),
+				Married: nullable.OptionalNull[string](),
+				Age:     nullable.From[int32](30),
+			},
+		},
+		{
+			name: "every kind",
+			dto: testForm{
+				Age:       nullable.From[int32](-30),
+				Score:     nullable.From(99.5),
+				Consent:   nullable.BoolFrom(false),
+				Submitted: nullable.TimeFrom(submitted),
+				Birthday:  nullable.NewDate(1990, time.May, 17),
+				Income:    nullable.DecimalFrom(decimal.RequireFromString("12345.67")),
+				Email:     null.StringFrom("tan@example.com"),
+				Meta:      nullable.JSONOfFrom(map[string]int{"step": 3}),
+				Plain:     "plain",
+			},
+		},
+		{
+			name: "nested",
+			dto: testForm{
+				Current:  &testAddress{Street: nullable.OptionalNull[string]()},
+				Mailing:  testAddress{ID: nullable.OptionalFrom[int64](1)},
+				Previous: []testAddress{{ID: nullable.OptionalFrom[int64](2)}, {}},
+			},
+		},
+		{
+			name: "empty slice is not nil",
+			dto:  testForm{Previous: []testAddress{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := Marshal(&tt.dto)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Start from a different value, so every field must be restored.
+			got := testForm{Name: nullable.OptionalFrom("stale"), Current: &testAddress{}, Previous: []testAddress{{}}}
+			if err := Unmarshal(b, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.dto) {
+				t.Errorf("round trip =\n%#v\nwant\n%#v", got, tt.dto)
+			}
+		})
+	}
+}
+
+func TestRoundTripBytes(t *testing.T) {
+	type upload struct{ Photo []byte }
+	b, err := Marshal(upload{Photo: []byte{0, 1, 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got upload
+	if err := Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0, 1, 2}; !reflect.DeepEqual(got.Photo, want) {
+		t.Errorf("Photo = %v, want %v", got.Photo, want)
+	}
+}
+
+func TestUnmarshalIntoAnotherType(t *testing.T) {
+	b, err := Marshal(testForm{Name: nullable.OptionalFrom("Tan"), Plain: "x", Previous: []testAddress{{}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Name  nullable.Null[string]
+		Extra nullable.Optional[string]
+	}
+	got.Extra = nullable.OptionalFrom("stale")
+	if err := Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != nullable.From("Tan") || got.Extra.IsDefined() {
+		t.Errorf("Unmarshal = %#v, want Name set and Extra reset to undefined", got)
+	}
+
+	var mismatched struct{ Previous string }
+	if err := Unmarshal(b, &mismatched); err == nil || errors.Is(err, ErrCorrupt) {
+		t.Errorf("Unmarshal of a slice into a string = %v, want a type error", err)
+	}
+}
+
+func TestUnmarshalCorrupt(t *testing.T) {
+	b, err := Marshal(testForm{
+		Name:      nullable.OptionalFrom("Tan"),
+		Score:     nullable.From(1.5),
+		Submitted: nullable.TimeFrom(time.Unix(0, 0).UTC()),
+		Current:   &testAddress{},
+		Previous:  []testAddress{{ID: nullable.OptionalFrom[int64](3)}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "unknown version", data: append([]byte{version + 1}, b[1:]...)},
+		{name: "trailing data", data: append(b[:len(b):len(b)], 0)},
+		{name: "bad state", data: []byte{version, 1, 1, 'A', 9}},
+		{name: "bad kind", data: []byte{version, 1, 1, 'A', statePresent, 99}},
+		{name: "huge slice", data: []byte{version, 1, 1, 'A', statePresent, kindSlice, 0xff, 0xff, 0x03}},
+	}
+	for i := 1; i < len(b); i++ {
+		tests = append(tests, struct {
+			name string
+			data []byte
+		}{name: "truncated", data: b[:i]})
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dst testForm
+			if err := Unmarshal(tt.data, &dst); !errors.Is(err, ErrCorrupt) {
+				t.Errorf("Unmarshal(%x) = %v, want ErrCorrupt", tt.data, err)
+			}
+		})
+	}
+}
+
+func TestMarshalErrors(t *testing.T) {
+	if _, err := Marshal(42); err == nil {
+		t.Error("Marshal(42): want an error")
+	}
+	if err := Unmarshal([]byte{version, 0}, testForm{}); err == nil {
+		t.Error("Unmarshal into a struct value: want an error")
+	}
+	if _, err := Marshal(struct{ F func() }{func() {}}); err == nil {
+		t.Error("Marshal of a func field: want an error")
+	}
+}
+
+func TestHookEncodesDTOs(t *testing.T) {
+	dto := testForm{Name: nullable.OptionalFrom("Tan")}
+	want, err := Marshal(dto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Unix(0, 0)
+	tests := []struct {
+		name string
+		arg  any
+		want any
+	}{
+		{name: "struct", arg: dto, want: want},
+		{name: "pointer", arg: &dto, want: want},
+		{name: "string", arg: "x", want: "x"},
+		{name: "time", arg: ts, want: ts},
+		{name: "nil", arg: nil, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := redis.NewStatusCmd(context.Background(), "set", "key", tt.arg)
+			var sent any
+			process := Hook{}.ProcessHook(func(_ context.Context, cmd redis.Cmder) error {
+				sent = cmd.Args()[2]
+				return nil
+			})
+			if err := process(context.Background(), cmd); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(sent, tt.want) {
+				t.Errorf("sent %#v, want %#v", sent, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"context"
+	"encoding"
+	"reflect"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Hook is a redis.Hook encoding DTO arguments with Marshal, so commands such as Set
+// accept DTOs directly. Arguments are encoded if they are structs or pointers to
+// structs, other than times and types implementing encoding.BinaryMarshaler, which
+// go-redis already knows how to send.
+type Hook struct{}
+
+var _ redis.Hook = Hook{}
+
+// DialHook implements redis.Hook.
+func (Hook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook implements redis.Hook.
+func (Hook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := encodeArgs(cmd); err != nil {
+			return err
+		}
+		return next(ctx, cmd)
+	}
+}
+
+// ProcessPipelineHook implements redis.Hook.
+func (Hook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			if err := encodeArgs(cmd); err != nil {
+				return err
+			}
+		}
+		return next(ctx, cmds)
+	}
+}
+
+var (
+	binaryMarshalerType = reflect.TypeFor[encoding.BinaryMarshaler]()
+	timeType            = reflect.TypeFor[time.Time]()
+)
+
+// encodeArgs replaces the DTO arguments of cmd by their encoding.
+func encodeArgs(cmd redis.Cmder) error {
+	args := cmd.Args()
+	for i, arg := range args {
+		t := reflect.TypeOf(arg)
+		if t == nil || t.Implements(binaryMarshalerType) {
+			continue
+		}
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || t == timeType {
+			continue
+		}
+		b, err := Marshal(arg)
+		if err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+		args[i] = b
+	}
+	return nil
+}
+
+// Get reads the DTO stored at key into dst with Unmarshal.
+// It returns redis.Nil if key does not exist.
+func Get(ctx context.Context, c redis.Cmdable, key string, dst any) error {
+	b, err := c.Get(ctx, key).Bytes()
+	if err != nil {
+		return err
+	}
+	return Unmarshal(b, dst)
+}
@@ -0,0 +1,137 @@
+// Package cdc decodes Debezium change events into nullable DTOs.
+//
+// The before and after row images are decoded with encoding/json, so DTO fields are
+// matched to columns through their `json` tags: columns absent from an image leave
+// nullable.Optional fields undefined, and columns holding null become explicit nulls.
+// nullable.Time understands Debezium's ISO timestamps and, with
+// time.precision.mode=connect, its epoch millisecond ones.
+package cdc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// ErrTombstone is returned for the empty or null messages Debezium emits after a
+// delete, so that Kafka log compaction can drop the key.
+var ErrTombstone = errors.New("cdc: tombstone event")
+
+// Op is the kind of change an event records.
+type Op string
+
+const (
+	OpCreate   Op = "c"
+	OpUpdate   Op = "u"
+	OpDelete   Op = "d"
+	OpRead     Op = "r" // snapshot read
+	OpTruncate Op = "t"
+)
+
+// Event is a decoded change event of a row represented by T. Before is nil for
+// creates and snapshot reads, After is nil for deletes. Before only holds every
+// column if the table's REPLICA IDENTITY is FULL; otherwise it is nil for updates
+// and only holds the key for deletes.
+type Event[T any] struct {
+	Op     Op
+	Before *T
+	After  *T
+	// TsMs is when Debezium processed the event, in milliseconds since the epoch.
+	TsMs   int64
+	Source json.RawMessage
+}
+
+// envelope is the Debezium payload; with schemas enabled it is wrapped in {"schema", "payload"}.
+type envelope struct {
+	Op     Op              `json:"op"`
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+	TsMs   int64           `json:"ts_ms"`
+	Source json.RawMessage `json:"source"`
+}
+
+var nullLiteral = []byte("null")
+
+// Decode decodes a Debezium event with or without its schema wrapper.
+func Decode[T any](data []byte) (Event[T], error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, nullLiteral) {
+		return Event[T]{}, ErrTombstone
+	}
+	var wrapped struct {
+		Payload json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(data, &wrapped); err != nil {
+		return Event[T]{}, fmt.Errorf("cdc: %w", err)
+	}
+	if len(wrapped.Payload) > 0 {
+		data = wrapped.Payload
+	}
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return Event[T]{}, fmt.Errorf("cdc: %w", err)
+	}
+	if env.Op == "" {
+		return Event[T]{}, errors.New("cdc: missing op, not a Debezium change event")
+	}
+
+	e := Event[T]{Op: env.Op, TsMs: env.TsMs, Source: env.Source}
+	var err error
+	if e.Before, err = image[T](env.Before); err != nil {
+		return Event[T]{}, fmt.Errorf("cdc: before: %w", err)
+	}
+	if e.After, err = image[T](env.After); err != nil {
+		return Event[T]{}, fmt.Errorf("cdc: after: %w", err)
+	}
+	return e, nil
+}
+
+func image[T any](raw json.RawMessage) (*T, error) {
+	if len(raw) == 0 || bytes.Equal(raw, nullLiteral) {
+		return nil, nil
+	}
+	var v T
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// Diff reports the fields the event changed, comparing Before and After with
+// forms.Diff. A missing image counts as a row of nulls, so creates report every
+// non-null column as set and deletes every non-null column as cleared.
+// Fields undefined in either image are not reported.
+func (e Event[T]) Diff() (forms.Changes, error) {
+	before, after := nullImage[T](), nullImage[T]()
+	if e.Before != nil {
+		before = *e.Before
+	}
+	if e.After != nil {
+		after = *e.After
+	}
+	return forms.Diff(before, after)
+}
+
+// nullImage returns a T whose fields are all null, explicitly so for those that
+// would otherwise be undefined.
+func nullImage[T any]() T {
+	var v T
+	rv := reflect.ValueOf(&v).Elem()
+	if rv.Kind() != reflect.Struct {
+		return v
+	}
+	for _, f := range nullreflect.Fields(rv.Type()) {
+		fv := rv.FieldByIndex(f.Index)
+		if nullreflect.CanBeUndefined(fv.Type()) {
+			// Scanning nil into a Scanner never fails for the package's types.
+			_ = nullreflect.Write(fv, nil, nullstate.Null)
+		}
+	}
+	return v
+}
@@ -0,0 +1,109 @@
+// Package convert translates nullable values between github.com/guregu/null/v6
+// and github.com/jackc/pgx/v5/pgtype, so API DTOs can stay on null.XxX types while
+// repository code keeps using pgtype.XxX types. It also maps both onto protobuf
+// well-known wrappers for gRPC services.
+package convert
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NullStringToPgText converts null.String to pgtype.Text.
+func NullStringToPgText(s null.String) pgtype.Text {
+	return pgtype.Text{String: s.String, Valid: s.Valid}
+}
+
+// PgTextToNullString converts pgtype.Text to null.String.
+func PgTextToNullString(t pgtype.Text) null.String {
+	return null.NewString(t.String, t.Valid)
+}
+
+// NullIntToPgInt8 converts null.Int to pgtype.Int8.
+func NullIntToPgInt8(i null.Int) pgtype.Int8 {
+	return pgtype.Int8{Int64: i.Int64, Valid: i.Valid}
+}
+
+// PgInt8ToNullInt converts pgtype.Int8 to null.Int.
+func PgInt8ToNullInt(i pgtype.Int8) null.Int {
+	return null.NewInt(i.Int64, i.Valid)
+}
+
+// NullInt32ToPgInt4 converts null.Int32 to pgtype.Int4.
+func NullInt32ToPgInt4(i null.Int32) pgtype.Int4 {
+	return pgtype.Int4{Int32: i.Int32, Valid: i.Valid}
+}
+
+// PgInt4ToNullInt32 converts pgtype.Int4 to null.Int32.
+func PgInt4ToNullInt32(i pgtype.Int4) null.Int32 {
+	return null.NewInt32(i.Int32, i.Valid)
+}
+
+// NullInt16ToPgInt2 converts null.Int16 to pgtype.Int2.
+func NullInt16ToPgInt2(i null.Int16) pgtype.Int2 {
+	return pgtype.Int2{Int16: i.Int16, Valid: i.Valid}
+}
+
+// PgInt2ToNullInt16 converts pgtype.Int2 to null.Int16.
+func PgInt2ToNullInt16(i pgtype.Int2) null.Int16 {
+	return null.NewInt16(i.Int16, i.Valid)
+}
+
+// NullFloatToPgFloat8 converts null.Float to pgtype.Float8.
+func NullFloatToPgFloat8(f null.Float) pgtype.Float8 {
+	return pgtype.Float8{Float64: f.Float64, Valid: f.Valid}
+}
+
+// PgFloat8ToNullFloat converts pgtype.Float8 to null.Float.
+func PgFloat8ToNullFloat(f pgtype.Float8) null.Float {
+	return null.NewFloat(f.Float64, f.Valid)
+}
+
+// NullBoolToPgBool converts null.Bool to pgtype.Bool.
+func NullBoolToPgBool(b null.Bool) pgtype.Bool {
+	return pgtype.Bool{Bool: b.Bool, Valid: b.Valid}
+}
+
+// PgBoolToNullBool converts pgtype.Bool to null.Bool.
+func PgBoolToNullBool(b pgtype.Bool) null.Bool {
+	return null.NewBool(b.Bool, b.Valid)
+}
+
+// NullTimeToPgTimestamptz converts null.Time to pgtype.Timestamptz.
+func NullTimeToPgTimestamptz(t null.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t.Time, Valid: t.Valid}
+}
+
+// PgTimestamptzToNullTime converts pgtype.Timestamptz to null.Time.
+// Infinite timestamps cannot be represented by time.Time and convert to null.
+func PgTimestamptzToNullTime(t pgtype.Timestamptz) null.Time {
+	return null.NewTime(t.Time, t.Valid && t.InfinityModifier == pgtype.Finite)
+}
+
+// NullTimeToPgTimestamp converts null.Time to pgtype.Timestamp.
+func NullTimeToPgTimestamp(t null.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t.Time, Valid: t.Valid}
+}
+
+// PgTimestampToNullTime converts pgtype.Timestamp to null.Time.
+// Infinite timestamps cannot be represented by time.Time and convert to null.
+func PgTimestampToNullTime(t pgtype.Timestamp) null.Time {
+	return null.NewTime(t.Time, t.Valid && t.InfinityModifier == pgtype.Finite)
+}
+
+// NullTimeToPgDate converts null.Time to pgtype.Date, discarding the time of day.
+func NullTimeToPgDate(t null.Time) pgtype.Date {
+	if !t.Valid {
+		return pgtype.Date{}
+	}
+	y, m, d := t.Time.Date()
+	return pgtype.Date{Time: time.Date(y, m, d, 0, 0, 0, 0, time.UTC), Valid: true}
+}
+
+// PgDateToNullTime converts pgtype.Date to null.Time.
+// Infinite dates cannot be represented by time.Time and convert to null.
+func PgDateToNullTime(d pgtype.Date) null.Time {
+	return null.NewTime(d.Time, d.Valid && d.InfinityModifier == pgtype.Finite)
+}
@@ -0,0 +1,40 @@
+package convert
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+func TestPgtypeHelpers(t *testing.T) {
+	born := time.Date(1990, time.May, 17, 8, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		got, want any
+	}{
+		{name: "text", got: PgTextToNullString(NullStringToPgText(null.StringFrom("a"))), want: null.StringFrom("a")},
+		{name: "null text", got: NullStringToPgText(null.String{}), want: pgtype.Text{}},
+		{name: "int8", got: PgInt8ToNullInt(NullIntToPgInt8(null.IntFrom(7))), want: null.IntFrom(7)},
+		{name: "int4", got: NullInt32ToPgInt4(null.Int32From(7)), want: pgtype.Int4{Int32: 7, Valid: true}},
+		{name: "bool", got: PgBoolToNullBool(pgtype.Bool{}), want: null.Bool{}},
+		{name: "timestamptz", got: PgTimestamptzToNullTime(NullTimeToPgTimestamptz(null.TimeFrom(born))), want: null.TimeFrom(born)},
+		{name: "date drops the time of day", got: NullTimeToPgDate(null.TimeFrom(born)), want: pgtype.Date{Time: time.Date(1990, time.May, 17, 0, 0, 0, 0, time.UTC), Valid: true}},
+		{name: "ptr", got: *PgTextToPtr(PgTextFromPtr(new(string))), want: ""},
+		{name: "nil ptr", got: PgInt4FromPtr(nil), want: pgtype.Int4{}},
+		{name: "presence", got: ToPresence(FromPresence[int32](nil)), want: (*int32)(nil)},
+		{name: "wrapper", got: FromStringValue(StringValue(nullable.From("a"))), want: nullable.From("a")},
+		{name: "null wrapper", got: StringValue(nullable.Null[string]{}) == nil, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %#v, want %#v", tt.got, tt.want)
+			}
+		})
+	}
+}
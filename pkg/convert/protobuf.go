@@ -0,0 +1,215 @@
+package convert
+
+import (
+	"time"
+
+	"github.com/guregu/null/v6"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Well-known wrapper messages carry nullability through message presence,
+// a nil wrapper is null and a non-nil wrapper is a value.
+
+// NullStringToStringValue converts null.String to *wrapperspb.StringValue.
+func NullStringToStringValue(s null.String) *wrapperspb.StringValue {
+	if !s.Valid {
+		return nil
+	}
+	return wrapperspb.String(s.String)
+}
+
+// StringValueToNullString converts *wrapperspb.StringValue to null.String.
+func StringValueToNullString(v *wrapperspb.StringValue) null.String {
+	if v == nil {
+		return null.String{}
+	}
+	return null.StringFrom(v.Value)
+}
+
+// NullInt32ToInt32Value converts null.Int32 to *wrapperspb.Int32Value.
+func NullInt32ToInt32Value(i null.Int32) *wrapperspb.Int32Value {
+	if !i.Valid {
+		return nil
+	}
+	return wrapperspb.Int32(i.Int32)
+}
+
+// Int32ValueToNullInt32 converts *wrapperspb.Int32Value to null.Int32.
+func Int32ValueToNullInt32(v *wrapperspb.Int32Value) null.Int32 {
+	if v == nil {
+		return null.Int32{}
+	}
+	return null.Int32From(v.Value)
+}
+
+// NullIntToInt64Value converts null.Int to *wrapperspb.Int64Value.
+func NullIntToInt64Value(i null.Int) *wrapperspb.Int64Value {
+	if !i.Valid {
+		return nil
+	}
+	return wrapperspb.Int64(i.Int64)
+}
+
+// Int64ValueToNullInt converts *wrapperspb.Int64Value to null.Int.
+func Int64ValueToNullInt(v *wrapperspb.Int64Value) null.Int {
+	if v == nil {
+		return null.Int{}
+	}
+	return null.IntFrom(v.Value)
+}
+
+// NullFloatToDoubleValue converts null.Float to *wrapperspb.DoubleValue.
+func NullFloatToDoubleValue(f null.Float) *wrapperspb.DoubleValue {
+	if !f.Valid {
+		return nil
+	}
+	return wrapperspb.Double(f.Float64)
+}
+
+// DoubleValueToNullFloat converts *wrapperspb.DoubleValue to null.Float.
+func DoubleValueToNullFloat(v *wrapperspb.DoubleValue) null.Float {
+	if v == nil {
+		return null.Float{}
+	}
+	return null.FloatFrom(v.Value)
+}
+
+// NullBoolToBoolValue converts null.Bool to *wrapperspb.BoolValue.
+func NullBoolToBoolValue(b null.Bool) *wrapperspb.BoolValue {
+	if !b.Valid {
+		return nil
+	}
+	return wrapperspb.Bool(b.Bool)
+}
+
+// BoolValueToNullBool converts *wrapperspb.BoolValue to null.Bool.
+func BoolValueToNullBool(v *wrapperspb.BoolValue) null.Bool {
+	if v == nil {
+		return null.Bool{}
+	}
+	return null.BoolFrom(v.Value)
+}
+
+// NullTimeToTimestamp converts null.Time to *timestamppb.Timestamp.
+func NullTimeToTimestamp(t null.Time) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
+
+// TimestampToNullTime converts *timestamppb.Timestamp to null.Time.
+// Timestamps outside the range accepted by protobuf convert to null.
+func TimestampToNullTime(v *timestamppb.Timestamp) null.Time {
+	if v == nil || v.CheckValid() != nil {
+		return null.Time{}
+	}
+	return null.TimeFrom(v.AsTime())
+}
+
+// StringValue converts nullable.Null[string] to *wrapperspb.StringValue.
+func StringValue(n nullable.Null[string]) *wrapperspb.StringValue {
+	return wrap(n, wrapperspb.String)
+}
+
+// FromStringValue converts *wrapperspb.StringValue to nullable.Null[string].
+func FromStringValue(v *wrapperspb.StringValue) nullable.Null[string] {
+	if v == nil {
+		return nullable.Null[string]{}
+	}
+	return nullable.From(v.Value)
+}
+
+// Int32Value converts nullable.Null[int32] to *wrapperspb.Int32Value.
+func Int32Value(n nullable.Null[int32]) *wrapperspb.Int32Value {
+	return wrap(n, wrapperspb.Int32)
+}
+
+// FromInt32Value converts *wrapperspb.Int32Value to nullable.Null[int32].
+func FromInt32Value(v *wrapperspb.Int32Value) nullable.Null[int32] {
+	if v == nil {
+		return nullable.Null[int32]{}
+	}
+	return nullable.From(v.Value)
+}
+
+// Int64Value converts nullable.Null[int64] to *wrapperspb.Int64Value.
+func Int64Value(n nullable.Null[int64]) *wrapperspb.Int64Value {
+	return wrap(n, wrapperspb.Int64)
+}
+
+// FromInt64Value converts *wrapperspb.Int64Value to nullable.Null[int64].
+func FromInt64Value(v *wrapperspb.Int64Value) nullable.Null[int64] {
+	if v == nil {
+		return nullable.Null[int64]{}
+	}
+	return nullable.From(v.Value)
+}
+
+// DoubleValue converts nullable.Null[float64] to *wrapperspb.DoubleValue.
+func DoubleValue(n nullable.Null[float64]) *wrapperspb.DoubleValue {
+	return wrap(n, wrapperspb.Double)
+}
+
+// FromDoubleValue converts *wrapperspb.DoubleValue to nullable.Null[float64].
+func FromDoubleValue(v *wrapperspb.DoubleValue) nullable.Null[float64] {
+	if v == nil {
+		return nullable.Null[float64]{}
+	}
+	return nullable.From(v.Value)
+}
+
+// BoolValue converts nullable.Null[bool] to *wrapperspb.BoolValue.
+func BoolValue(n nullable.Null[bool]) *wrapperspb.BoolValue {
+	return wrap(n, wrapperspb.Bool)
+}
+
+// FromBoolValue converts *wrapperspb.BoolValue to nullable.Null[bool].
+func FromBoolValue(v *wrapperspb.BoolValue) nullable.Null[bool] {
+	if v == nil {
+		return nullable.Null[bool]{}
+	}
+	return nullable.From(v.Value)
+}
+
+// Timestamp converts nullable.Null[time.Time] to *timestamppb.Timestamp.
+func Timestamp(n nullable.Null[time.Time]) *timestamppb.Timestamp {
+	return wrap(n, timestamppb.New)
+}
+
+// FromTimestamp converts *timestamppb.Timestamp to nullable.Null[time.Time].
+// Timestamps outside the range accepted by protobuf convert to null.
+func FromTimestamp(v *timestamppb.Timestamp) nullable.Null[time.Time] {
+	if v == nil || v.CheckValid() != nil {
+		return nullable.Null[time.Time]{}
+	}
+	return nullable.From(v.AsTime())
+}
+
+func wrap[T comparable, M any](n nullable.Null[T], fn func(T) *M) *M {
+	if !n.Valid {
+		return nil
+	}
+	return fn(n.V)
+}
+
+// Proto3 optional scalars are generated as pointer fields, a nil pointer
+// meaning the field was not set on the wire.
+
+// FromPresence converts a proto3 optional field to nullable.Optional,
+// an unset field becoming undefined.
+func FromPresence[T comparable](p *T) nullable.Optional[T] {
+	if p == nil {
+		return nullable.Optional[T]{}
+	}
+	return nullable.OptionalFrom(*p)
+}
+
+// ToPresence converts nullable.Optional to a proto3 optional field,
+// undefined and null both leaving the field unset.
+func ToPresence[T comparable](o nullable.Optional[T]) *T {
+	return o.Ptr()
+}
@@ -0,0 +1,180 @@
+package convert
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PgTextFromPtr converts *string to pgtype.Text, nil becoming null.
+func PgTextFromPtr(p *string) pgtype.Text {
+	if p == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *p, Valid: true}
+}
+
+// PgTextToPtr converts pgtype.Text to *string, null becoming nil.
+func PgTextToPtr(v pgtype.Text) *string {
+	if !v.Valid {
+		return nil
+	}
+	x := v.String
+	return &x
+}
+
+// PgInt2FromPtr converts *int16 to pgtype.Int2, nil becoming null.
+func PgInt2FromPtr(p *int16) pgtype.Int2 {
+	if p == nil {
+		return pgtype.Int2{}
+	}
+	return pgtype.Int2{Int16: *p, Valid: true}
+}
+
+// PgInt2ToPtr converts pgtype.Int2 to *int16, null becoming nil.
+func PgInt2ToPtr(v pgtype.Int2) *int16 {
+	if !v.Valid {
+		return nil
+	}
+	x := v.Int16
+	return &x
+}
+
+// PgInt4FromPtr converts *int32 to pgtype.Int4, nil becoming null.
+func PgInt4FromPtr(p *int32) pgtype.Int4 {
+	if p == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *p, Valid: true}
+}
+
+// PgInt4ToPtr converts pgtype.Int4 to *int32, null becoming nil.
+func PgInt4ToPtr(v pgtype.Int4) *int32 {
+	if !v.Valid {
+		return nil
+	}
+	x := v.Int32
+	return &x
+}
+
+// PgInt8FromPtr converts *int64 to pgtype.Int8, nil becoming null.
+func PgInt8FromPtr(p *int64) pgtype.Int8 {
+	if p == nil {
+		return pgtype.Int8{}
+	}
+	return pgtype.Int8{Int64: *p, Valid: true}
+}
+
+// PgInt8ToPtr converts pgtype.Int8 to *int64, null becoming nil.
+func PgInt8ToPtr(v pgtype.Int8) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	x := v.Int64
+	return &x
+}
+
+// PgFloat4FromPtr converts *float32 to pgtype.Float4, nil becoming null.
+func PgFloat4FromPtr(p *float32) pgtype.Float4 {
+	if p == nil {
+		return pgtype.Float4{}
+	}
+	return pgtype.Float4{Float32: *p, Valid: true}
+}
+
+// PgFloat4ToPtr converts pgtype.Float4 to *float32, null becoming nil.
+func PgFloat4ToPtr(v pgtype.Float4) *float32 {
+	if !v.Valid {
+		return nil
+	}
+	x := v.Float32
+	return &x
+}
+
+// PgFloat8FromPtr converts *float64 to pgtype.Float8, nil becoming null.
+func PgFloat8FromPtr(p *float64) pgtype.Float8 {
+	if p == nil {
+		return pgtype.Float8{}
+	}
+	return pgtype.Float8{Float64: *p, Valid: true}
+}
+
+// PgFloat8ToPtr converts pgtype.Float8 to *float64, null becoming nil.
+func PgFloat8ToPtr(v pgtype.Float8) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	x := v.Float64
+	return &x
+}
+
+// PgBoolFromPtr converts *bool to pgtype.Bool, nil becoming null.
+func PgBoolFromPtr(p *bool) pgtype.Bool {
+	if p == nil {
+		return pgtype.Bool{}
+	}
+	return pgtype.Bool{Bool: *p, Valid: true}
+}
+
+// PgBoolToPtr converts pgtype.Bool to *bool, null becoming nil.
+func PgBoolToPtr(v pgtype.Bool) *bool {
+	if !v.Valid {
+		return nil
+	}
+	x := v.Bool
+	return &x
+}
+
+// PgTimestamptzFromPtr converts *time.Time to pgtype.Timestamptz, nil becoming null.
+func PgTimestamptzFromPtr(p *time.Time) pgtype.Timestamptz {
+	if p == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *p, Valid: true}
+}
+
+// PgTimestamptzToPtr converts pgtype.Timestamptz to *time.Time, null becoming nil.
+// Infinite values cannot be represented by time.Time and convert to nil.
+func PgTimestamptzToPtr(v pgtype.Timestamptz) *time.Time {
+	if !v.Valid || v.InfinityModifier != pgtype.Finite {
+		return nil
+	}
+	x := v.Time
+	return &x
+}
+
+// PgTimestampFromPtr converts *time.Time to pgtype.Timestamp, nil becoming null.
+func PgTimestampFromPtr(p *time.Time) pgtype.Timestamp {
+	if p == nil {
+		return pgtype.Timestamp{}
+	}
+	return pgtype.Timestamp{Time: *p, Valid: true}
+}
+
+// PgTimestampToPtr converts pgtype.Timestamp to *time.Time, null becoming nil.
+// Infinite values cannot be represented by time.Time and convert to nil.
+func PgTimestampToPtr(v pgtype.Timestamp) *time.Time {
+	if !v.Valid || v.InfinityModifier != pgtype.Finite {
+		return nil
+	}
+	x := v.Time
+	return &x
+}
+
+// PgDateFromPtr converts *time.Time to pgtype.Date, nil becoming null.
+func PgDateFromPtr(p *time.Time) pgtype.Date {
+	if p == nil {
+		return pgtype.Date{}
+	}
+	return pgtype.Date{Time: *p, Valid: true}
+}
+
+// PgDateToPtr converts pgtype.Date to *time.Time, null becoming nil.
+// Infinite values cannot be represented by time.Time and convert to nil.
+func PgDateToPtr(v pgtype.Date) *time.Time {
+	if !v.Valid || v.InfinityModifier != pgtype.Finite {
+		return nil
+	}
+	x := v.Time
+	return &x
+}
@@ -0,0 +1,285 @@
+package convert
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// Registry holds adapters between application types and types the struct mapper
+// understands, so bespoke column types such as an encrypted string still map
+// automatically:
+//
+//	convert.Register(convert.DefaultRegistry, func(s myapp.EncryptedString) (pgtype.Text, error) {
+//		return s.Seal()
+//	})
+//	convert.Register(convert.DefaultRegistry, func(t pgtype.Text) (myapp.EncryptedString, error) {
+//		return myapp.Open(t)
+//	})
+//
+// A Registry is safe for concurrent use, but adapters should be registered during initialization.
+type Registry struct {
+	mu   sync.RWMutex
+	from map[reflect.Type][]adapter // by source type, in registration order
+	into map[reflect.Type][]adapter // by target type, in registration order
+	// plans caches the steps converting a struct type into another, built from the
+	// adapters above and dropped whenever one is registered.
+	plans map[planKey][]step
+}
+
+type adapter struct {
+	src, dst reflect.Type
+	fn       func(reflect.Value) (reflect.Value, error)
+}
+
+// DefaultRegistry is consulted by Struct and by package sqlbuild.
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		from:  make(map[reflect.Type][]adapter),
+		into:  make(map[reflect.Type][]adapter),
+		plans: make(map[planKey][]step),
+	}
+}
+
+// Register adds an adapter converting A into B to r. Register both directions
+// for types that are read as well as written.
+//
+// The first adapter registered from a type also says how to store it: Registry.Struct
+// and Value convert through it before reading the value as usual, and the first one
+// registered into a type lets fields of that type be written from anything that can be
+// written into the adapter's source type.
+func Register[A, B any](r *Registry, fn func(A) (B, error)) {
+	a := adapter{
+		src: reflect.TypeFor[A](),
+		dst: reflect.TypeFor[B](),
+		fn: func(v reflect.Value) (reflect.Value, error) {
+			b, err := fn(v.Interface().(A))
+			return reflect.ValueOf(&b).Elem(), err
+		},
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.from[a.src] = append(r.from[a.src], a)
+	r.into[a.dst] = append(r.into[a.dst], a)
+	clear(r.plans)
+}
+
+// direct returns the adapter converting src into dst, if any.
+func (r *Registry) direct(src, dst reflect.Type) (adapter, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, a := range r.from[src] {
+		if a.dst == dst {
+			return a, true
+		}
+	}
+	return adapter{}, false
+}
+
+// first returns the first adapter registered in m for t.
+func (r *Registry) first(m map[reflect.Type][]adapter, t reflect.Type) (adapter, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if as := m[t]; len(as) > 0 {
+		return as[0], true
+	}
+	return adapter{}, false
+}
+
+// Value returns x converted by the first adapter registered from its type, or x itself
+// if there is none, for use as a SQL argument. Adapter errors are deferred to the driver
+// by returning a driver.Valuer that fails with them.
+func (r *Registry) Value(x any) any {
+	if x == nil {
+		return nil
+	}
+	a, ok := r.first(r.from, reflect.TypeOf(x))
+	if !ok {
+		return x
+	}
+	v, err := a.fn(reflect.ValueOf(x))
+	if err != nil {
+		return errValuer{err}
+	}
+	return v.Interface()
+}
+
+type errValuer struct{ err error }
+
+func (e errValuer) Value() (driver.Value, error) {
+	return nil, e.err
+}
+
+// Struct is like the package-level Struct, consulting the adapters of r:
+// fields whose types have an adapter between them are converted with it, and other
+// fields go through the first adapter registered from their source type or into
+// their destination type, if any.
+func (r *Registry) Struct(src, dst any) error {
+	sv, dv, err := structValues(src, dst)
+	if err != nil {
+		return err
+	}
+	var fe forms.FieldErrors
+	r.structFields(dv, sv, "", &fe)
+	return fe.Err()
+}
+
+// converter converts src into dst, reporting failures under path.
+type converter func(r *Registry, dst, src reflect.Value, path string, fe *forms.FieldErrors)
+
+// step converts a field of a source struct into the same-named field of a destination one.
+type step struct {
+	name     string
+	src, dst []int
+	convert  converter
+}
+
+type planKey struct{ src, dst reflect.Type }
+
+// plan returns the steps converting struct type src into dst, with the converter
+// of each field pair chosen once from the adapters of r.
+func (r *Registry) plan(src, dst reflect.Type) []step {
+	key := planKey{src, dst}
+	r.mu.RLock()
+	steps, ok := r.plans[key]
+	r.mu.RUnlock()
+	if ok {
+		return steps
+	}
+	pairs := nullreflect.Pairs(src, dst)
+	steps = make([]step, len(pairs))
+	for i, p := range pairs {
+		st, dt := src.FieldByIndex(p.Src).Type, dst.FieldByIndex(p.Dst).Type
+		steps[i] = step{name: p.Name, src: p.Src, dst: p.Dst, convert: r.converter(st, dt)}
+	}
+	r.mu.Lock()
+	r.plans[key] = steps
+	r.mu.Unlock()
+	return steps
+}
+
+// structFields converts the same-named fields of the structs sv into dv, reporting
+// failures under path, the dotted path of the structs within the top-level ones.
+func (r *Registry) structFields(dv, sv reflect.Value, path string, fe *forms.FieldErrors) {
+	for _, s := range r.plan(sv.Type(), dv.Type()) {
+		name := s.name
+		if path != "" {
+			name = path + "." + name
+		}
+		s.convert(r, dv.FieldByIndex(s.dst), sv.FieldByIndex(s.src), name, fe)
+	}
+}
+
+// converter returns the converter of values of type src into dst, recursing into nested
+// DTOs and slices of them unless an adapter applies to their types.
+func (r *Registry) converter(src, dst reflect.Type) converter {
+	if !r.adapts(src, dst) {
+		if nullreflect.IsNested(src) && nullreflect.IsNested(dst) {
+			return (*Registry).nested
+		}
+		_, srcSlice := nullreflect.NestedSlice(src)
+		_, dstSlice := nullreflect.NestedSlice(dst)
+		if srcSlice && dstSlice {
+			elem := r.converter(src.Elem(), dst.Elem())
+			return func(r *Registry, dst, src reflect.Value, path string, fe *forms.FieldErrors) {
+				if src.IsNil() {
+					dst.SetZero()
+					return
+				}
+				s := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+				for i := range src.Len() {
+					elem(r, s.Index(i), src.Index(i), fmt.Sprintf("%s[%d]", path, i), fe)
+				}
+				dst.Set(s)
+			}
+		}
+	}
+	field := r.field(src, dst)
+	return func(_ *Registry, dst, src reflect.Value, path string, fe *forms.FieldErrors) {
+		if err := field(dst, src); err != nil {
+			fe.Add(path, err.Error())
+		}
+	}
+}
+
+// nested converts the nested DTO src into dst, either of which may be a pointer.
+// A nil src pointer clears dst.
+func (r *Registry) nested(dst, src reflect.Value, path string, fe *forms.FieldErrors) {
+	if src.Kind() == reflect.Pointer {
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		src = src.Elem()
+	}
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		dst = dst.Elem()
+	}
+	r.structFields(dst, src, path, fe)
+}
+
+// adapts reports whether an adapter of r applies to a field of type src written into dst.
+func (r *Registry) adapts(src, dst reflect.Type) bool {
+	if _, ok := r.direct(src, dst); ok {
+		return true
+	}
+	if _, ok := r.first(r.from, src); ok {
+		return true
+	}
+	_, ok := r.first(r.into, dst)
+	return ok
+}
+
+// field returns the conversion of a value of type src into dst: through the adapter
+// between them, or read through the first adapter from src and written through the
+// first adapter into dst, if any.
+func (r *Registry) field(src, dst reflect.Type) func(dst, src reflect.Value) error {
+	if a, ok := r.direct(src, dst); ok {
+		return func(dst, src reflect.Value) error {
+			v, err := a.fn(src)
+			if err != nil {
+				return err
+			}
+			dst.Set(v)
+			return nil
+		}
+	}
+	from, hasFrom := r.first(r.from, src)
+	into, hasInto := r.first(r.into, dst)
+	return func(dst, src reflect.Value) error {
+		if hasFrom {
+			v, err := from.fn(src)
+			if err != nil {
+				return err
+			}
+			src = v
+		}
+		val, state, err := nullreflect.Read(src)
+		if err != nil {
+			return err
+		}
+		if !hasInto {
+			return nullreflect.Write(dst, val, state)
+		}
+		tmp := reflect.New(into.src).Elem()
+		if err := nullreflect.Write(tmp, val, state); err != nil {
+			return err
+		}
+		v, err := into.fn(tmp)
+		if err != nil {
+			return fmt.Errorf("converting %s: %w", into.src, err)
+		}
+		dst.Set(v)
+		return nil
+	}
+}
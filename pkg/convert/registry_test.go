@@ -0,0 +1,147 @@
+package convert
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// cents is an application type stored as a string through adapters.
+type cents int64
+
+type price struct{ Amount cents }
+
+type pgPrice struct{ Amount pgtype.Text }
+
+type jsonPrice struct{ Amount nullable.Null[string] }
+
+func TestRegistryStruct(t *testing.T) {
+	r := NewRegistry()
+	Register(r, func(c cents) (string, error) {
+		return strconv.FormatInt(int64(c), 10), nil
+	})
+	Register(r, func(s string) (cents, error) {
+		if s == "" {
+			return 0, errors.New("empty amount")
+		}
+		n, err := strconv.ParseInt(s, 10, 64)
+		return cents(n), err
+	})
+	Register(r, func(c cents) (pgtype.Text, error) {
+		return pgtype.Text{String: "direct", Valid: true}, nil
+	})
+
+	tests := []struct {
+		name    string
+		src     any
+		dst     any
+		want    any
+		wantErr string
+	}{
+		{
+			name: "direct adapter wins",
+			src:  price{Amount: 150},
+			dst:  &pgPrice{},
+			want: &pgPrice{Amount: pgtype.Text{String: "direct", Valid: true}},
+		},
+		{
+			name: "read through the first adapter from the source type",
+			src:  price{Amount: 150},
+			dst:  &jsonPrice{},
+			want: &jsonPrice{Amount: nullable.From("150")},
+		},
+		{
+			name: "written through the first adapter into the target type",
+			src:  jsonPrice{Amount: nullable.From("275")},
+			dst:  &price{},
+			want: &price{Amount: 275},
+		},
+		{
+			name:    "adapter errors are reported",
+			src:     jsonPrice{Amount: nullable.From("")},
+			dst:     &price{},
+			wantErr: "Amount",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := r.Struct(tt.src, tt.dst)
+			if tt.wantErr != "" {
+				var fe forms.FieldErrors
+				if !errors.As(err, &fe) || len(fe[tt.wantErr]) == 0 {
+					t.Fatalf("Struct = %v, want an error for %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tt.dst, tt.want) {
+				t.Errorf("Struct = %+v, want %+v", tt.dst, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterDropsCachedPlans(t *testing.T) {
+	type src struct{ V cents }
+	type dst struct{ V pgtype.Text }
+	r := NewRegistry()
+	var d dst
+	if err := r.Struct(src{V: 1}, &d); err == nil {
+		t.Fatalf("Struct without adapters = %+v, want an error", d)
+	}
+	Register(r, func(c cents) (pgtype.Text, error) {
+		return pgtype.Text{String: "one", Valid: true}, nil
+	})
+	if err := r.Struct(src{V: 1}, &d); err != nil {
+		t.Fatal(err)
+	}
+	if d.V != (pgtype.Text{String: "one", Valid: true}) {
+		t.Errorf("Struct after Register = %+v, want the adapter applied", d)
+	}
+}
+
+func TestRegistryValue(t *testing.T) {
+	r := NewRegistry()
+	Register(r, func(c cents) (int64, error) {
+		if c < 0 {
+			return 0, errors.New("negative")
+		}
+		return int64(c) * 100, nil
+	})
+	tests := []struct {
+		name    string
+		in      any
+		want    any
+		wantErr bool
+	}{
+		{name: "nil", in: nil, want: nil},
+		{name: "no adapter", in: "x", want: "x"},
+		{name: "adapted", in: cents(2), want: int64(200)},
+		{name: "deferred error", in: cents(-1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.Value(tt.in)
+			if ev, ok := got.(errValuer); ok {
+				if !tt.wantErr {
+					t.Fatalf("Value(%v): %v", tt.in, ev.err)
+				}
+				if _, err := ev.Value(); err == nil {
+					t.Error("errValuer.Value(): want the adapter error")
+				}
+				return
+			}
+			if tt.wantErr || got != tt.want {
+				t.Errorf("Value(%v) = %#v, want %#v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package convert
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Struct copies fields of src into dst by field name, translating between
+// nullable representations such as null.String, pgtype.Text, nullable.Null[string],
+// *string and string. Fields are read through driver.Valuer and written through
+// sql.Scanner, so any type implementing both participates.
+//
+// src must be a struct or a pointer to one, dst must be a non-nil pointer to a struct.
+// Fields that do not exist in both structs are left untouched. How each field is
+// converted, including the adapters it goes through, is worked out once per pair of
+// struct types and cached. Fields that cannot be converted are
+// reported together as forms.FieldErrors keyed by field name, with messages naming
+// the source value and target type, such as cannot parse "abc" as int32.
+//
+// Nested DTOs, structs that are not values themselves such as an AddressForm field,
+// and slices of them are converted recursively into their counterparts, element by
+// element, reporting failures by path, such as PreviousAddresses[1].PostalCode.
+//
+// Struct consults DefaultRegistry for adapters of application types.
+func Struct(src, dst any) error {
+	return DefaultRegistry.Struct(src, dst)
+}
+
+func structValues(src, dst any) (sv, dv reflect.Value, err error) {
+	sv = reflect.ValueOf(src)
+	if sv.Kind() == reflect.Pointer {
+		if sv.IsNil() {
+			return sv, dv, errors.New("convert: src is a nil pointer")
+		}
+		sv = sv.Elem()
+	}
+	if sv.Kind() != reflect.Struct {
+		return sv, dv, fmt.Errorf("convert: src must be a struct, got %T", src)
+	}
+	dv = reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return sv, dv, fmt.Errorf("convert: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	return sv, dv.Elem(), nil
+}
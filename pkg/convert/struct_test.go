@@ -0,0 +1,190 @@
+package convert
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type addressForm struct {
+	Street     null.String
+	PostalCode null.String
+}
+
+type pgAddress struct {
+	Street     pgtype.Text
+	PostalCode pgtype.Int4
+}
+
+type personForm struct {
+	Name     null.String
+	Nickname *string
+	Age      nullable.Null[int32]
+	Born     null.Time
+	Score    string
+	Current  addressForm
+	Mailing  *addressForm
+	Previous []addressForm
+	Only     string
+}
+
+type pgPerson struct {
+	Name     pgtype.Text
+	Nickname nullable.Null[string]
+	Age      pgtype.Int8
+	Born     pgtype.Timestamptz
+	Score    nullable.Null[float64]
+	Current  *pgAddress
+	Mailing  pgAddress
+	Previous []pgAddress
+	Extra    string
+}
+
+func TestStruct(t *testing.T) {
+	born := time.Date(1990, time.May, 17, 8, 0, 0, 0, time.UTC)
+	nick := "ah boy"
+	tests := []struct {
+		name string
+		src  any
+		dst  pgPerson
+		want pgPerson
+	}{
+		{
+			name: "values",
+			src: personForm{
+				Name:     null.StringFrom("Tan"),
+				Nickname: &nick,
+				Age:      nullable.From[int32](34),
+				Born:     null.TimeFrom(born),
+				Score:    "1.5",
+			},
+			want: pgPerson{
+				Name:     pgtype.Text{String: "Tan", Valid: true},
+				Nickname: nullable.From("ah boy"),
+				Age:      pgtype.Int8{Int64: 34, Valid: true},
+				Born:     pgtype.Timestamptz{Time: born, Valid: true},
+				Score:    nullable.From(1.5),
+				Current:  &pgAddress{},
+			},
+		},
+		{
+			name: "nulls clear",
+			src:  &personForm{Score: "2"},
+			dst: pgPerson{
+				Name:     pgtype.Text{String: "old", Valid: true},
+				Nickname: nullable.From("old"),
+				Age:      pgtype.Int8{Int64: 1, Valid: true},
+				Extra:    "kept",
+			},
+			want: pgPerson{Score: nullable.From(2.0), Current: &pgAddress{}, Extra: "kept"},
+		},
+		{
+			name: "nested and slices",
+			src: personForm{
+				Score:    "0",
+				Current:  addressForm{Street: null.StringFrom("Orchard Rd"), PostalCode: null.StringFrom("238801")},
+				Mailing:  &addressForm{PostalCode: null.StringFrom("018989")},
+				Previous: []addressForm{{Street: null.StringFrom("A")}, {PostalCode: null.StringFrom("1")}},
+			},
+			want: pgPerson{
+				Score:   nullable.From(0.0),
+				Current: &pgAddress{Street: pgtype.Text{String: "Orchard Rd", Valid: true}, PostalCode: pgtype.Int4{Int32: 238801, Valid: true}},
+				Mailing: pgAddress{PostalCode: pgtype.Int4{Int32: 18989, Valid: true}},
+				Previous: []pgAddress{
+					{Street: pgtype.Text{String: "A", Valid: true}},
+					{PostalCode: pgtype.Int4{Int32: 1, Valid: true}},
+				},
+			},
+		},
+		{
+			name: "nil nested pointer clears",
+			src:  personForm{Score: "0"},
+			dst:  pgPerson{Mailing: pgAddress{Street: pgtype.Text{String: "old", Valid: true}}},
+			want: pgPerson{Score: nullable.From(0.0), Current: &pgAddress{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.dst
+			if err := Struct(tt.src, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Struct =\n%+v\nwant\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+type auditForm struct {
+	CreatedBy null.String
+	Name      null.String // hidden by the Name of the struct embedding it
+}
+
+type namedForm struct {
+	auditForm
+	Name null.String
+}
+
+type pgNamed struct {
+	Name      pgtype.Text
+	CreatedBy pgtype.Text
+}
+
+func TestStructEmbedded(t *testing.T) {
+	src := namedForm{auditForm: auditForm{CreatedBy: null.StringFrom("admin"), Name: null.StringFrom("hidden")}, Name: null.StringFrom("Tan")}
+	var pg pgNamed
+	if err := Struct(src, &pg); err != nil {
+		t.Fatal(err)
+	}
+	want := pgNamed{Name: pgtype.Text{String: "Tan", Valid: true}, CreatedBy: pgtype.Text{String: "admin", Valid: true}}
+	if pg != want {
+		t.Errorf("Struct = %+v, want %+v", pg, want)
+	}
+	var back namedForm
+	if err := Struct(pg, &back); err != nil {
+		t.Fatal(err)
+	}
+	if wantBack := (namedForm{auditForm: auditForm{CreatedBy: null.StringFrom("admin")}, Name: null.StringFrom("Tan")}); back != wantBack {
+		t.Errorf("Struct back = %+v, want %+v", back, wantBack)
+	}
+}
+
+func TestStructErrors(t *testing.T) {
+	src := personForm{
+		Score:    "abc",
+		Current:  addressForm{PostalCode: null.StringFrom("S238801")},
+		Previous: []addressForm{{}, {PostalCode: null.StringFrom("x")}},
+	}
+	var dst pgPerson
+	err := Struct(src, &dst)
+	var fe forms.FieldErrors
+	if !errors.As(err, &fe) {
+		t.Fatalf("Struct = %v, want forms.FieldErrors", err)
+	}
+	want := []string{"Current.PostalCode", "Previous[1].PostalCode", "Score"}
+	if got := fe.Fields(); !reflect.DeepEqual(got, want) {
+		t.Errorf("fields with errors = %q, want %q", got, want)
+	}
+	if msg := fe["Score"][0]; !strings.Contains(msg, `"abc"`) {
+		t.Errorf("Score error = %q, want it to name the source value", msg)
+	}
+
+	for name, args := range map[string][2]any{
+		"nil src":   {(*personForm)(nil), &dst},
+		"src int":   {1, &dst},
+		"dst value": {src, dst},
+	} {
+		if err := Struct(args[0], args[1]); err == nil {
+			t.Errorf("%s: want an error", name)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package csvnull
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/guregu/null/v6"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+func TestEncodeAppendMatchesCSVWriter(t *testing.T) {
+	cells := []string{"", "plain", "a,b", `say "hi"`, "line\nbreak", "cr\r", " lead", "\tlead", `\.`, `\N`, "trail ", "ünï", "a;b"}
+	for _, comma := range []rune{',', ';', '\t', '|', 'é'} {
+		for _, cell := range cells {
+			dto := struct {
+				A nullable.Null[string]
+				B null.String
+			}{nullable.From(cell), null.StringFrom("x")}
+			var want bytes.Buffer
+			cw := csv.NewWriter(&want)
+			cw.Comma = comma
+			if err := cw.Write([]string{cell, "x"}); err != nil {
+				t.Fatal(err)
+			}
+			cw.Flush()
+			got, err := EncodeAppend(nil, &dto, Comma(comma))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != want.String() {
+				t.Errorf("EncodeAppend(%q, Comma(%q)) = %q, want %q", cell, comma, got, want.String())
+			}
+		}
+	}
+}
+
+func TestEncodeAppendAfterHeader(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, []person(nil), NullToken("NULL")); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.Bytes()
+	var err error
+	for _, p := range []person{{ID: 1, Name: nullable.OptionalFrom("Tan")}, {ID: 2}} {
+		if out, err = EncodeAppend(out, p, NullToken("NULL")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := "id,name,married_name,age,email,birthday\n1,Tan,NULL,NULL,NULL,NULL\n2,NULL,NULL,NULL,NULL,NULL\n"
+	if string(out) != want {
+		t.Errorf("export =\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestEncodeAppendErrors(t *testing.T) {
+	dst := []byte("kept")
+	for name, call := range map[string]func() ([]byte, error){
+		"not a struct":      func() ([]byte, error) { return EncodeAppend(dst, 1) },
+		"invalid delimiter": func() ([]byte, error) { return EncodeAppend(dst, person{}, Comma('\n')) },
+		"unreadable field": func() ([]byte, error) {
+			return EncodeAppend(dst, struct{ F func() }{})
+		},
+	} {
+		got, err := call()
+		if err == nil || !strings.HasPrefix(err.Error(), "csvnull: ") {
+			t.Errorf("%s: EncodeAppend = %v, want a csvnull error", name, err)
+		}
+		if string(got) != "kept" {
+			t.Errorf("%s: EncodeAppend returned %q, want dst unchanged", name, got)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+// Package csvnull reads and writes CSV files of nullable DTOs.
+//
+// Columns are matched to fields by their `csv` tag or snake_cased name, and a
+// configurable token stands for null: cells holding it are null, columns missing
+// from the file leave their fields undefined and every other cell is parsed into
+// the field's type, as package bind does for form input.
+package csvnull
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type options struct {
+	null  string
+	comma rune
+}
+
+// Option configures reading and writing.
+type Option func(*options)
+
+// NullToken sets the cell content standing for null, empty by default.
+// PostgreSQL's COPY uses \N, other exports often NULL. With the default,
+// empty strings cannot be told apart from null.
+func NullToken(token string) Option {
+	return func(o *options) {
+		o.null = token
+	}
+}
+
+// Comma sets the field delimiter, ',' by default.
+func Comma(r rune) Option {
+	return func(o *options) {
+		o.comma = r
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{comma: ','}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// column links a CSV column to a struct field.
+type column struct {
+	name  string
+	field nullreflect.Field
+}
+
+var columnsCache sync.Map // map[reflect.Type][]column
+
+// columns returns the CSV columns of struct type t, in field order.
+func columns(t reflect.Type) []column {
+	if cached, ok := columnsCache.Load(t); ok {
+		return cached.([]column)
+	}
+	var cols []column
+	for _, f := range nullreflect.Fields(t) {
+		tag := f.Tag.Get("csv")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = nullreflect.SnakeCase(f.Name)
+		}
+		cols = append(cols, column{name: name, field: f})
+	}
+	cached, _ := columnsCache.LoadOrStore(t, cols)
+	return cached.([]column)
+}
+
+// Read decodes every record of r into a T, a struct. The first record is the header;
+// every header column must match a field. Errors mention the 1-based line number
+// of the offending record.
+func Read[T any](r io.Reader, opts ...Option) ([]T, error) {
+	o := newOptions(opts)
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("csvnull: %s is not a struct", t)
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = o.comma
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("csvnull: %w", err)
+	}
+
+	byName := make(map[string]nullreflect.Field)
+	for _, c := range columns(t) {
+		byName[c.name] = c.field
+	}
+	fields := make([]nullreflect.Field, len(header))
+	for i, name := range header {
+		f, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("csvnull: no field of %s matches column %q", t, name)
+		}
+		fields[i] = f
+	}
+
+	var out []T
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("csvnull: %w", err)
+		}
+		var value T
+		v := reflect.ValueOf(&value).Elem()
+		for i, cell := range record {
+			fv := v.FieldByIndex(fields[i].Index)
+			if cell == o.null {
+				err = nullreflect.Write(fv, nil, nullable.StateNull)
+			} else {
+				err = nullreflect.WriteString(fv, cell)
+			}
+			if err != nil {
+				line, _ := cr.FieldPos(i)
+				return nil, fmt.Errorf("csvnull: line %d, column %s: %w", line, header[i], err)
+			}
+		}
+		out = append(out, value)
+	}
+}
+
+// Write encodes rows to w, preceded by a header of every column of T.
+// Null and undefined fields are written as the null token.
+func Write[T any](w io.Writer, rows []T, opts ...Option) error {
+	o := newOptions(opts)
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("csvnull: %s is not a struct", t)
+	}
+	if !validDelim(o.comma) {
+		return errInvalidDelim
+	}
+	cols := columns(t)
+
+	bw := bufio.NewWriter(w)
+	var buf []byte
+	for i, c := range cols {
+		if i > 0 {
+			buf = utf8.AppendRune(buf, o.comma)
+		}
+		buf = appendField(buf, c.name, o.comma)
+	}
+	buf = append(buf, '\n')
+	if _, err := bw.Write(buf); err != nil {
+		return fmt.Errorf("csvnull: %w", err)
+	}
+	for n, row := range rows {
+		var err error
+		buf, err = appendRow(buf[:0], reflect.ValueOf(row), cols, o)
+		if err != nil {
+			return fmt.Errorf("csvnull: row %d, %w", n, err)
+		}
+		if _, err := bw.Write(buf); err != nil {
+			return fmt.Errorf("csvnull: %w", err)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("csvnull: %w", err)
+	}
+	return nil
+}
+
+// EncodeAppend appends dto, a struct or pointer to one, to dst as a single CSV record
+// terminated by a newline, with the columns and cells Write writes for it, and returns
+// the extended buffer. Exports can write the header once, with Write and no rows, and
+// append every row to the same buffer.
+func EncodeAppend(dst []byte, dto any, opts ...Option) ([]byte, error) {
+	o := newOptions(opts)
+	v := reflect.Indirect(reflect.ValueOf(dto))
+	if v.Kind() != reflect.Struct {
+		return dst, fmt.Errorf("csvnull: dto must be a struct, got %T", dto)
+	}
+	if !validDelim(o.comma) {
+		return dst, errInvalidDelim
+	}
+	out, err := appendRow(dst, v, columns(v.Type()), o)
+	if err != nil {
+		return dst, fmt.Errorf("csvnull: %w", err)
+	}
+	return out, nil
+}
+
+// appendRow appends the record of struct v to dst.
+func appendRow(dst []byte, v reflect.Value, cols []column, o options) ([]byte, error) {
+	for i, c := range cols {
+		s, state, err := nullreflect.ReadString(v.FieldByIndex(c.field.Index))
+		if err != nil {
+			return dst, fmt.Errorf("column %s: %w", c.name, err)
+		}
+		if state != nullable.StatePresent {
+			s = o.null
+		}
+		if i > 0 {
+			dst = utf8.AppendRune(dst, o.comma)
+		}
+		dst = appendField(dst, s, o.comma)
+	}
+	return append(dst, '\n'), nil
+}
+
+var errInvalidDelim = errors.New("csvnull: invalid field delimiter")
+
+// validDelim reports whether r can delimit fields, as encoding/csv requires.
+func validDelim(r rune) bool {
+	return r != 0 && r != '"' && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
+
+// appendField appends field to dst, quoted as encoding/csv quotes it.
+func appendField(dst []byte, field string, comma rune) []byte {
+	if !fieldNeedsQuotes(field, comma) {
+		return append(dst, field...)
+	}
+	dst = append(dst, '"')
+	for {
+		i := strings.IndexByte(field, '"')
+		if i < 0 {
+			break
+		}
+		dst = append(dst, field[:i+1]...)
+		dst = append(dst, '"')
+		field = field[i+1:]
+	}
+	dst = append(dst, field...)
+	return append(dst, '"')
+}
+
+// fieldNeedsQuotes follows csv.Writer: fields holding the delimiter, quotes or line
+// breaks, starting with a space, or equal to \. are quoted.
+func fieldNeedsQuotes(field string, comma rune) bool {
+	if field == "" {
+		return false
+	}
+	if field == `\.` || strings.ContainsRune(field, comma) || strings.ContainsAny(field, "\"\r\n") {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(field)
+	return unicode.IsSpace(r)
+}
@@ -0,0 +1,168 @@
+package csvnull
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v6"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type person struct {
+	ID       int64
+	Name     nullable.Optional[string]
+	Married  nullable.Optional[string] `csv:"married_name"`
+	Age      nullable.Null[int32]
+	Email    null.String
+	Birthday nullable.Date
+	Internal string `csv:"-"`
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  []Option
+		want  []person
+	}{
+		{name: "empty", input: "", want: nil},
+		{name: "header only", input: "id,name\n", want: nil},
+		{
+			name:  "states with the default token",
+			input: "id,name,married_name,age,email,birthday\n1,Tan,,30,tan@example.com,1990-05-17\n2,,Lee,,,\n",
+			want: []person{
+				{ID: 1, Name: nullable.OptionalFrom("Tan"), Married: nullable.OptionalNull[string](), Age: nullable.From[int32](30), Email: null.StringFrom("tan@example.com"), Birthday: nullable.NewDate(1990, time.May, 17)},
+				{ID: 2, Name: nullable.OptionalNull[string](), Married: nullable.OptionalFrom("Lee")},
+			},
+		},
+		{
+			name:  "missing columns leave fields undefined",
+			input: "married_name, id \nLee,3\n",
+			want:  []person{{ID: 3, Married: nullable.OptionalFrom("Lee")}},
+		},
+		{
+			name:  "null token and delimiter",
+			input: "id;name;email\n1;\\N;\n2;\"a;b\";\\N\n",
+			opts:  []Option{NullToken(`\N`), Comma(';')},
+			want: []person{
+				{ID: 1, Name: nullable.OptionalNull[string](), Email: null.StringFrom("")},
+				{ID: 2, Name: nullable.OptionalFrom("a;b")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read[person](strings.NewReader(tt.input), tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Read =\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "unknown column", input: "id,nope\n", wantErr: `no field of csvnull.person matches column "nope"`},
+		{name: "skipped column", input: "internal\n", wantErr: `matches column "internal"`},
+		{name: "bad cell", input: "id,age\n1,2\n2,old\n", wantErr: "line 3, column age"},
+		{name: "wrong field count", input: "id,age\n1\n", wantErr: "wrong number of fields"},
+		{name: "bad quote", input: "id\n\"1\n", wantErr: "csvnull: "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read[person](strings.NewReader(tt.input))
+			if err == nil || !strings.HasPrefix(err.Error(), "csvnull: ") || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Read = %v, want an error mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+	if _, err := Read[int](strings.NewReader("a\n")); err == nil {
+		t.Error("Read[int]: want an error")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	rows := []person{
+		{ID: 1, Name: nullable.OptionalFrom("Tan, Ah Kow"), Married: nullable.OptionalNull[string](), Age: nullable.From[int32](30), Birthday: nullable.NewDate(1990, time.May, 17)},
+		{ID: 2, Name: nullable.OptionalFrom(`say "hi"`), Email: null.StringFrom(" lead space"), Internal: "x"},
+	}
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			name: "default token",
+			want: "id,name,married_name,age,email,birthday\n" +
+				"1,\"Tan, Ah Kow\",,30,,1990-05-17\n" +
+				"2,\"say \"\"hi\"\"\",,,\" lead space\",\n",
+		},
+		{
+			name: "null token and delimiter",
+			opts: []Option{NullToken("NULL"), Comma('\t')},
+			want: "id\tname\tmarried_name\tage\temail\tbirthday\n" +
+				"1\tTan, Ah Kow\tNULL\t30\tNULL\t1990-05-17\n" +
+				"2\t\"say \"\"hi\"\"\"\tNULL\tNULL\t\" lead space\"\tNULL\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, rows, tt.opts...); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Write =\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	rows := []person{
+		{ID: 1, Name: nullable.OptionalFrom(""), Married: nullable.OptionalNull[string](), Email: null.StringFrom("a\nb")},
+		{ID: 2, Name: nullable.OptionalFrom(`\.`), Age: nullable.From[int32](-1), Birthday: nullable.NewDate(2024, time.February, 29)},
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, rows, NullToken(`\N`)); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Read[person](&buf, NullToken(`\N`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every column is written, so undefined fields come back as null.
+	want := rows
+	want[1].Married = nullable.OptionalNull[string]()
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip =\n%#v\nwant\n%#v", got, want)
+	}
+}
+
+func TestWriteErrors(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, []int{1}); err == nil {
+		t.Error("Write of ints: want an error")
+	}
+	if err := Write(&bytes.Buffer{}, []person{}, Comma('"')); !errors.Is(err, errInvalidDelim) {
+		t.Errorf("Write with a quote delimiter = %v, want errInvalidDelim", err)
+	}
+	if err := Write(errWriter{}, []person{{}}); err == nil || !strings.HasPrefix(err.Error(), "csvnull: ") {
+		t.Errorf("Write to a failing writer = %v, want a csvnull error", err)
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
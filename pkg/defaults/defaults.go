@@ -0,0 +1,49 @@
+// Package defaults fills missing answers of nullable DTOs from struct tags,
+// so new form fields can roll out with a fallback before every client sends them:
+//
+//	type Preferences struct {
+//		Language nullable.Optional[string] `default:"en"`
+//		Pages    nullable.Null[int]        `default:"20"`
+//		Notify   *bool                     `default:"true"`
+//	}
+package defaults
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// Apply sets every undefined or null field of dto, a non-nil pointer to a struct,
+// to the value of its `default` tag. Tags are parsed like form input: numbers,
+// booleans and strings by their inner type, times with nullable.TimeLayouts, and
+// types that parse text themselves, such as nullable.Enum, with their own rules.
+// Fields that always hold a value, such as plain strings or ints, are left alone.
+func Apply(dto any) error {
+	v := reflect.ValueOf(dto)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("defaults: dto must be a non-nil pointer to a struct, got %T", dto)
+	}
+	v = v.Elem()
+
+	for _, f := range nullreflect.Fields(v.Type()) {
+		def, ok := f.Tag.Lookup("default")
+		if !ok {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		_, state, err := nullreflect.Read(fv)
+		if err != nil {
+			return fmt.Errorf("defaults: field %s: %w", f.Name, err)
+		}
+		if state == nullstate.Present {
+			continue
+		}
+		if err := nullreflect.WriteString(fv, def); err != nil {
+			return fmt.Errorf("defaults: field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
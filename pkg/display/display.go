@@ -0,0 +1,138 @@
+// Package display renders nullable values and field errors for people, in their language,
+// so review screens say "Not provided" or "未提供" instead of leaving cells empty:
+//
+//	<td>{{ display .MarriedName "zh-SG" }}</td>
+//
+// Messages come from a github.com/nicksnyder/go-i18n catalog. Bundle ships with English
+// and Chinese messages; add languages or override messages with Bundle.AddMessages or
+// Bundle.LoadMessageFile during initialization. Message IDs are display.null and
+// display.undefined for values, and validation.<tag>, such as validation.required,
+// for field errors, whose templates receive the tag parameter as {{.Param}}.
+//
+// The lang arguments accept language tags and Accept-Language header values alike,
+// falling back to English for languages the catalog lacks.
+package display
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"html/template"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/tmplnull"
+)
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// Bundle is the message catalog used by this package.
+var Bundle = newBundle()
+
+func newBundle() *i18n.Bundle {
+	b := i18n.NewBundle(language.English)
+	files, _ := locales.ReadDir("locales")
+	for _, f := range files {
+		data, err := locales.ReadFile("locales/" + f.Name())
+		if err != nil {
+			panic(err)
+		}
+		if _, err := b.ParseMessageFileBytes(data, f.Name()); err != nil {
+			panic(fmt.Sprintf("display: %s: %v", f.Name(), err))
+		}
+	}
+	return b
+}
+
+// String returns the value of n formatted with %v, or the display.null message if n is null.
+func String[T comparable](n nullable.Null[T], lang string) string {
+	if !n.Valid {
+		return state(lang, "null")
+	}
+	return fmt.Sprint(n.V)
+}
+
+// Optional is like String, rendering undefined values with the display.undefined message.
+func Optional[T comparable](o nullable.Optional[T], lang string) string {
+	if !o.IsDefined() {
+		return state(lang, "undefined")
+	}
+	return String(o.Null(), lang)
+}
+
+// Value is like String for any value tmplnull understands, such as guregu null types,
+// pgtype types and pointers. Present values are formatted like tmplnull.Value unwraps them.
+func Value(x any, lang string) string {
+	switch {
+	case tmplnull.IsSet(x):
+		return fmt.Sprint(tmplnull.Value(x))
+	case isUndefined(x):
+		return state(lang, "undefined")
+	}
+	return state(lang, "null")
+}
+
+func isUndefined(x any) bool {
+	d, ok := x.(interface{ IsDefined() bool })
+	return ok && !d.IsDefined()
+}
+
+// FuncMap returns a display template function rendering values with Value,
+// written as {{ display .Name $.Lang }}.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{"display": Value}
+}
+
+// Errors translates the field errors wrapped by err into lang, keyed like
+// nullvalidate.FieldErrors keys them. validator.ValidationErrors are rendered from their
+// tag and parameter, and the messages of forms.FieldErrors, such as the tags reported by
+// nullvalidate and pgerr, are treated as tags. Messages without a translation, such as
+// conversion errors, are kept as they are. It returns nil if err holds no field errors.
+func Errors(err error, lang string) forms.FieldErrors {
+	var ves validator.ValidationErrors
+	if errors.As(err, &ves) {
+		var fe forms.FieldErrors
+		for _, e := range ves {
+			fe.Add(e.Field(), message(lang, e.Tag(), e.Param()))
+		}
+		return fe
+	}
+	var src forms.FieldErrors
+	if !errors.As(err, &src) {
+		return nil
+	}
+	fe := make(forms.FieldErrors, len(src))
+	for field, msgs := range src {
+		for _, msg := range msgs {
+			fe.Add(field, message(lang, msg, ""))
+		}
+	}
+	return fe
+}
+
+// message returns the validation.<tag> message in lang, or tag if there is none.
+func message(lang, tag, param string) string {
+	msg, ok := localize(lang, "validation."+tag, map[string]string{"Param": param})
+	if !ok {
+		return tag
+	}
+	return msg
+}
+
+// localize returns message id in lang, and false if the catalog lacks it.
+func localize(lang, id string, data any) (string, bool) {
+	loc := i18n.NewLocalizer(Bundle, lang)
+	msg, err := loc.Localize(&i18n.LocalizeConfig{MessageID: id, TemplateData: data})
+	return msg, err == nil
+}
+
+// state returns the display.<state> message in lang, for null and undefined values.
+func state(lang, name string) string {
+	msg, _ := localize(lang, "display."+name, nil)
+	return msg
+}
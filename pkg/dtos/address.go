@@ -0,0 +1,17 @@
+package dtos
+
+import "github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+
+// Correction forms repeat sections, previous addresses are matched
+// across submissions by their ID
+type AddressForm struct {
+	ID         nullable.Optional[int64] `forms:"key"`
+	Street     nullable.Optional[string]
+	PostalCode nullable.Optional[string]
+}
+
+type AddressCorrectionForm struct {
+	Uinfin            nullable.Uinfin `pii:"mask"`
+	Current           AddressForm
+	PreviousAddresses []AddressForm
+}
@@ -0,0 +1,93 @@
+// Code generated by nullgen. DO NOT EDIT.
+
+package fixtures
+
+import (
+	"github.com/guregu/null/v6"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/dtos"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Option customizes a DTO built by a factory of this package.
+type Option[T any] func(*T)
+
+// UinfinNamesForm returns a dtos.UinfinNamesForm with every field set to a deterministic value,
+// then applies overrides in order.
+func UinfinNamesForm(overrides ...Option[dtos.UinfinNamesForm]) dtos.UinfinNamesForm {
+	f := dtos.UinfinNamesForm{
+		Uinfin:            nullable.UinfinFrom("S1234567D"),
+		Name:              null.StringFrom("Name"),
+		Aliasnme:          null.StringFrom("Aliasnme"),
+		HanyupinName:      null.StringFrom("HanyupinName"),
+		HanyupinAliasname: null.StringFrom("HanyupinAliasname"),
+		MarriedName:       null.StringFrom("MarriedName"),
+	}
+	for _, o := range overrides {
+		o(&f)
+	}
+	return f
+}
+
+// SparseUinfinNamesForm returns a dtos.UinfinNamesForm with its nullable fields null or undefined
+// and its other fields set like UinfinNamesForm does, then applies overrides in order.
+func SparseUinfinNamesForm(overrides ...Option[dtos.UinfinNamesForm]) dtos.UinfinNamesForm {
+	f := dtos.UinfinNamesForm{}
+	for _, o := range overrides {
+		o(&f)
+	}
+	return f
+}
+
+// NullableUinfinNamesForm returns a dtos.NullableUinfinNamesForm with every field set to a deterministic value,
+// then applies overrides in order.
+func NullableUinfinNamesForm(overrides ...Option[dtos.NullableUinfinNamesForm]) dtos.NullableUinfinNamesForm {
+	f := dtos.NullableUinfinNamesForm{
+		Uinfin:            nullable.UinfinFrom("S1234567D"),
+		Name:              nullable.From("Name"),
+		Aliasnme:          nullable.From("Aliasnme"),
+		HanyupinName:      nullable.From("HanyupinName"),
+		HanyupinAliasname: nullable.From("HanyupinAliasname"),
+		MarriedName:       nullable.From("MarriedName"),
+	}
+	for _, o := range overrides {
+		o(&f)
+	}
+	return f
+}
+
+// SparseNullableUinfinNamesForm returns a dtos.NullableUinfinNamesForm with its nullable fields null or undefined
+// and its other fields set like NullableUinfinNamesForm does, then applies overrides in order.
+func SparseNullableUinfinNamesForm(overrides ...Option[dtos.NullableUinfinNamesForm]) dtos.NullableUinfinNamesForm {
+	f := dtos.NullableUinfinNamesForm{}
+	for _, o := range overrides {
+		o(&f)
+	}
+	return f
+}
+
+// UinfinNamesPatch returns a dtos.UinfinNamesPatch with every field set to a deterministic value,
+// then applies overrides in order.
+func UinfinNamesPatch(overrides ...Option[dtos.UinfinNamesPatch]) dtos.UinfinNamesPatch {
+	f := dtos.UinfinNamesPatch{
+		Uinfin:            nullable.OptionalFrom("Uinfin"),
+		Name:              nullable.OptionalFrom("Name"),
+		Aliasnme:          nullable.OptionalFrom("Aliasnme"),
+		HanyupinName:      nullable.OptionalFrom("HanyupinName"),
+		HanyupinAliasname: nullable.OptionalFrom("HanyupinAliasname"),
+		MarriedName:       nullable.OptionalFrom("MarriedName"),
+	}
+	for _, o := range overrides {
+		o(&f)
+	}
+	return f
+}
+
+// SparseUinfinNamesPatch returns a dtos.UinfinNamesPatch with its nullable fields null or undefined
+// and its other fields set like UinfinNamesPatch does, then applies overrides in order.
+func SparseUinfinNamesPatch(overrides ...Option[dtos.UinfinNamesPatch]) dtos.UinfinNamesPatch {
+	f := dtos.UinfinNamesPatch{}
+	for _, o := range overrides {
+		o(&f)
+	}
+	return f
+}
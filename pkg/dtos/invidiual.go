@@ -1,33 +1,55 @@
 package dtos
 
+//go:generate go run github.com/nadhifikbarw/x-go-painless-null/cmd/nullgen -type UinfinNamesForm -clone
+//go:generate go run github.com/nadhifikbarw/x-go-painless-null/cmd/nullgen factories -type UinfinNamesForm,NullableUinfinNamesForm,UinfinNamesPatch
+
 import (
 	"github.com/guregu/null/v6"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
 )
 
 // Imagine you have Web UI stepped form
 // allowing user to correct their uinfin and names
 type UinfinNamesForm struct {
-	Uinfin            string
+	Uinfin            nullable.Uinfin `pii:"mask"`
 	Name              null.String
-	Aliasnme          null.String
-	HanyupinName      null.String
-	HanyupinAliasname null.String
+	Aliasnme          null.String `db:"aliasname"`
+	HanyupinName      null.String `db:"hanyupinyin_name"`
+	HanyupinAliasname null.String `db:"hanyupinyin_aliasname"`
 	MarriedName       null.String
 }
 
-// Using pgtype
+// Using generic nullable
+
+type NullableUinfinNamesForm struct {
+	Uinfin            nullable.Uinfin `pii:"mask"`
+	Name              nullable.Null[string]
+	Aliasnme          nullable.Null[string] `db:"aliasname"`
+	HanyupinName      nullable.Null[string] `db:"hanyupinyin_name"`
+	HanyupinAliasname nullable.Null[string] `db:"hanyupinyin_aliasname"`
+	MarriedName       nullable.Null[string]
+}
 
-type PgUinfinNamesForm struct {
-	Uinfin            string
-	Name              pgtype.Text
-	Aliasnme          pgtype.Text
-	HanyupinName      pgtype.Text
-	HanyupinAliasname pgtype.Text
-	MarriedName       pgtype.Text
+// Submitting a step only sends fields the user touched,
+// Optional tells apart absent fields from explicitly cleared ones
+type UinfinNamesPatch struct {
+	Uinfin            nullable.Optional[string] `pii:"mask"`
+	Name              nullable.Optional[string]
+	Aliasnme          nullable.Optional[string] `db:"aliasname"`
+	HanyupinName      nullable.Optional[string] `db:"hanyupinyin_name"`
+	HanyupinAliasname nullable.Optional[string] `db:"hanyupinyin_aliasname"`
+	MarriedName       nullable.Optional[string]
 }
 
+// Using pgtype, PgUinfinNamesForm is generated by nullgen
+
 // Very leaky
 type PgAgeForm struct {
 	Age pgtype.Int4
 }
+
+// Marshals as {"Age":null} or {"Age":42}, converts with Age.Pg() and nullable.Int32FromPg
+type AgeForm struct {
+	Age nullable.Int32 `validate:"omitnull,min=0,max=150"`
+}
@@ -0,0 +1,47 @@
+// Code generated by nullgen. DO NOT EDIT.
+
+package dtos
+
+import (
+	"github.com/guregu/null/v6"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// PgUinfinNamesForm mirrors UinfinNamesForm using pgtype types.
+type PgUinfinNamesForm struct {
+	Uinfin            nullable.Uinfin `pii:"mask"`
+	Name              pgtype.Text
+	Aliasnme          pgtype.Text `db:"aliasname"`
+	HanyupinName      pgtype.Text `db:"hanyupinyin_name"`
+	HanyupinAliasname pgtype.Text `db:"hanyupinyin_aliasname"`
+	MarriedName       pgtype.Text
+}
+
+// ToPg converts f into PgUinfinNamesForm.
+func (f UinfinNamesForm) ToPg() PgUinfinNamesForm {
+	return PgUinfinNamesForm{
+		Uinfin:            f.Uinfin,
+		Name:              pgtype.Text{String: f.Name.String, Valid: f.Name.Valid},
+		Aliasnme:          pgtype.Text{String: f.Aliasnme.String, Valid: f.Aliasnme.Valid},
+		HanyupinName:      pgtype.Text{String: f.HanyupinName.String, Valid: f.HanyupinName.Valid},
+		HanyupinAliasname: pgtype.Text{String: f.HanyupinAliasname.String, Valid: f.HanyupinAliasname.Valid},
+		MarriedName:       pgtype.Text{String: f.MarriedName.String, Valid: f.MarriedName.Valid},
+	}
+}
+
+// FromPg populates f from PgUinfinNamesForm.
+func (f *UinfinNamesForm) FromPg(pg PgUinfinNamesForm) {
+	f.Uinfin = pg.Uinfin
+	f.Name = null.NewString(pg.Name.String, pg.Name.Valid)
+	f.Aliasnme = null.NewString(pg.Aliasnme.String, pg.Aliasnme.Valid)
+	f.HanyupinName = null.NewString(pg.HanyupinName.String, pg.HanyupinName.Valid)
+	f.HanyupinAliasname = null.NewString(pg.HanyupinAliasname.String, pg.HanyupinAliasname.Valid)
+	f.MarriedName = null.NewString(pg.MarriedName.String, pg.MarriedName.Valid)
+}
+
+// Clone returns a deep copy of f.
+func (f UinfinNamesForm) Clone() UinfinNamesForm {
+	c := f
+	return c
+}
@@ -0,0 +1,69 @@
+// Package envnull loads configuration from environment variables into nullable DTOs
+// with tri-state semantics: unset variables are undefined, empty ones are null and
+// everything else is parsed into the field's type.
+//
+//	type Config struct {
+//		DatabaseURL nullable.Optional[string]
+//		MaxConns    nullable.Optional[int32] `env:"POOL_MAX_CONNS"`
+//	}
+//	err := envnull.Load("APP", &cfg) // reads APP_DATABASE_URL and APP_POOL_MAX_CONNS
+package envnull
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Load fills dst, a non-nil pointer to a struct, from the environment. Variables are
+// named after the `env` tag or the upper snake_cased field name, joined to prefix
+// with an underscore unless prefix is empty; `env:"-"` skips a field.
+// Fields that cannot be undefined receive their zero value for unset variables.
+func Load(prefix string, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("envnull: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	v = v.Elem()
+
+	for _, f := range nullreflect.Fields(v.Type()) {
+		name, ok := varName(prefix, f)
+		if !ok {
+			continue
+		}
+		if err := load(v.FieldByIndex(f.Index), name); err != nil {
+			return fmt.Errorf("envnull: %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func load(fv reflect.Value, name string) error {
+	s, ok := os.LookupEnv(name)
+	switch {
+	case !ok:
+		return nullreflect.Write(fv, nil, nullable.StateUndefined)
+	case s == "":
+		return nullreflect.Write(fv, nil, nullable.StateNull)
+	}
+	return nullreflect.WriteString(fv, s)
+}
+
+func varName(prefix string, f nullreflect.Field) (string, bool) {
+	tag := f.Tag.Get("env")
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = strings.ToUpper(nullreflect.SnakeCase(f.Name))
+	}
+	if prefix == "" || strings.HasSuffix(prefix, "_") {
+		return prefix + name, true
+	}
+	return prefix + "_" + name, true
+}
@@ -0,0 +1,77 @@
+package envnull
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type config struct {
+	DatabaseURL nullable.Optional[string]
+	MaxConns    nullable.Optional[int32] `env:"POOL_MAX_CONNS"`
+	Timeout     nullable.Optional[float64]
+	Debug       nullable.Null[bool]
+	Region      string
+	Secret      nullable.Optional[string] `env:"-"`
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+		env    map[string]string
+		dst    config
+		want   config
+	}{
+		{
+			name:   "unset is undefined, empty is null",
+			prefix: "APP",
+			env:    map[string]string{"APP_DATABASE_URL": "postgres://db", "APP_POOL_MAX_CONNS": "", "APP_DEBUG": "true"},
+			dst:    config{Timeout: nullable.OptionalFrom(1.5), Region: "stale"},
+			want: config{
+				DatabaseURL: nullable.OptionalFrom("postgres://db"),
+				MaxConns:    nullable.OptionalNull[int32](),
+				Debug:       nullable.From(true),
+			},
+		},
+		{
+			name:   "prefix ending in an underscore",
+			prefix: "APP_",
+			env:    map[string]string{"APP_TIMEOUT": "2.5", "APP_REGION": "sg"},
+			want:   config{Timeout: nullable.OptionalFrom(2.5), Region: "sg"},
+		},
+		{
+			name: "no prefix and skipped fields",
+			env:  map[string]string{"DATABASE_URL": "x", "SECRET": "s", "DEBUG": ""},
+			dst:  config{Secret: nullable.OptionalFrom("kept")},
+			want: config{DatabaseURL: nullable.OptionalFrom("x"), Secret: nullable.OptionalFrom("kept")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			got := tt.dst
+			if err := Load(tt.prefix, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Load = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	t.Setenv("APP_POOL_MAX_CONNS", "many")
+	var cfg config
+	if err := Load("APP", &cfg); err == nil || !strings.HasPrefix(err.Error(), "envnull: APP_POOL_MAX_CONNS: ") {
+		t.Errorf("Load = %v, want an error naming the variable", err)
+	}
+	if err := Load("APP", cfg); err == nil {
+		t.Error("Load into a struct value: want an error")
+	}
+}
@@ -0,0 +1,109 @@
+// Package flagnull binds command line flags to nullable values with tri-state
+// semantics: flags that are not passed stay undefined, flags passed empty, as in
+// -name=, are null and everything else is parsed into the value's type.
+//
+//	var patch dtos.UinfinNamesPatch
+//	fs := flag.NewFlagSet("patch", flag.ExitOnError)
+//	flagnull.Bind(fs, &patch) // -uinfin, -name, -aliasnme, ...
+//	fs.Parse(os.Args[1:])
+//	sql, args := sqlbuild.UpdateSet("individuals", patch)
+package flagnull
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Var defines a flag with the given name and usage on fs, storing its value in p.
+// p is left as is unless the flag is passed.
+func Var[T comparable](fs *flag.FlagSet, p *nullable.Optional[T], name, usage string) {
+	fs.Var(Value(p), name, usage)
+}
+
+// Value returns a flag.Value storing into p, which must be a non-nil pointer to a
+// nullable value, pointer or plain value. Empty input stores null. Boolean values
+// can be passed without input, as in -flag, to store true.
+func Value(p any) flag.Value {
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		panic(fmt.Sprintf("flagnull: value must be a non-nil pointer, got %T", p))
+	}
+	return fieldValue{v.Elem()}
+}
+
+// Bind defines a flag for every exported field of dst, a non-nil pointer to a struct.
+// Flags are named after the `flag` tag or the kebab-cased field name; `flag:"-"`
+// skips a field. The `usage` tag provides the usage message.
+func Bind(fs *flag.FlagSet, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("flagnull: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	v = v.Elem()
+	for _, f := range nullreflect.Fields(v.Type()) {
+		name, ok := flagName(f)
+		if !ok {
+			continue
+		}
+		fs.Var(fieldValue{v.FieldByIndex(f.Index)}, name, f.Tag.Get("usage"))
+	}
+	return nil
+}
+
+func flagName(f nullreflect.Field) (string, bool) {
+	tag := f.Tag.Get("flag")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return strings.ReplaceAll(nullreflect.SnakeCase(f.Name), "_", "-"), true
+}
+
+// fieldValue implements flag.Value on top of a settable value.
+type fieldValue struct {
+	v reflect.Value
+}
+
+func (f fieldValue) Set(s string) error {
+	if s == "" {
+		return nullreflect.Write(f.v, nil, nullable.StateNull)
+	}
+	return nullreflect.WriteString(f.v, s)
+}
+
+// String returns the current value, empty for null, undefined or the zero fieldValue
+// the flag package creates to detect default values.
+func (f fieldValue) String() string {
+	if !f.v.IsValid() {
+		return ""
+	}
+	val, state, err := nullreflect.Read(f.v)
+	if err != nil || state != nullable.StatePresent {
+		return ""
+	}
+	if b, ok := val.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(val)
+}
+
+// IsBoolFlag lets boolean flags be passed without input.
+func (f fieldValue) IsBoolFlag() bool {
+	if !f.v.IsValid() {
+		return false
+	}
+	t := f.v.Type()
+	if inner, ok := nullreflect.Inner(t); ok {
+		t = inner
+	} else if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Bool
+}
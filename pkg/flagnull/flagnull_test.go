@@ -0,0 +1,153 @@
+package flagnull
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/guregu/null/v6"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type patch struct {
+	Name        nullable.Optional[string] `usage:"full name"`
+	MarriedName nullable.Optional[string]
+	Age         nullable.Optional[int32] `flag:"years"`
+	Active      nullable.Optional[bool]
+	Email       null.String
+	Count       int
+	Nickname    *string
+	Internal    string `flag:"-"`
+}
+
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("patch", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func TestBind(t *testing.T) {
+	nick := "ah boy"
+	tests := []struct {
+		name string
+		args []string
+		want patch
+	}{
+		{name: "nothing passed stays undefined", args: nil, want: patch{}},
+		{
+			name: "values, empty and bare booleans",
+			args: []string{"-name", "Tan", "-married-name=", "-years=30", "-active", "-email=", "-count", "3", "-nickname", "ah boy"},
+			want: patch{
+				Name:        nullable.OptionalFrom("Tan"),
+				MarriedName: nullable.OptionalNull[string](),
+				Age:         nullable.OptionalFrom[int32](30),
+				Active:      nullable.OptionalFrom(true),
+				Count:       3,
+				Nickname:    &nick,
+			},
+		},
+		{
+			name: "explicit false and null booleans",
+			args: []string{"-active=false", "-email", "tan@example.com"},
+			want: patch{Active: nullable.OptionalFrom(false), Email: null.StringFrom("tan@example.com")},
+		},
+		{
+			name: "empty boolean is null",
+			args: []string{"-active="},
+			want: patch{Active: nullable.OptionalNull[bool]()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got patch
+			fs := newFlagSet()
+			if err := Bind(fs, &got); err != nil {
+				t.Fatal(err)
+			}
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsed %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindDefinesFlags(t *testing.T) {
+	var p patch
+	fs := newFlagSet()
+	if err := Bind(fs, &p); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) { names = append(names, f.Name) })
+	if want := []string{"active", "count", "email", "married-name", "name", "nickname", "years"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("flags = %q, want %q", names, want)
+	}
+	if usage := fs.Lookup("name").Usage; usage != "full name" {
+		t.Errorf("usage = %q, want %q", usage, "full name")
+	}
+	if err := fs.Parse([]string{"-years", "old"}); err == nil || !strings.Contains(err.Error(), "years") {
+		t.Errorf("Parse = %v, want an error for -years", err)
+	}
+	if err := Bind(fs, p); err == nil {
+		t.Error("Bind to a struct value: want an error")
+	}
+}
+
+func TestVar(t *testing.T) {
+	fs := newFlagSet()
+	name := nullable.OptionalFrom("default")
+	Var(fs, &name, "name", "full name")
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+	if name != nullable.OptionalFrom("default") {
+		t.Errorf("name = %#v, want it left as is", name)
+	}
+	if err := fs.Parse([]string{"-name="}); err != nil {
+		t.Fatal(err)
+	}
+	if name != nullable.OptionalNull[string]() {
+		t.Errorf("name = %#v, want null", name)
+	}
+}
+
+func TestValueString(t *testing.T) {
+	b := []byte("raw")
+	tests := []struct {
+		name string
+		p    any
+		want string
+	}{
+		{name: "value", p: ptrTo(nullable.OptionalFrom[int32](3)), want: "3"},
+		{name: "null", p: new(nullable.Null[string]), want: ""},
+		{name: "bytes", p: &b, want: "raw"},
+		{name: "plain", p: new(int), want: "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Value(tt.p).String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if (fieldValue{}).String() != "" || (fieldValue{}).IsBoolFlag() {
+		t.Error("the zero fieldValue must print empty and not be a bool flag")
+	}
+}
+
+func TestValuePanicsOnNonPointer(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.HasPrefix(r.(string), "flagnull: ") {
+			t.Errorf("recover() = %v, want a flagnull panic", r)
+		}
+	}()
+	Value(1)
+}
+
+func ptrTo[T any](v T) *T { return &v }
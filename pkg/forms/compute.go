@@ -0,0 +1,604 @@
+package forms
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Compute sets the derived fields of dto, a non-nil pointer to a struct, from rules
+// mapping field names to expressions over the other fields, so derived fields are
+// declared once instead of duplicated in Go and SQL:
+//
+//	err := forms.Compute(&form, map[string]string{
+//		"DisplayName": "coalesce(MarriedName, Name)",
+//		"Initials":    "upper(substr(Name, 1, 1))",
+//	})
+//
+// Expressions are made of field names, literals ('text' with quotes doubled inside,
+// numbers, true, false and null), the operators || for text concatenation and + - * /
+// for numbers, parentheses and the functions below. They follow SQL null semantics:
+// operators and functions return null if any argument is null, except coalesce, and
+// undefined if any argument is undefined, so a derived field of a partial update stays
+// undefined unless its inputs were sent.
+//
+//   - coalesce(x, ...) returns its first argument holding a value, null if all are null,
+//     or undefined if none holds a value and any is undefined
+//   - nullif(x, y) returns null if x equals y, and x otherwise, including when y is null
+//   - upper(s), lower(s) and trim(s) change the case of s or trim its surrounding spaces
+//   - length(s) returns the number of characters of s
+//   - substr(s, start[, count]) returns the characters of s from start, counting from 1
+//
+// Rules may refer to fields computed by other rules, which are evaluated first, and
+// fail if they refer to each other in a cycle. A rule referring to its own field reads
+// the value it holds before, as in trim(Name). Results are written like Merge writes
+// fields, so a null result clears its field, and an undefined one leaves fields that
+// cannot be undefined, such as nullable.Null, as they are. Parsed expressions are cached.
+func Compute(dto any, rules map[string]string) error {
+	v := reflect.ValueOf(dto)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("forms: dto must be a non-nil pointer to a struct, got %T", dto)
+	}
+	v = v.Elem()
+	fields := make(map[string]nullreflect.Field)
+	for _, f := range nullreflect.Fields(v.Type()) {
+		fields[f.Name] = f
+	}
+
+	exprs := make(map[string]expr, len(rules))
+	for name, src := range rules {
+		if _, ok := fields[name]; !ok {
+			return fmt.Errorf("forms: rule %s: no such field in %s", name, v.Type())
+		}
+		e, err := parseExpr(src)
+		if err != nil {
+			return fmt.Errorf("forms: rule %s: %w", name, err)
+		}
+		for _, ref := range e.refs(nil) {
+			if _, ok := fields[ref]; !ok {
+				return fmt.Errorf("forms: rule %s: no such field %s in %s", name, ref, v.Type())
+			}
+		}
+		exprs[name] = e
+	}
+
+	order, err := ruleOrder(exprs)
+	if err != nil {
+		return err
+	}
+	for _, name := range order {
+		val, state, err := exprs[name].eval(v, fields)
+		if err != nil {
+			return fmt.Errorf("forms: rule %s: %w", name, err)
+		}
+		f := fields[name]
+		if state == nullable.StateUndefined && !nullreflect.CanBeUndefined(f.Type) {
+			continue
+		}
+		if err := nullreflect.Write(v.FieldByIndex(f.Index), val, state); err != nil {
+			return fmt.Errorf("forms: field %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ruleOrder returns the rule names so that each rule comes after the rules computing
+// the fields it refers to.
+func ruleOrder(exprs map[string]expr) ([]string, error) {
+	names := make([]string, 0, len(exprs))
+	for name := range exprs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	marks := make(map[string]int, len(exprs))
+	order := make([]string, 0, len(exprs))
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch marks[name] {
+		case visiting:
+			return fmt.Errorf("forms: rules %s refer to each other", strings.Join(append(path, name), " -> "))
+		case done:
+			return nil
+		}
+		marks[name] = visiting
+		for _, ref := range exprs[name].refs(nil) {
+			if _, ok := exprs[ref]; ok && ref != name {
+				if err := visit(ref, append(path, name)); err != nil {
+					return err
+				}
+			}
+		}
+		marks[name] = done
+		order = append(order, name)
+		return nil
+	}
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// expr is a parsed expression evaluating to a driver value and its state.
+type expr interface {
+	eval(dto reflect.Value, fields map[string]nullreflect.Field) (driver.Value, nullable.State, error)
+	// refs appends the names of the fields the expression refers to.
+	refs(names []string) []string
+}
+
+type literal struct {
+	val driver.Value
+}
+
+func (l literal) eval(reflect.Value, map[string]nullreflect.Field) (driver.Value, nullable.State, error) {
+	if l.val == nil {
+		return nil, nullable.StateNull, nil
+	}
+	return l.val, nullable.StatePresent, nil
+}
+
+func (l literal) refs(names []string) []string { return names }
+
+type fieldRef struct {
+	name string
+}
+
+func (r fieldRef) eval(dto reflect.Value, fields map[string]nullreflect.Field) (driver.Value, nullable.State, error) {
+	return nullreflect.Read(dto.FieldByIndex(fields[r.name].Index))
+}
+
+func (r fieldRef) refs(names []string) []string { return append(names, r.name) }
+
+// call applies an operator or function to its arguments.
+type call struct {
+	name string
+	args []expr
+}
+
+func (c call) refs(names []string) []string {
+	for _, a := range c.args {
+		names = a.refs(names)
+	}
+	return names
+}
+
+func (c call) eval(dto reflect.Value, fields map[string]nullreflect.Field) (driver.Value, nullable.State, error) {
+	vals := make([]driver.Value, len(c.args))
+	states := make([]nullable.State, len(c.args))
+	state := nullable.StatePresent
+	for i, a := range c.args {
+		val, s, err := a.eval(dto, fields)
+		if err != nil {
+			return nil, 0, err
+		}
+		if c.name == "coalesce" && s == nullable.StatePresent {
+			return val, s, nil
+		}
+		vals[i], states[i] = val, s
+		state = weaker(state, s)
+	}
+	switch {
+	case c.name == "coalesce":
+		return nil, state, nil
+	case c.name == "nullif" && states[0] == nullable.StatePresent && states[1] != nullable.StateUndefined:
+		if states[1] == nullable.StatePresent && equalValues(vals[0], vals[1]) {
+			return nil, nullable.StateNull, nil
+		}
+		return vals[0], nullable.StatePresent, nil
+	case state != nullable.StatePresent:
+		return nil, state, nil
+	}
+	val, err := apply(c.name, vals)
+	return val, nullable.StatePresent, err
+}
+
+// weaker returns undefined if either state is undefined, then null, then present.
+func weaker(a, b nullable.State) nullable.State {
+	if a == nullable.StateUndefined || b == nullable.StateUndefined {
+		return nullable.StateUndefined
+	}
+	if a == nullable.StateNull || b == nullable.StateNull {
+		return nullable.StateNull
+	}
+	return nullable.StatePresent
+}
+
+// arity holds the minimum and maximum number of arguments of each function,
+// -1 meaning any number.
+var arity = map[string][2]int{
+	"coalesce": {1, -1},
+	"nullif":   {2, 2},
+	"upper":    {1, 1},
+	"lower":    {1, 1},
+	"trim":     {1, 1},
+	"length":   {1, 1},
+	"substr":   {2, 3},
+}
+
+// apply applies function or operator name to vals, which all hold values.
+func apply(name string, vals []driver.Value) (driver.Value, error) {
+	switch name {
+	case "||":
+		a, aok := text(vals[0])
+		b, bok := text(vals[1])
+		if !aok || !bok {
+			return nil, fmt.Errorf("cannot concatenate %T and %T", vals[0], vals[1])
+		}
+		return a + b, nil
+	case "+", "-", "*", "/":
+		return arith(name, vals[0], vals[1])
+	case "neg":
+		return arith("-", int64(0), vals[0])
+	}
+	s, ok := text(vals[0])
+	if !ok {
+		return nil, fmt.Errorf("%s: expected text, got %T", name, vals[0])
+	}
+	switch name {
+	case "upper":
+		return strings.ToUpper(s), nil
+	case "lower":
+		return strings.ToLower(s), nil
+	case "trim":
+		return strings.TrimSpace(s), nil
+	case "length":
+		return int64(len([]rune(s))), nil
+	}
+	// substr
+	r := []rune(s)
+	start, ok := vals[1].(int64)
+	if !ok {
+		return nil, fmt.Errorf("substr: expected an integer start, got %T", vals[1])
+	}
+	end := int64(len(r)) + 1
+	if len(vals) == 3 {
+		count, ok := vals[2].(int64)
+		if !ok || count < 0 {
+			return nil, fmt.Errorf("substr: expected a non-negative integer count, got %v", vals[2])
+		}
+		end = start + count
+	}
+	start, end = max(start, 1), min(end, int64(len(r))+1)
+	if start >= end {
+		return "", nil
+	}
+	return string(r[start-1 : end-1]), nil
+}
+
+func text(v driver.Value) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	}
+	return "", false
+}
+
+func arith(op string, a, b driver.Value) (driver.Value, error) {
+	x, xok := a.(int64)
+	y, yok := b.(int64)
+	if xok && yok {
+		switch op {
+		case "+":
+			return x + y, nil
+		case "-":
+			return x - y, nil
+		case "*":
+			return x * y, nil
+		}
+		if y == 0 {
+			return nil, errors.New("division by zero")
+		}
+		return x / y, nil
+	}
+	f, fok := float(a)
+	g, gok := float(b)
+	if !fok || !gok {
+		return nil, fmt.Errorf("cannot apply %s to %T and %T", op, a, b)
+	}
+	switch op {
+	case "+":
+		return f + g, nil
+	case "-":
+		return f - g, nil
+	case "*":
+		return f * g, nil
+	}
+	if g == 0 {
+		return nil, errors.New("division by zero")
+	}
+	return f / g, nil
+}
+
+func float(v driver.Value) (float64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+var exprCache sync.Map // map[string]expr
+
+// parseExpr parses src, caching the result.
+func parseExpr(src string) (expr, error) {
+	if cached, ok := exprCache.Load(src); ok {
+		return cached.(expr), nil
+	}
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	e, err := p.concat()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %s at offset %d", t, t.pos)
+	}
+	cached, _ := exprCache.LoadOrStore(src, e)
+	return cached.(expr), nil
+}
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokOp
+)
+
+type token struct {
+	kind tokKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+func lex(src string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(src); {
+		c, size := utf8.DecodeRuneInString(src[i:])
+		switch {
+		case unicode.IsSpace(c):
+			i += size
+		case isIdentStart(c):
+			j := i + size
+			for j < len(src) {
+				r, n := utf8.DecodeRuneInString(src[j:])
+				if !isIdentStart(r) && !unicode.IsDigit(r) {
+					break
+				}
+				j += n
+			}
+			toks = append(toks, token{tokIdent, src[i:j], i})
+			i = j
+		case isDigit(c):
+			j := i
+			for j < len(src) && (isDigit(rune(src[j])) || src[j] == '.') {
+				j++
+			}
+			toks = append(toks, token{tokNumber, src[i:j], i})
+			i = j
+		case c == '\'':
+			var b strings.Builder
+			j := i + 1
+			for {
+				if j >= len(src) {
+					return nil, fmt.Errorf("unterminated text at offset %d", i)
+				}
+				if src[j] == '\'' {
+					if j+1 < len(src) && src[j+1] == '\'' {
+						b.WriteByte('\'')
+						j += 2
+						continue
+					}
+					break
+				}
+				b.WriteByte(src[j])
+				j++
+			}
+			toks = append(toks, token{tokString, b.String(), i})
+			i = j + 1
+		case strings.HasPrefix(src[i:], "||"):
+			toks = append(toks, token{tokOp, "||", i})
+			i += 2
+		case strings.ContainsRune("+-*/(),", c):
+			toks = append(toks, token{tokOp, string(c), i})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", c, i)
+		}
+	}
+	return append(toks, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+// isIdentStart reports whether r can start a field or function name, as it can start a
+// Go identifier.
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+// isDigit reports whether r is an ASCII digit, the only ones numbers are written with.
+func isDigit(r rune) bool {
+	return '0' <= r && r <= '9'
+}
+
+// parser is a recursive descent parser over the grammar, from lowest precedence:
+//
+//	concat  = sum { "||" sum }
+//	sum     = product { ("+" | "-") product }
+//	product = unary { ("*" | "/") unary }
+//	unary   = [ "-" ] primary
+//	primary = literal | field | function "(" [ concat { "," concat } ] ")" | "(" concat ")"
+type parser struct {
+	toks []token
+	pos  int
+}
+
+func (p *parser) peek() token { return p.toks[p.pos] }
+
+func (p *parser) next() token {
+	t := p.toks[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) accept(op string) bool {
+	if t := p.peek(); t.kind == tokOp && t.text == op {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if !p.accept(op) {
+		t := p.peek()
+		return fmt.Errorf("expected %q, got %s at offset %d", op, t, t.pos)
+	}
+	return nil
+}
+
+// binary parses operands with operand joined by any of ops, left to right.
+func (p *parser) binary(operand func() (expr, error), ops ...string) (expr, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op string
+		for _, o := range ops {
+			if p.accept(o) {
+				op = o
+				break
+			}
+		}
+		if op == "" {
+			return left, nil
+		}
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = call{name: op, args: []expr{left, right}}
+	}
+}
+
+func (p *parser) concat() (expr, error)  { return p.binary(p.sum, "||") }
+func (p *parser) sum() (expr, error)     { return p.binary(p.product, "+", "-") }
+func (p *parser) product() (expr, error) { return p.binary(p.unary, "*", "/") }
+
+func (p *parser) unary() (expr, error) {
+	if p.accept("-") {
+		e, err := p.primary()
+		if err != nil {
+			return nil, err
+		}
+		return call{name: "neg", args: []expr{e}}, nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		if n, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+			return literal{n}, nil
+		}
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s at offset %d", t, t.pos)
+		}
+		return literal{f}, nil
+	case tokString:
+		return literal{t.text}, nil
+	case tokIdent:
+		switch strings.ToLower(t.text) {
+		case "null":
+			return literal{nil}, nil
+		case "true":
+			return literal{true}, nil
+		case "false":
+			return literal{false}, nil
+		}
+		if !p.accept("(") {
+			return fieldRef{t.text}, nil
+		}
+		name := strings.ToLower(t.text)
+		n, ok := arity[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown function %s at offset %d", t.text, t.pos)
+		}
+		var args []expr
+		if !p.accept(")") {
+			for {
+				a, err := p.concat()
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, a)
+				if !p.accept(",") {
+					break
+				}
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+		}
+		if len(args) < n[0] || (n[1] >= 0 && len(args) > n[1]) {
+			return nil, fmt.Errorf("%s takes %s arguments, got %d", name, arityText(n), len(args))
+		}
+		return call{name: name, args: args}, nil
+	case tokOp:
+		if t.text == "(" {
+			e, err := p.concat()
+			if err != nil {
+				return nil, err
+			}
+			return e, p.expect(")")
+		}
+	}
+	return nil, fmt.Errorf("unexpected %s at offset %d", t, t.pos)
+}
+
+func arityText(n [2]int) string {
+	switch {
+	case n[1] < 0:
+		return fmt.Sprintf("at least %d", n[0])
+	case n[0] == n[1]:
+		return strconv.Itoa(n[0])
+	}
+	return fmt.Sprintf("%d to %d", n[0], n[1])
+}
@@ -0,0 +1,107 @@
+package forms
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name  string
+		form  testForm
+		rules map[string]string
+		want  testForm
+	}{
+		{
+			name:  "coalesce picks the first value",
+			form:  testForm{Name: some("Tan"), MarriedName: cleared},
+			rules: map[string]string{"DisplayName": "coalesce(MarriedName, Name)"},
+			want:  testForm{Name: some("Tan"), MarriedName: cleared, DisplayName: some("Tan")},
+		},
+		{
+			name:  "functions and concatenation",
+			form:  testForm{Name: some("  tan ah kow ")},
+			rules: map[string]string{"Initials": "upper(substr(trim(Name), 1, 1)) || '.'"},
+			want:  testForm{Name: some("  tan ah kow "), Initials: some("T.")},
+		},
+		{
+			name:  "null propagates",
+			form:  testForm{Name: cleared, DisplayName: some("old")},
+			rules: map[string]string{"DisplayName": "upper(Name)"},
+			want:  testForm{Name: cleared, DisplayName: cleared},
+		},
+		{
+			name:  "undefined propagates",
+			form:  testForm{DisplayName: some("old")},
+			rules: map[string]string{"DisplayName": "upper(Name)"},
+			want:  testForm{DisplayName: undefined},
+		},
+		{
+			name:  "undefined leaves fields that cannot be undefined",
+			form:  testForm{Age: nullable.From[int32](30)},
+			rules: map[string]string{"Age": "length(Name)"},
+			want:  testForm{Age: nullable.From[int32](30)},
+		},
+		{
+			name:  "arithmetic",
+			form:  testForm{Age: nullable.From[int32](30)},
+			rules: map[string]string{"Age": "(Age + 2) * 3 - 6 / 2"},
+			want:  testForm{Age: nullable.From[int32](93)},
+		},
+		{
+			name:  "nullif",
+			form:  testForm{Name: some("n/a")},
+			rules: map[string]string{"Name": "nullif(Name, 'n/a')"},
+			want:  testForm{Name: cleared},
+		},
+		{
+			name: "rules read computed fields",
+			form: testForm{Name: some("tan")},
+			rules: map[string]string{
+				"Initials":    "substr(DisplayName, 1, 1)",
+				"DisplayName": "upper(Name)",
+			},
+			want: testForm{Name: some("tan"), DisplayName: some("TAN"), Initials: some("T")},
+		},
+		{
+			name:  "non-ASCII text",
+			form:  testForm{Name: some("陈大文")},
+			rules: map[string]string{"Initials": "substr(Name, 1, 1) || 'é'", "Age": "length(Name)"},
+			want:  testForm{Name: some("陈大文"), Initials: some("陈é"), Age: nullable.From[int32](3)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.form
+			if err := Compute(&got, tt.rules); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Compute = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules map[string]string
+	}{
+		{name: "unknown field", rules: map[string]string{"Nope": "Name"}},
+		{name: "unknown reference", rules: map[string]string{"DisplayName": "Nope"}},
+		{name: "syntax", rules: map[string]string{"DisplayName": "upper(Name"}},
+		{name: "unknown function", rules: map[string]string{"DisplayName": "reverse(Name)"}},
+		{name: "cycle", rules: map[string]string{"DisplayName": "Initials", "Initials": "DisplayName"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := testForm{Name: some("Tan")}
+			if err := Compute(&f, tt.rules); err == nil {
+				t.Errorf("Compute(%q): want an error", tt.rules)
+			}
+		})
+	}
+}
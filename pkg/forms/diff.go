@@ -0,0 +1,205 @@
+package forms
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Change holds the old and new value of a changed field as driver values,
+// nil meaning null.
+type Change struct {
+	Old, New driver.Value
+}
+
+// Set reports whether the field went from null to a value.
+func (c Change) Set() bool {
+	return c.Old == nil && c.New != nil
+}
+
+// Cleared reports whether the field went from a value to null.
+func (c Change) Cleared() bool {
+	return c.Old != nil && c.New == nil
+}
+
+// Changes maps field names, or paths for fields of nested DTOs, to their change.
+type Changes map[string]Change
+
+// Fields returns the changed field names in sorted order.
+func (c Changes) Fields() []string {
+	names := make([]string, 0, len(c))
+	for name := range c {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Diff compares same-named fields of oldDTO and newDTO, structs or pointers to them,
+// and reports the fields whose value or nullness changed. Fields that are undefined
+// on either side are ignored, so diffing stored data against a partial submission
+// only reports what the submission touched.
+//
+// Nested DTOs are compared field by field and reported by dotted path, such as
+// Address.PostalCode; nil pointers to them in newDTO are ignored like undefined fields.
+// Slices of nested DTOs pair their elements like Merge does and report them by key,
+// or by position without a key field, such as PreviousAddresses[ID=3].Street. Fields of
+// added elements are reported as set, while old elements missing from newDTO are
+// ignored, since Merge keeps them.
+func Diff(oldDTO, newDTO any) (Changes, error) {
+	ov, err := structValue(oldDTO)
+	if err != nil {
+		return nil, err
+	}
+	nv, err := structValue(newDTO)
+	if err != nil {
+		return nil, err
+	}
+	changes := make(Changes)
+	if err := diffStruct(changes, ov, nv, ""); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func diffStruct(changes Changes, ov, nv reflect.Value, path string) error {
+	for _, p := range nullreflect.Pairs(ov.Type(), nv.Type()) {
+		name := fieldPath(path, p.Name)
+		of, nf := ov.FieldByIndex(p.Src), nv.FieldByIndex(p.Dst)
+		if nullreflect.IsNested(of.Type()) && nullreflect.IsNested(nf.Type()) {
+			n, ok := deref(nf)
+			if !ok {
+				continue
+			}
+			var err error
+			if o, ok := deref(of); ok {
+				err = diffStruct(changes, o, n, name)
+			} else {
+				err = listFields(changes, n, name)
+			}
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		_, oldSlice := nullreflect.NestedSlice(of.Type())
+		_, newSlice := nullreflect.NestedSlice(nf.Type())
+		if oldSlice && newSlice {
+			if err := diffSlice(changes, of, nf, name); err != nil {
+				return err
+			}
+			continue
+		}
+		before, bs, err := nullreflect.Read(of)
+		if err != nil {
+			return fmt.Errorf("forms: field %s: %w", name, err)
+		}
+		after, as, err := nullreflect.Read(nf)
+		if err != nil {
+			return fmt.Errorf("forms: field %s: %w", name, err)
+		}
+		if bs == nullable.StateUndefined || as == nullable.StateUndefined {
+			continue
+		}
+		if !equalValues(before, after) {
+			changes[name] = Change{Old: before, New: after}
+		}
+	}
+	return nil
+}
+
+func diffSlice(changes Changes, of, nf reflect.Value, path string) error {
+	if nf.IsNil() {
+		return nil
+	}
+	ko, kn, keyed := elemKeys(of.Type(), nf.Type())
+	for i := range nf.Len() {
+		n, present := deref(nf.Index(i))
+		if !present {
+			continue
+		}
+		j := i
+		var key driver.Value
+		hasKey := false
+		if keyed {
+			j = -1
+			if key, hasKey = nullreflect.Key(n, kn); hasKey {
+				j = match(of, ko, key)
+			}
+		}
+		name := nullreflect.ElemPath(path, i, kn, key, hasKey)
+		var o reflect.Value
+		if j >= 0 && j < of.Len() {
+			o, present = deref(of.Index(j))
+		} else {
+			present = false
+		}
+		var err error
+		if present {
+			err = diffStruct(changes, o, n, name)
+		} else {
+			err = listFields(changes, n, name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listFields reports the fields of the nested DTO v holding a value as set, for
+// nested DTOs and elements that exist in the new DTO only.
+func listFields(changes Changes, v reflect.Value, path string) error {
+	for _, f := range nullreflect.Fields(v.Type()) {
+		name := fieldPath(path, f.Name)
+		fv := v.FieldByIndex(f.Index)
+		if nullreflect.IsNested(fv.Type()) {
+			if s, ok := deref(fv); ok {
+				if err := listFields(changes, s, name); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if _, ok := nullreflect.NestedSlice(fv.Type()); ok {
+			for i := range fv.Len() {
+				if s, ok := deref(fv.Index(i)); ok {
+					if err := listFields(changes, s, fmt.Sprintf("%s[%d]", name, i)); err != nil {
+						return err
+					}
+				}
+			}
+			continue
+		}
+		val, _, err := nullreflect.Read(fv)
+		if err != nil {
+			return fmt.Errorf("forms: field %s: %w", name, err)
+		}
+		if val == nil {
+			continue
+		}
+		changes[name] = Change{New: val}
+	}
+	return nil
+}
+
+// equalValues compares two driver values, treating two nulls as equal.
+func equalValues(a, b driver.Value) bool {
+	switch av := a.(type) {
+	case nil:
+		return b == nil
+	case time.Time:
+		bv, ok := b.(time.Time)
+		return ok && av.Equal(bv)
+	case []byte:
+		bv, ok := b.([]byte)
+		return ok && bytes.Equal(av, bv)
+	}
+	return a == b
+}
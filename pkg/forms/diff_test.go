@@ -0,0 +1,80 @@
+package forms
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/guregu/null/v6"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new any
+		want     Changes
+	}{
+		{
+			name: "no changes",
+			old:  testForm{Name: some("Tan")},
+			new:  testForm{Name: some("Tan")},
+			want: Changes{},
+		},
+		{
+			name: "undefined ignored",
+			old:  testForm{Name: some("Tan"), MarriedName: some("Lee")},
+			new:  testForm{MarriedName: some("Lee")},
+			want: Changes{},
+		},
+		{
+			name: "changed, set and cleared",
+			old:  testForm{Name: some("Tan"), MarriedName: some("Lee"), Initials: cleared},
+			new:  testForm{Name: some("Lim"), MarriedName: cleared, Initials: some("L")},
+			want: Changes{
+				"Name":        {Old: "Tan", New: "Lim"},
+				"MarriedName": {Old: "Lee", New: nil},
+				"Initials":    {Old: nil, New: "L"},
+			},
+		},
+		{
+			name: "across types",
+			old:  testForm{Age: nullable.From[int32](30)},
+			new:  testStep{Age: null.Int32From(31)},
+			want: Changes{"Age": {Old: int64(30), New: int64(31)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(tt.old, tt.new)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Errorf("Diff = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChange(t *testing.T) {
+	tests := []struct {
+		name         string
+		c            Change
+		set, cleared bool
+	}{
+		{name: "set", c: Change{Old: nil, New: "a"}, set: true},
+		{name: "cleared", c: Change{Old: "a", New: nil}, cleared: true},
+		{name: "changed", c: Change{Old: "a", New: "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.Set(); got != tt.set {
+				t.Errorf("Set() = %v, want %v", got, tt.set)
+			}
+			if got := tt.c.Cleared(); got != tt.cleared {
+				t.Errorf("Cleared() = %v, want %v", got, tt.cleared)
+			}
+		})
+	}
+}
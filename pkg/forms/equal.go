@@ -0,0 +1,114 @@
+package forms
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// Equal reports whether a and b, structs or pointers to them, hold the same state and
+// value in every field. Fields are matched by name and compared through their driver
+// values, so nullable.Null, null.XxX and pgtype.XxX holding the same value are equal,
+// two nulls are equal and undefined is not equal to null. A field present on only one
+// side must be undefined there.
+//
+// Nested DTOs are compared field by field, a nil pointer to one standing for a DTO whose
+// fields are all undefined. Slices of nested DTOs are equal if both are nil or they hold
+// equal elements in the same order. Fields that cannot be read, such as unsupported
+// types, are reported as an error naming their path.
+func Equal(a, b any) (bool, error) {
+	av, err := structValue(a)
+	if err != nil {
+		return false, err
+	}
+	bv, err := structValue(b)
+	if err != nil {
+		return false, err
+	}
+	return equalStruct(av, bv, "")
+}
+
+func equalStruct(av, bv reflect.Value, path string) (bool, error) {
+	for _, p := range nullreflect.Pairs(av.Type(), bv.Type()) {
+		name := fieldPath(path, p.Name)
+		af, bf := av.FieldByIndex(p.Src), bv.FieldByIndex(p.Dst)
+		if nullreflect.IsNested(af.Type()) && nullreflect.IsNested(bf.Type()) {
+			if eq, err := equalStruct(nestedOrZero(af), nestedOrZero(bf), name); err != nil || !eq {
+				return false, err
+			}
+			continue
+		}
+		_, aSlice := nullreflect.NestedSlice(af.Type())
+		_, bSlice := nullreflect.NestedSlice(bf.Type())
+		if aSlice && bSlice {
+			if af.IsNil() != bf.IsNil() || af.Len() != bf.Len() {
+				return false, nil
+			}
+			for i := range af.Len() {
+				eq, err := equalStruct(nestedOrZero(af.Index(i)), nestedOrZero(bf.Index(i)), fmt.Sprintf("%s[%d]", name, i))
+				if err != nil || !eq {
+					return false, err
+				}
+			}
+			continue
+		}
+		x, xs, err := nullreflect.Read(af)
+		if err != nil {
+			return false, fmt.Errorf("forms: field %s: %w", name, err)
+		}
+		y, ys, err := nullreflect.Read(bf)
+		if err != nil {
+			return false, fmt.Errorf("forms: field %s: %w", name, err)
+		}
+		if xs != ys || !equalValues(x, y) {
+			return false, nil
+		}
+	}
+	return allUndefined(av, bv.Type()) && allUndefined(bv, av.Type()), nil
+}
+
+// nestedOrZero returns the struct held by the nested DTO v, or the zero struct of its
+// type if v is a nil pointer.
+func nestedOrZero(v reflect.Value) reflect.Value {
+	if s, ok := deref(v); ok {
+		return s
+	}
+	return reflect.Zero(v.Type().Elem())
+}
+
+// allUndefined reports whether every field of struct v without a same-named field in t
+// is undefined, t being nil to check every field. Nested DTOs are undefined if all their
+// fields are, and slices of them if nil.
+func allUndefined(v reflect.Value, t reflect.Type) bool {
+	paired := make(map[string]bool)
+	if t != nil {
+		for _, p := range nullreflect.Pairs(v.Type(), t) {
+			paired[p.Name] = true
+		}
+	}
+	for _, f := range nullreflect.Fields(v.Type()) {
+		if paired[f.Name] {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		switch {
+		case nullreflect.IsNested(fv.Type()):
+			if !allUndefined(nestedOrZero(fv), nil) {
+				return false
+			}
+		case isNestedSlice(fv.Type()):
+			if !fv.IsNil() {
+				return false
+			}
+		case !nullreflect.IsUndefined(fv):
+			return false
+		}
+	}
+	return true
+}
+
+func isNestedSlice(t reflect.Type) bool {
+	_, ok := nullreflect.NestedSlice(t)
+	return ok
+}
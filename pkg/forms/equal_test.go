@@ -0,0 +1,44 @@
+package forms
+
+import (
+	"testing"
+
+	"github.com/guregu/null/v6"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+var equalTests = []struct {
+	name  string
+	a, b  any
+	equal bool
+}{
+	{name: "same", a: testForm{Name: some("Tan")}, b: testForm{Name: some("Tan")}, equal: true},
+	{name: "different value", a: testForm{Name: some("Tan")}, b: testForm{Name: some("Lim")}},
+	{name: "null is not undefined", a: testForm{Name: cleared}, b: testForm{}},
+	{name: "two nulls", a: testForm{Name: cleared}, b: testForm{Name: cleared}, equal: true},
+	{
+		name: "different nullable types",
+		a:    testStep{Name: some("Tan"), Age: null.Int32From(30)},
+		b: struct {
+			Name pgtype.Text
+			Age  nullable.Int32
+		}{pgtype.Text{String: "Tan", Valid: true}, nullable.Int32From(30)},
+		equal: true,
+	},
+}
+
+func TestEqual(t *testing.T) {
+	for _, tt := range equalTests {
+		t.Run(tt.name, func(t *testing.T) {
+			eq, err := Equal(tt.a, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if eq != tt.equal {
+				t.Errorf("Equal = %v, want %v", eq, tt.equal)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package forms
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// FieldErrors maps field names to their error messages. It is the error returned
+// by binding, conversion and validation layers alike, and marshals to the shape
+// frontends render next to the inputs:
+//
+//	{"errors":{"name":["required"]}}
+type FieldErrors map[string][]string
+
+// Add appends msg to the messages of field, allocating fe if needed.
+func (fe *FieldErrors) Add(field, msg string) {
+	if *fe == nil {
+		*fe = make(FieldErrors)
+	}
+	(*fe)[field] = append((*fe)[field], msg)
+}
+
+// Err returns fe as an error, or nil if it holds no errors, so a collected
+// FieldErrors can be returned directly.
+func (fe FieldErrors) Err() error {
+	if len(fe) == 0 {
+		return nil
+	}
+	return fe
+}
+
+// Fields returns the names of the fields with errors in sorted order.
+func (fe FieldErrors) Fields() []string {
+	names := make([]string, 0, len(fe))
+	for name := range fe {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Error lists the messages of each field, sorted by field name.
+func (fe FieldErrors) Error() string {
+	names := fe.Fields()
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + ": " + strings.Join(fe[name], ", ")
+	}
+	return strings.Join(parts, "; ")
+}
+
+// MarshalJSON implements json.Marshaler, nesting the messages under an "errors" key.
+func (fe FieldErrors) MarshalJSON() ([]byte, error) {
+	errs := map[string][]string(fe)
+	if errs == nil {
+		errs = map[string][]string{}
+	}
+	return json.Marshal(struct {
+		Errors map[string][]string `json:"errors"`
+	}{errs})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting what MarshalJSON produces.
+func (fe *FieldErrors) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Errors map[string][]string `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*fe = v.Errors
+	return nil
+}
@@ -0,0 +1,39 @@
+package forms
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFieldErrors(t *testing.T) {
+	var fe FieldErrors
+	if fe.Err() != nil {
+		t.Fatal("empty FieldErrors: Err() != nil")
+	}
+	fe.Add("name", "required")
+	fe.Add("age", "min")
+	fe.Add("name", "too short")
+	err := fe.Err()
+	var got FieldErrors
+	if !errors.As(err, &got) {
+		t.Fatalf("Err() = %T, want FieldErrors", err)
+	}
+	if want := "age: min; name: required, too short"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	b, err := fe.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"errors":{"age":["min"],"name":["required","too short"]}}`; string(b) != want {
+		t.Errorf("MarshalJSON = %s, want %s", b, want)
+	}
+	var back FieldErrors
+	if err := back.UnmarshalJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back, fe) {
+		t.Errorf("UnmarshalJSON = %v, want %v", back, fe)
+	}
+}
@@ -0,0 +1,126 @@
+package forms
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Fingerprint returns a deterministic 64-bit FNV-1a hash of the defined fields of dto,
+// a struct or pointer to one, for idempotency keys and deduplicating repeated submissions.
+// Explicit nulls are hashed and undefined fields are skipped, so a field sent as null and
+// a field left out give different fingerprints. Fields are hashed by name in sorted order
+// through their driver values, making the result independent of field order and of the
+// nullable type used, and times are compared as instants. It is not a cryptographic hash.
+//
+// Fields of nested DTOs are hashed by path, such as Current.Street, so nil pointers to
+// them hash like DTOs whose fields are all undefined. Slices of nested DTOs hash their
+// length and their elements in order, and nil slices are skipped. The fingerprints of
+// two DTOs are equal whenever Equal reports them equal.
+func Fingerprint(dto any) (uint64, error) {
+	v, err := structValue(dto)
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New64a()
+	if err := fingerprintStruct(h, v, ""); err != nil {
+		return 0, err
+	}
+	return h.Sum64(), nil
+}
+
+func fingerprintStruct(h hash.Hash64, v reflect.Value, path string) error {
+	fields := slices.Clone(nullreflect.Fields(v.Type()))
+	slices.SortFunc(fields, func(a, b nullreflect.Field) int { return strings.Compare(a.Name, b.Name) })
+	for _, f := range fields {
+		name := fieldPath(path, f.Name)
+		fv := v.FieldByIndex(f.Index)
+		if nullreflect.IsNested(fv.Type()) {
+			if err := fingerprintStruct(h, nestedOrZero(fv), name); err != nil {
+				return err
+			}
+			continue
+		}
+		if isNestedSlice(fv.Type()) {
+			if fv.IsNil() {
+				continue
+			}
+			writeBytes(h, []byte(name))
+			var n [8]byte
+			binary.BigEndian.PutUint64(n[:], uint64(fv.Len()))
+			h.Write(n[:])
+			for i := range fv.Len() {
+				if err := fingerprintStruct(h, nestedOrZero(fv.Index(i)), fmt.Sprintf("%s[%d]", name, i)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		val, state, err := nullreflect.Read(fv)
+		if err != nil {
+			return fmt.Errorf("forms: field %s: %w", name, err)
+		}
+		if state == nullable.StateUndefined {
+			continue
+		}
+		writeBytes(h, []byte(name))
+		if err := writeValue(h, val); err != nil {
+			return fmt.Errorf("forms: field %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// writeValue hashes a driver value prefixed by a type tag, so values of different
+// types never collide by their encoding alone.
+func writeValue(h hash.Hash64, val driver.Value) error {
+	var buf [9]byte
+	switch v := val.(type) {
+	case nil:
+		h.Write([]byte{0})
+	case int64:
+		buf[0] = 1
+		binary.BigEndian.PutUint64(buf[1:], uint64(v))
+		h.Write(buf[:])
+	case float64:
+		buf[0] = 2
+		binary.BigEndian.PutUint64(buf[1:], math.Float64bits(v))
+		h.Write(buf[:])
+	case bool:
+		buf[0] = 3
+		if v {
+			buf[1] = 1
+		}
+		h.Write(buf[:2])
+	case string:
+		h.Write([]byte{4})
+		writeBytes(h, []byte(v))
+	case []byte:
+		h.Write([]byte{5})
+		writeBytes(h, v)
+	case time.Time:
+		h.Write([]byte{6})
+		writeBytes(h, v.UTC().AppendFormat(nil, time.RFC3339Nano))
+	default:
+		return fmt.Errorf("cannot fingerprint %T", val)
+	}
+	return nil
+}
+
+// writeBytes hashes b prefixed by its length.
+func writeBytes(h hash.Hash64, b []byte) {
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], uint64(len(b)))
+	h.Write(n[:])
+	h.Write(b)
+}
@@ -0,0 +1,24 @@
+package forms
+
+import (
+	"testing"
+)
+
+// TestFingerprint checks that fingerprints agree with Equal.
+func TestFingerprint(t *testing.T) {
+	for _, tt := range equalTests {
+		t.Run(tt.name, func(t *testing.T) {
+			fa, err := Fingerprint(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fb, err := Fingerprint(tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (fa == fb) != tt.equal {
+				t.Errorf("Fingerprint = %#x and %#x, want equal %v", fa, fb, tt.equal)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+// Package forms implements the multi-step form workflow on top of nullable DTOs:
+// accumulating answers across steps, deriving computed fields and comparing submissions.
+package forms
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Merge overlays the defined fields of step onto base, matching fields by name.
+// Undefined step fields keep the answer already in base, null step fields clear it,
+// and any other value replaces it. Fields that cannot be undefined, such as
+// nullable.Null or plain values, are always merged.
+//
+// Nested DTOs, structs that are not values themselves, are merged field by field,
+// and nil pointers to them in step keep base as it is. Slices of nested DTOs, such as
+// repeated previous addresses, are merged element by element: each step element is
+// matched with the base element holding the same value in the field tagged
+// `forms:"key"`, or by position if the element type has no key field, and appended
+// if there is none. Base elements missing from step are kept, as is base when the
+// step slice is nil.
+//
+// base must be a non-nil pointer to a struct, step a struct or a pointer to one.
+// The two may be different types, e.g. a step DTO covering a subset of the full form.
+func Merge(base, step any) error {
+	bv := reflect.ValueOf(base)
+	if bv.Kind() != reflect.Pointer || bv.IsNil() || bv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("forms: base must be a non-nil pointer to a struct, got %T", base)
+	}
+	sv, err := structValue(step)
+	if err != nil {
+		return err
+	}
+	return mergeStruct(bv.Elem(), sv, "")
+}
+
+func mergeStruct(bv, sv reflect.Value, path string) error {
+	for _, p := range nullreflect.Pairs(sv.Type(), bv.Type()) {
+		name := fieldPath(path, p.Name)
+		sf, bf := sv.FieldByIndex(p.Src), bv.FieldByIndex(p.Dst)
+		if nullreflect.IsNested(sf.Type()) && nullreflect.IsNested(bf.Type()) {
+			if s, ok := deref(sf); ok {
+				if err := mergeStruct(alloc(bf), s, name); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		_, srcSlice := nullreflect.NestedSlice(sf.Type())
+		_, dstSlice := nullreflect.NestedSlice(bf.Type())
+		if srcSlice && dstSlice {
+			if err := mergeSlice(bf, sf, name); err != nil {
+				return err
+			}
+			continue
+		}
+		val, state, err := nullreflect.Read(sf)
+		if err != nil {
+			return fmt.Errorf("forms: field %s: %w", name, err)
+		}
+		if state == nullable.StateUndefined {
+			continue
+		}
+		if err := nullreflect.Write(bf, val, state); err != nil {
+			return fmt.Errorf("forms: field %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func mergeSlice(bf, sf reflect.Value, path string) error {
+	if sf.IsNil() {
+		return nil
+	}
+	sk, bk, keyed := elemKeys(sf.Type(), bf.Type())
+	for i := range sf.Len() {
+		se, ok := deref(sf.Index(i))
+		if !ok {
+			continue
+		}
+		j := i
+		var key driver.Value
+		hasKey := false
+		if keyed {
+			j = -1
+			if key, hasKey = nullreflect.Key(se, sk); hasKey {
+				j = match(bf, bk, key)
+			}
+		}
+		if j < 0 || j >= bf.Len() {
+			bf.Set(reflect.Append(bf, reflect.Zero(bf.Type().Elem())))
+			j = bf.Len() - 1
+		}
+		if err := mergeStruct(alloc(bf.Index(j)), se, nullreflect.ElemPath(path, i, sk, key, hasKey)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func structValue(x any) (reflect.Value, error) {
+	v := reflect.ValueOf(x)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, errors.New("forms: nil pointer")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("forms: expected a struct, got %T", x)
+	}
+	return v, nil
+}
@@ -0,0 +1,92 @@
+package forms
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/guregu/null/v6"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type testAddress struct {
+	ID     nullable.Optional[int64] `forms:"key"`
+	Street nullable.Optional[string]
+}
+
+type testLine struct {
+	Text nullable.Optional[string]
+}
+
+type testForm struct {
+	Name        nullable.Optional[string]
+	MarriedName nullable.Optional[string]
+	Age         nullable.Null[int32]
+	DisplayName nullable.Optional[string]
+	Initials    nullable.Optional[string]
+	Current     *testAddress
+	Previous    []testAddress
+	Lines       []testLine
+}
+
+// testStep covers a subset of testForm, using other nullable types for the same fields.
+type testStep struct {
+	Name nullable.Optional[string]
+	Age  null.Int32
+}
+
+var (
+	undefined = nullable.Optional[string]{}
+	cleared   = nullable.OptionalNull[string]()
+	some      = nullable.OptionalFrom[string]
+	id        = nullable.OptionalFrom[int64]
+)
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name string
+		base testForm
+		step any
+		want testForm
+	}{
+		{
+			name: "undefined keeps, null clears, value replaces",
+			base: testForm{Name: some("Tan"), MarriedName: some("Lee"), Initials: some("T")},
+			step: testForm{Name: some("Lim"), MarriedName: cleared},
+			want: testForm{Name: some("Lim"), MarriedName: cleared, Initials: some("T")},
+		},
+		{
+			name: "fields that cannot be undefined are always merged",
+			base: testForm{Age: nullable.From[int32](30)},
+			step: testForm{},
+			want: testForm{},
+		},
+		{
+			name: "step of another type",
+			base: testForm{Name: some("Tan"), Age: nullable.From[int32](30)},
+			step: &testStep{Age: null.Int32From(31)},
+			want: testForm{Name: some("Tan"), Age: nullable.From[int32](31)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.base
+			if err := Merge(&got, tt.step); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Merge = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeErrors(t *testing.T) {
+	var f testForm
+	if err := Merge(f, testStep{}); err == nil {
+		t.Error("Merge into a struct value: want an error")
+	}
+	if err := Merge(&f, 42); err == nil {
+		t.Error("Merge of an int: want an error")
+	}
+}
@@ -0,0 +1,59 @@
+package forms
+
+import (
+	"database/sql/driver"
+	"reflect"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// fieldPath joins the path of a nested DTO and the name of one of its fields.
+func fieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// deref returns the struct held by the nested DTO v, and false for nil pointers.
+func deref(v reflect.Value) (reflect.Value, bool) {
+	if v.Kind() != reflect.Pointer {
+		return v, true
+	}
+	if v.IsNil() {
+		return v, false
+	}
+	return v.Elem(), true
+}
+
+// alloc returns the struct held by the nested DTO v, allocating it if v is a nil pointer.
+func alloc(v reflect.Value) reflect.Value {
+	if v.Kind() != reflect.Pointer {
+		return v
+	}
+	if v.IsNil() {
+		v.Set(reflect.New(v.Type().Elem()))
+	}
+	return v.Elem()
+}
+
+// elemKeys returns the key fields of the element types of two slices of nested DTOs,
+// which must have the same name, or false if they have none.
+func elemKeys(a, b reflect.Type) (ka, kb nullreflect.Field, ok bool) {
+	ka, ok = nullreflect.KeyField(a.Elem())
+	if !ok {
+		return ka, kb, false
+	}
+	kb, ok = nullreflect.KeyField(b.Elem())
+	return ka, kb, ok && ka.Name == kb.Name
+}
+
+// match returns the index of the element of s whose key is key, or -1.
+func match(s reflect.Value, f nullreflect.Field, key driver.Value) int {
+	for i := range s.Len() {
+		if k, ok := nullreflect.Key(s.Index(i), f); ok && nullreflect.SameKey(k, key) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,178 @@
+package forms
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeNested(t *testing.T) {
+	tests := []struct {
+		name string
+		base testForm
+		step testForm
+		want testForm
+	}{
+		{
+			name: "nil nested pointer keeps base",
+			base: testForm{Current: &testAddress{Street: some("Orchard Rd")}},
+			step: testForm{},
+			want: testForm{Current: &testAddress{Street: some("Orchard Rd")}},
+		},
+		{
+			name: "nested field by field",
+			base: testForm{Current: &testAddress{ID: id(1), Street: some("Orchard Rd")}},
+			step: testForm{Current: &testAddress{Street: cleared}},
+			want: testForm{Current: &testAddress{ID: id(1), Street: cleared}},
+		},
+		{
+			name: "nested allocated",
+			base: testForm{},
+			step: testForm{Current: &testAddress{Street: some("Orchard Rd")}},
+			want: testForm{Current: &testAddress{Street: some("Orchard Rd")}},
+		},
+		{
+			name: "slice elements by key",
+			base: testForm{Previous: []testAddress{{ID: id(1), Street: some("A")}, {ID: id(2), Street: some("B")}}},
+			step: testForm{Previous: []testAddress{{ID: id(2), Street: some("C")}, {ID: id(3), Street: some("D")}}},
+			want: testForm{Previous: []testAddress{{ID: id(1), Street: some("A")}, {ID: id(2), Street: some("C")}, {ID: id(3), Street: some("D")}}},
+		},
+		{
+			name: "slice elements by position",
+			base: testForm{Lines: []testLine{{Text: some("a")}, {Text: some("b")}}},
+			step: testForm{Lines: []testLine{{}, {Text: cleared}, {Text: some("c")}}},
+			want: testForm{Lines: []testLine{{Text: some("a")}, {Text: cleared}, {Text: some("c")}}},
+		},
+		{
+			name: "nil slice keeps base",
+			base: testForm{Lines: []testLine{{Text: some("a")}}},
+			step: testForm{},
+			want: testForm{Lines: []testLine{{Text: some("a")}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.base
+			if err := Merge(&got, tt.step); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Merge = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffNested(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new testForm
+		want     Changes
+	}{
+		{
+			name: "nested by path",
+			old:  testForm{Current: &testAddress{Street: some("A")}},
+			new:  testForm{Current: &testAddress{Street: some("B")}},
+			want: Changes{"Current.Street": {Old: "A", New: "B"}},
+		},
+		{
+			name: "slice by key ignores old elements missing from new",
+			old:  testForm{Previous: []testAddress{{ID: id(1), Street: some("A")}, {ID: id(2), Street: some("B")}}},
+			new:  testForm{Previous: []testAddress{{ID: id(2), Street: some("C")}, {ID: id(3)}}},
+			want: Changes{
+				"Previous[ID=2].Street": {Old: "B", New: "C"},
+				"Previous[ID=3].ID":     {Old: nil, New: int64(3)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Diff(tt.old, tt.new)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Diff = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffAgreesWithMerge(t *testing.T) {
+	base := testForm{
+		Current:  &testAddress{Street: some("A")},
+		Previous: []testAddress{{ID: id(1), Street: some("A")}, {ID: id(2), Street: some("B")}},
+		Lines:    []testLine{{Text: some("a")}, {Text: some("b")}},
+	}
+	step := testForm{
+		Current:  &testAddress{ID: id(9)},
+		Previous: []testAddress{{ID: id(2), Street: cleared}, {ID: id(3), Street: some("D")}},
+		Lines:    []testLine{{}, {Text: some("c")}},
+	}
+	want, err := Diff(base, step)
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged := base
+	merged.Current = &testAddress{Street: some("A")}
+	merged.Previous = append([]testAddress(nil), base.Previous...)
+	merged.Lines = append([]testLine(nil), base.Lines...)
+	if err := Merge(&merged, step); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Diff(base, merged)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff(base, Merge(base, step)) = %#v, want Diff(base, step) = %#v", got, want)
+	}
+}
+
+func TestEqualNested(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  testForm
+		equal bool
+	}{
+		{
+			name:  "nil nested is all undefined",
+			a:     testForm{Current: &testAddress{}},
+			b:     testForm{},
+			equal: true,
+		},
+		{name: "nested differs", a: testForm{Current: &testAddress{Street: some("A")}}, b: testForm{}},
+		{
+			name:  "slices in order",
+			a:     testForm{Lines: []testLine{{Text: some("a")}, {Text: some("b")}}},
+			b:     testForm{Lines: []testLine{{Text: some("a")}, {Text: some("b")}}},
+			equal: true,
+		},
+		{
+			name: "slices out of order",
+			a:    testForm{Lines: []testLine{{Text: some("a")}, {Text: some("b")}}},
+			b:    testForm{Lines: []testLine{{Text: some("b")}, {Text: some("a")}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eq, err := Equal(tt.a, tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if eq != tt.equal {
+				t.Errorf("Equal = %v, want %v", eq, tt.equal)
+			}
+			fa, err := Fingerprint(tt.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fb, err := Fingerprint(tt.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (fa == fb) != tt.equal {
+				t.Errorf("Fingerprint = %#x and %#x, want equal %v", fa, fb, tt.equal)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Package formsession persists partially completed form DTOs between requests,
+// so users can resume a stepped form where they left off.
+//
+// A session blob records which fields were defined, null or holding a value, and
+// nothing about the undefined ones, so restoring it brings back the same
+// nullable.Optional states the DTO had when it was saved:
+//
+//	blob, err := formsession.Encode(patch)
+//	http.SetCookie(w, &http.Cookie{Name: "uinfin-names", Value: blob})
+//	// on the next request
+//	var patch dtos.UinfinNamesPatch
+//	err := formsession.Decode(cookie.Value, &patch)
+package formsession
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// ErrInvalid is returned by Decode for blobs that are malformed or fail signature verification.
+var ErrInvalid = errors.New("formsession: invalid session blob")
+
+// Codec encodes DTOs into URL-safe session blobs, fit for cookie values or Redis keys.
+// If Key is set, blobs are signed with HMAC-SHA256 and Decode rejects tampered ones;
+// blobs stored client-side, such as in cookies, should always be signed.
+type Codec struct {
+	Key []byte
+}
+
+// Encode serializes the defined fields of dto, a struct or pointer to one, into a blob.
+// Fields are stored under their Go name with their JSON encoding; undefined fields are left out.
+func (c Codec) Encode(dto any) (string, error) {
+	v := reflect.Indirect(reflect.ValueOf(dto))
+	if v.Kind() != reflect.Struct {
+		return "", fmt.Errorf("formsession: dto must be a struct, got %T", dto)
+	}
+
+	fields := make(map[string]json.RawMessage)
+	for _, f := range nullreflect.Fields(v.Type()) {
+		fv := v.FieldByIndex(f.Index)
+		if nullreflect.IsUndefined(fv) {
+			continue
+		}
+		b, err := json.Marshal(fv.Interface())
+		if err != nil {
+			return "", fmt.Errorf("formsession: field %s: %w", f.Name, err)
+		}
+		fields[f.Name] = b
+	}
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("formsession: %w", err)
+	}
+
+	blob := base64.RawURLEncoding.EncodeToString(payload)
+	if c.Key != nil {
+		blob += "." + base64.RawURLEncoding.EncodeToString(c.sign(payload))
+	}
+	return blob, nil
+}
+
+// Decode restores a blob produced by Encode into dto, a non-nil pointer to a struct.
+// Fields missing from the blob are reset to their zero value, which is undefined for
+// nullable.Optional; fields in the blob that dto doesn't have are ignored.
+func (c Codec) Decode(blob string, dto any) error {
+	v := reflect.ValueOf(dto)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("formsession: dto must be a non-nil pointer to a struct, got %T", dto)
+	}
+	v = v.Elem()
+
+	encoded, sig, signed := strings.Cut(blob, ".")
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return ErrInvalid
+	}
+	if c.Key != nil {
+		mac, err := base64.RawURLEncoding.DecodeString(sig)
+		if !signed || err != nil || !hmac.Equal(mac, c.sign(payload)) {
+			return ErrInvalid
+		}
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return ErrInvalid
+	}
+	for _, f := range nullreflect.Fields(v.Type()) {
+		fv := v.FieldByIndex(f.Index)
+		fv.SetZero()
+		raw, ok := fields[f.Name]
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, fv.Addr().Interface()); err != nil {
+			return fmt.Errorf("formsession: field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c Codec) sign(payload []byte) []byte {
+	h := hmac.New(sha256.New, c.Key)
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// Encode serializes dto into an unsigned blob, see Codec.Encode.
+func Encode(dto any) (string, error) {
+	return Codec{}.Encode(dto)
+}
+
+// Decode restores an unsigned blob into dto, see Codec.Decode.
+func Decode(blob string, dto any) error {
+	return Codec{}.Decode(blob, dto)
+}
@@ -0,0 +1,65 @@
+// Package gormnull integrates the nullable types with GORM.
+//
+// The nullable types already implement sql.Scanner and driver.Valuer, so they work
+// as GORM model fields without extra tags. Types that GORM cannot infer a column type
+// for, such as nullable.Date or nullable.UUID, report one through GormDataType:
+//
+//	type Person struct {
+//		ID          nullable.UUID `gorm:"primaryKey"`
+//		Name        nullable.Null[string]
+//		DateOfBirth nullable.Date
+//		Income      nullable.Decimal
+//	}
+//
+// GORM skips zero fields when updating from a struct, which leaves undefined Optional
+// fields untouched while explicit nulls still clear their column:
+//
+//	db.Model(&person).Updates(dtos.UinfinNamesPatch{Name: nullable.OptionalNull[string]()})
+//
+// Fields of any other type, for example legacy structs that pgx or GORM cannot store
+// directly, can opt into the "nullable" serializer registered by this package, which
+// converts values the way the rest of this module does:
+//
+//	Aliasname null.String `gorm:"serializer:nullable"`
+package gormnull
+
+import (
+	"context"
+	"reflect"
+
+	"gorm.io/gorm/schema"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+func init() {
+	schema.RegisterSerializer("nullable", Serializer{})
+}
+
+// Serializer is a GORM serializer storing nullable fields as their driver value,
+// NULL for null and undefined values. It is registered under the name "nullable".
+type Serializer struct{}
+
+// Scan implements schema.SerializerInterface.
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	fieldValue := reflect.New(field.FieldType).Elem()
+	state := nullstate.Present
+	if dbValue == nil {
+		state = nullstate.Null
+	}
+	if err := nullreflect.Write(fieldValue, dbValue, state); err != nil {
+		return err
+	}
+	field.ReflectValueOf(ctx, dst).Set(fieldValue)
+	return nil
+}
+
+// Value implements schema.SerializerValuerInterface.
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	if fieldValue == nil {
+		return nil, nil
+	}
+	v, _, err := nullreflect.Read(reflect.ValueOf(fieldValue))
+	return v, err
+}
@@ -0,0 +1,221 @@
+// Package graphql lets gqlgen bind nullable.Null and nullable.Optional as custom scalars,
+// so optional and nullable mutation inputs map straight onto DTO fields.
+//
+// gqlgen resolves a model to the MarshalXxx and UnmarshalXxx functions of this package:
+//
+//	# gqlgen.yml
+//	models:
+//	  String:
+//	    model:
+//	      - github.com/99designs/gqlgen/graphql.String
+//	      - github.com/nadhifikbarw/x-go-painless-null/pkg/graphql.NullString
+//	      - github.com/nadhifikbarw/x-go-painless-null/pkg/graphql.OptionalString
+//
+// With that binding an input type such as
+//
+//	input UinfinNamesPatch {
+//	  uinfin: String
+//	  name: String
+//	}
+//
+// can be bound to dtos.UinfinNamesPatch. gqlgen only unmarshals fields that are present
+// in the input, so omitted fields stay undefined while an explicit null becomes null.
+package graphql
+
+import (
+	"time"
+
+	gql "github.com/99designs/gqlgen/graphql"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+func marshalNull[T comparable](n nullable.Null[T], marshal func(T) gql.Marshaler) gql.Marshaler {
+	if !n.Valid {
+		return gql.Null
+	}
+	return marshal(n.V)
+}
+
+func unmarshalNull[T comparable](v any, unmarshal func(any) (T, error)) (nullable.Null[T], error) {
+	if v == nil {
+		return nullable.Null[T]{}, nil
+	}
+	x, err := unmarshal(v)
+	if err != nil {
+		return nullable.Null[T]{}, err
+	}
+	return nullable.From(x), nil
+}
+
+func marshalOptional[T comparable](o nullable.Optional[T], marshal func(T) gql.Marshaler) gql.Marshaler {
+	return marshalNull(o.Null(), marshal)
+}
+
+func unmarshalOptional[T comparable](v any, unmarshal func(any) (T, error)) (nullable.Optional[T], error) {
+	n, err := unmarshalNull(v, unmarshal)
+	if err != nil {
+		return nullable.Optional[T]{}, err
+	}
+	return nullable.OptionalOf(n), nil
+}
+
+// MarshalNullString marshals a nullable.Null[string] as a String.
+func MarshalNullString(n nullable.Null[string]) gql.Marshaler {
+	return marshalNull(n, gql.MarshalString)
+}
+
+// UnmarshalNullString unmarshals a String into a nullable.Null[string].
+func UnmarshalNullString(v any) (nullable.Null[string], error) {
+	return unmarshalNull(v, gql.UnmarshalString)
+}
+
+// MarshalOptionalString marshals a nullable.Optional[string] as a String.
+func MarshalOptionalString(o nullable.Optional[string]) gql.Marshaler {
+	return marshalOptional(o, gql.MarshalString)
+}
+
+// UnmarshalOptionalString unmarshals a String into a nullable.Optional[string].
+func UnmarshalOptionalString(v any) (nullable.Optional[string], error) {
+	return unmarshalOptional(v, gql.UnmarshalString)
+}
+
+// MarshalNullID marshals a nullable.Null[string] as an ID.
+func MarshalNullID(n nullable.Null[string]) gql.Marshaler {
+	return marshalNull(n, gql.MarshalID)
+}
+
+// UnmarshalNullID unmarshals an ID into a nullable.Null[string].
+func UnmarshalNullID(v any) (nullable.Null[string], error) {
+	return unmarshalNull(v, gql.UnmarshalID)
+}
+
+// MarshalOptionalID marshals a nullable.Optional[string] as an ID.
+func MarshalOptionalID(o nullable.Optional[string]) gql.Marshaler {
+	return marshalOptional(o, gql.MarshalID)
+}
+
+// UnmarshalOptionalID unmarshals an ID into a nullable.Optional[string].
+func UnmarshalOptionalID(v any) (nullable.Optional[string], error) {
+	return unmarshalOptional(v, gql.UnmarshalID)
+}
+
+// MarshalNullInt marshals a nullable.Null[int] as an Int.
+func MarshalNullInt(n nullable.Null[int]) gql.Marshaler {
+	return marshalNull(n, gql.MarshalInt)
+}
+
+// UnmarshalNullInt unmarshals an Int into a nullable.Null[int].
+func UnmarshalNullInt(v any) (nullable.Null[int], error) {
+	return unmarshalNull(v, gql.UnmarshalInt)
+}
+
+// MarshalOptionalInt marshals a nullable.Optional[int] as an Int.
+func MarshalOptionalInt(o nullable.Optional[int]) gql.Marshaler {
+	return marshalOptional(o, gql.MarshalInt)
+}
+
+// UnmarshalOptionalInt unmarshals an Int into a nullable.Optional[int].
+func UnmarshalOptionalInt(v any) (nullable.Optional[int], error) {
+	return unmarshalOptional(v, gql.UnmarshalInt)
+}
+
+// MarshalNullInt32 marshals a nullable.Null[int32] as an Int.
+func MarshalNullInt32(n nullable.Null[int32]) gql.Marshaler {
+	return marshalNull(n, gql.MarshalInt32)
+}
+
+// UnmarshalNullInt32 unmarshals an Int into a nullable.Null[int32].
+func UnmarshalNullInt32(v any) (nullable.Null[int32], error) {
+	return unmarshalNull(v, gql.UnmarshalInt32)
+}
+
+// MarshalOptionalInt32 marshals a nullable.Optional[int32] as an Int.
+func MarshalOptionalInt32(o nullable.Optional[int32]) gql.Marshaler {
+	return marshalOptional(o, gql.MarshalInt32)
+}
+
+// UnmarshalOptionalInt32 unmarshals an Int into a nullable.Optional[int32].
+func UnmarshalOptionalInt32(v any) (nullable.Optional[int32], error) {
+	return unmarshalOptional(v, gql.UnmarshalInt32)
+}
+
+// MarshalNullInt64 marshals a nullable.Null[int64] as an Int.
+func MarshalNullInt64(n nullable.Null[int64]) gql.Marshaler {
+	return marshalNull(n, gql.MarshalInt64)
+}
+
+// UnmarshalNullInt64 unmarshals an Int into a nullable.Null[int64].
+func UnmarshalNullInt64(v any) (nullable.Null[int64], error) {
+	return unmarshalNull(v, gql.UnmarshalInt64)
+}
+
+// MarshalOptionalInt64 marshals a nullable.Optional[int64] as an Int.
+func MarshalOptionalInt64(o nullable.Optional[int64]) gql.Marshaler {
+	return marshalOptional(o, gql.MarshalInt64)
+}
+
+// UnmarshalOptionalInt64 unmarshals an Int into a nullable.Optional[int64].
+func UnmarshalOptionalInt64(v any) (nullable.Optional[int64], error) {
+	return unmarshalOptional(v, gql.UnmarshalInt64)
+}
+
+// MarshalNullFloat marshals a nullable.Null[float64] as a Float.
+func MarshalNullFloat(n nullable.Null[float64]) gql.Marshaler {
+	return marshalNull(n, gql.MarshalFloat)
+}
+
+// UnmarshalNullFloat unmarshals a Float into a nullable.Null[float64].
+func UnmarshalNullFloat(v any) (nullable.Null[float64], error) {
+	return unmarshalNull(v, gql.UnmarshalFloat)
+}
+
+// MarshalOptionalFloat marshals a nullable.Optional[float64] as a Float.
+func MarshalOptionalFloat(o nullable.Optional[float64]) gql.Marshaler {
+	return marshalOptional(o, gql.MarshalFloat)
+}
+
+// UnmarshalOptionalFloat unmarshals a Float into a nullable.Optional[float64].
+func UnmarshalOptionalFloat(v any) (nullable.Optional[float64], error) {
+	return unmarshalOptional(v, gql.UnmarshalFloat)
+}
+
+// MarshalNullBoolean marshals a nullable.Null[bool] as a Boolean.
+func MarshalNullBoolean(n nullable.Null[bool]) gql.Marshaler {
+	return marshalNull(n, gql.MarshalBoolean)
+}
+
+// UnmarshalNullBoolean unmarshals a Boolean into a nullable.Null[bool].
+func UnmarshalNullBoolean(v any) (nullable.Null[bool], error) {
+	return unmarshalNull(v, gql.UnmarshalBoolean)
+}
+
+// MarshalOptionalBoolean marshals a nullable.Optional[bool] as a Boolean.
+func MarshalOptionalBoolean(o nullable.Optional[bool]) gql.Marshaler {
+	return marshalOptional(o, gql.MarshalBoolean)
+}
+
+// UnmarshalOptionalBoolean unmarshals a Boolean into a nullable.Optional[bool].
+func UnmarshalOptionalBoolean(v any) (nullable.Optional[bool], error) {
+	return unmarshalOptional(v, gql.UnmarshalBoolean)
+}
+
+// MarshalNullTime marshals a nullable.Null[time.Time] as an RFC 3339 Time.
+func MarshalNullTime(n nullable.Null[time.Time]) gql.Marshaler {
+	return marshalNull(n, gql.MarshalTime)
+}
+
+// UnmarshalNullTime unmarshals an RFC 3339 Time into a nullable.Null[time.Time].
+func UnmarshalNullTime(v any) (nullable.Null[time.Time], error) {
+	return unmarshalNull(v, gql.UnmarshalTime)
+}
+
+// MarshalOptionalTime marshals a nullable.Optional[time.Time] as an RFC 3339 Time.
+func MarshalOptionalTime(o nullable.Optional[time.Time]) gql.Marshaler {
+	return marshalOptional(o, gql.MarshalTime)
+}
+
+// UnmarshalOptionalTime unmarshals an RFC 3339 Time into a nullable.Optional[time.Time].
+func UnmarshalOptionalTime(v any) (nullable.Optional[time.Time], error) {
+	return unmarshalOptional(v, gql.UnmarshalTime)
+}
@@ -0,0 +1,352 @@
+// Package history keeps the successive snapshots of form drafts in a PostgreSQL table,
+// each stored as the RFC 7386 JSON merge patch from the previous one, so a wizard can
+// undo steps, restore any prior version and show what changed between two steps:
+//
+//	store := history.New(pool, "form_history")
+//	v, err := store.Save(ctx, draftID, form)
+//	...
+//	changes, err := store.Changes(ctx, draftID, 2, 3, &dtos.UinfinNamesForm{})
+//
+// Snapshots are the JSON encoding of drafts with undefined fields left out, including
+// those of nested DTOs and of the elements of slices of them, and versions are rebuilt
+// by applying their patches in order with patch.MergePatch. Undefined fields are thus
+// restored as undefined and explicit nulls as null, except for a field that goes back
+// to undefined after holding a value or null: it is stored as null, as merge patches
+// cannot tell a removed member apart from a cleared one.
+package history
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/patch"
+)
+
+// ErrNotFound reports a draft or version that is not in the store.
+var ErrNotFound = errors.New("history: version not found")
+
+// Conn is implemented by *pgx.Conn, *pgxpool.Pool and pgx.Tx.
+type Conn interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// Version is a stored snapshot of a draft.
+type Version struct {
+	Number    int             `db:"version" json:"version"`
+	Patch     json.RawMessage `db:"patch" json:"patch"`
+	CreatedAt time.Time       `db:"created_at" json:"created_at"`
+}
+
+// Store reads and writes the versions of drafts in a single table.
+type Store struct {
+	conn  Conn
+	table string
+}
+
+// New creates a Store keeping versions in table, which may be schema qualified.
+func New(conn Conn, table string) *Store {
+	return &Store{conn: conn, table: pgx.Identifier(strings.Split(table, ".")).Sanitize()}
+}
+
+// CreateTable creates the table of s unless it already exists.
+func (s *Store) CreateTable(ctx context.Context) error {
+	_, err := s.conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS `+s.table+` (
+	draft_id text NOT NULL,
+	version integer NOT NULL,
+	patch jsonb NOT NULL,
+	created_at timestamptz NOT NULL DEFAULT now(),
+	PRIMARY KEY (draft_id, version)
+)`)
+	if err != nil {
+		return fmt.Errorf("history: %w", err)
+	}
+	return nil
+}
+
+// Save stores dto, a struct or pointer to one, as the next version of draftID and returns
+// its number, starting at 1. If dto equals the latest version nothing is stored and the
+// number of the latest version is returned. Concurrent saves of the same draft fail on
+// the primary key instead of overwriting each other.
+func (s *Store) Save(ctx context.Context, draftID string, dto any) (int, error) {
+	t := reflect.TypeOf(dto)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return 0, fmt.Errorf("history: dto must be a struct, got %T", dto)
+	}
+	versions, err := s.Versions(ctx, draftID)
+	if err != nil {
+		return 0, err
+	}
+	latest := reflect.New(t)
+	if err := apply(latest.Interface(), versions); err != nil {
+		return 0, err
+	}
+	prev, err := snapshot(latest.Interface())
+	if err != nil {
+		return 0, err
+	}
+	next, err := snapshot(dto)
+	if err != nil {
+		return 0, err
+	}
+	p, changed, err := mergePatch(prev, next)
+	if err != nil {
+		return 0, err
+	}
+	if !changed && len(versions) > 0 {
+		return len(versions), nil
+	}
+	number := len(versions) + 1
+	_, err = s.conn.Exec(ctx, `INSERT INTO `+s.table+` (draft_id, version, patch) VALUES ($1, $2, $3)`,
+		draftID, number, string(p))
+	if err != nil {
+		return 0, fmt.Errorf("history: %w", err)
+	}
+	return number, nil
+}
+
+// Versions returns the versions of draftID in order, for replaying its changes.
+// A draft without versions has none, without error.
+func (s *Store) Versions(ctx context.Context, draftID string) ([]Version, error) {
+	rows, err := s.conn.Query(ctx, `SELECT version, patch, created_at FROM `+s.table+
+		` WHERE draft_id = $1 ORDER BY version`, draftID)
+	if err != nil {
+		return nil, fmt.Errorf("history: %w", err)
+	}
+	versions, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Version, error) {
+		var v Version
+		var p []byte
+		err := row.Scan(&v.Number, &p, &v.CreatedAt)
+		v.Patch = p
+		return v, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("history: %w", err)
+	}
+	return versions, nil
+}
+
+// Load rebuilds version number of draftID into dst, a non-nil pointer to a zero struct.
+func (s *Store) Load(ctx context.Context, draftID string, number int, dst any) error {
+	versions, err := s.Versions(ctx, draftID)
+	if err != nil {
+		return err
+	}
+	if number < 1 || number > len(versions) {
+		return fmt.Errorf("%w: %s version %d", ErrNotFound, draftID, number)
+	}
+	return apply(dst, versions[:number])
+}
+
+// Latest rebuilds the latest version of draftID into dst, a non-nil pointer to a zero
+// struct, and returns its number. It returns ErrNotFound if the draft has no versions.
+func (s *Store) Latest(ctx context.Context, draftID string, dst any) (int, error) {
+	versions, err := s.Versions(ctx, draftID)
+	if err != nil {
+		return 0, err
+	}
+	if len(versions) == 0 {
+		return 0, fmt.Errorf("%w: %s", ErrNotFound, draftID)
+	}
+	return len(versions), apply(dst, versions)
+}
+
+// Replay applies the versions of draftID in order onto dst, a non-nil pointer to a zero
+// struct, calling fn with each version after it has been applied. It stops at the first
+// error returned by fn.
+func (s *Store) Replay(ctx context.Context, draftID string, dst any, fn func(Version) error) error {
+	versions, err := s.Versions(ctx, draftID)
+	if err != nil {
+		return err
+	}
+	for _, v := range versions {
+		if err := apply(dst, []Version{v}); err != nil {
+			return err
+		}
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Changes reports what changed between versions from and to of draftID, rebuilding both
+// into new values of the type dto points to and comparing them with forms.Diff.
+func (s *Store) Changes(ctx context.Context, draftID string, from, to int, dto any) (forms.Changes, error) {
+	t := reflect.TypeOf(dto)
+	if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("history: dto must be a pointer to a struct, got %T", dto)
+	}
+	older, newer := reflect.New(t.Elem()).Interface(), reflect.New(t.Elem()).Interface()
+	if err := s.Load(ctx, draftID, from, older); err != nil {
+		return nil, err
+	}
+	if err := s.Load(ctx, draftID, to, newer); err != nil {
+		return nil, err
+	}
+	return forms.Diff(older, newer)
+}
+
+// Revert stores version number of draftID again as its latest version, undoing the
+// versions after it while keeping them in the history, and returns the new version number.
+// dto is a pointer to a struct of the draft's type, which receives the restored version.
+func (s *Store) Revert(ctx context.Context, draftID string, number int, dto any) (int, error) {
+	v := reflect.ValueOf(dto)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return 0, fmt.Errorf("history: dto must be a non-nil pointer to a struct, got %T", dto)
+	}
+	v.Elem().SetZero()
+	if err := s.Load(ctx, draftID, number, dto); err != nil {
+		return 0, err
+	}
+	return s.Save(ctx, draftID, dto)
+}
+
+// apply applies the patches of versions in order onto dst.
+func apply(dst any, versions []Version) error {
+	for _, v := range versions {
+		if err := patch.MergePatch(dst, v.Patch); err != nil {
+			return fmt.Errorf("history: version %d: %w", v.Number, err)
+		}
+	}
+	return nil
+}
+
+// snapshot returns the JSON tree of dto, a struct or pointer to one: objects as
+// map[string]any, arrays of nested DTOs as []any and other values as json.RawMessage.
+func snapshot(dto any) (map[string]any, error) {
+	v := reflect.Indirect(reflect.ValueOf(dto))
+	obj, err := snapshotStruct(v, "")
+	if err != nil {
+		return nil, fmt.Errorf("history: %w", err)
+	}
+	return obj, nil
+}
+
+func snapshotStruct(v reflect.Value, path string) (map[string]any, error) {
+	obj := make(map[string]any)
+	for _, f := range nullreflect.Fields(v.Type()) {
+		name, ok := f.JSONName()
+		if !ok {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		if nullreflect.IsUndefined(fv) {
+			continue
+		}
+		node, err := snapshotValue(fv, path+"/"+name)
+		if err != nil {
+			return nil, err
+		}
+		obj[name] = node
+	}
+	return obj, nil
+}
+
+func snapshotValue(fv reflect.Value, path string) (any, error) {
+	_, nestedSlice := nullreflect.NestedSlice(fv.Type())
+	switch {
+	case (nullreflect.IsNested(fv.Type()) || nestedSlice) && fv.Kind() != reflect.Struct && fv.IsNil():
+		return json.RawMessage("null"), nil
+	case nullreflect.IsNested(fv.Type()):
+		return snapshotStruct(reflect.Indirect(fv), path)
+	case nestedSlice:
+		elems := make([]any, fv.Len())
+		for i := range elems {
+			elem, err := snapshotValue(fv.Index(i), fmt.Sprintf("%s/%d", path, i))
+			if err != nil {
+				return nil, err
+			}
+			elems[i] = elem
+		}
+		return elems, nil
+	}
+	data, err := json.Marshal(fv.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return json.RawMessage(data), nil
+}
+
+// mergePatch returns the merge patch turning prev into next and whether there is any
+// change. Changed objects are patched member by member, nulling removed members, and
+// any other changed value, arrays included, is replaced wholesale.
+func mergePatch(prev, next map[string]any) ([]byte, bool, error) {
+	p, err := diffObjects(prev, next)
+	if err != nil {
+		return nil, false, fmt.Errorf("history: %w", err)
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, false, fmt.Errorf("history: %w", err)
+	}
+	return data, len(p) > 0, nil
+}
+
+func diffObjects(prev, next map[string]any) (map[string]any, error) {
+	p := make(map[string]any)
+	for name, n := range next {
+		old, ok := prev[name]
+		if !ok {
+			p[name] = n
+			continue
+		}
+		oldObj, oldIsObj := old.(map[string]any)
+		newObj, newIsObj := n.(map[string]any)
+		if oldIsObj && newIsObj {
+			sub, err := diffObjects(oldObj, newObj)
+			if err != nil {
+				return nil, err
+			}
+			if len(sub) > 0 {
+				p[name] = sub
+			}
+			continue
+		}
+		same, err := sameJSON(old, n)
+		if err != nil {
+			return nil, err
+		}
+		if !same {
+			p[name] = n
+		}
+	}
+	for name, old := range prev {
+		if _, ok := next[name]; ok {
+			continue
+		}
+		// A member gone undefined is restored as null; one that already is needs no patch.
+		if raw, ok := old.(json.RawMessage); ok && bytes.Equal(raw, []byte("null")) {
+			continue
+		}
+		p[name] = json.RawMessage("null")
+	}
+	return p, nil
+}
+
+// sameJSON reports whether two snapshot nodes have the same encoding.
+func sameJSON(a, b any) (bool, error) {
+	x, err := json.Marshal(a)
+	if err != nil {
+		return false, err
+	}
+	y, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(x, y), nil
+}
@@ -0,0 +1,327 @@
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// fakeConn is an in-memory history table answering the statements of Store.
+type fakeConn struct {
+	rows    map[string][]Version
+	execErr error
+	queries []string
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{rows: make(map[string][]Version)}
+}
+
+func (c *fakeConn) Exec(_ context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if c.execErr != nil {
+		return pgconn.CommandTag{}, c.execErr
+	}
+	switch {
+	case strings.HasPrefix(sql, "CREATE TABLE"):
+		return pgconn.NewCommandTag("CREATE TABLE"), nil
+	case strings.HasPrefix(sql, "INSERT INTO"):
+		id, number, p := args[0].(string), args[1].(int), args[2].(string)
+		if number != len(c.rows[id])+1 {
+			return pgconn.CommandTag{}, fmt.Errorf("duplicate key (draft_id, version)=(%s, %d)", id, number)
+		}
+		if !json.Valid([]byte(p)) {
+			return pgconn.CommandTag{}, fmt.Errorf("invalid jsonb %q", p)
+		}
+		c.rows[id] = append(c.rows[id], Version{Number: number, Patch: json.RawMessage(p), CreatedAt: time.Unix(int64(number), 0)})
+		return pgconn.NewCommandTag("INSERT 0 1"), nil
+	}
+	return pgconn.CommandTag{}, fmt.Errorf("unexpected statement %q", sql)
+}
+
+func (c *fakeConn) Query(_ context.Context, sql string, args ...any) (pgx.Rows, error) {
+	c.queries = append(c.queries, sql)
+	if !strings.HasPrefix(sql, "SELECT version, patch, created_at FROM") {
+		return nil, fmt.Errorf("unexpected query %q", sql)
+	}
+	return &fakeRows{versions: c.rows[args[0].(string)], i: -1}, nil
+}
+
+// fakeRows yields versions as pgx.Rows.
+type fakeRows struct {
+	versions []Version
+	i        int
+	closed   bool
+}
+
+func (r *fakeRows) Close()                                       { r.closed = true }
+func (r *fakeRows) Err() error                                   { return nil }
+func (r *fakeRows) CommandTag() pgconn.CommandTag                { return pgconn.NewCommandTag("SELECT") }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return nil }
+func (r *fakeRows) RawValues() [][]byte                          { return nil }
+func (r *fakeRows) Conn() *pgx.Conn                              { return nil }
+
+func (r *fakeRows) Next() bool {
+	if r.closed || r.i+1 >= len(r.versions) {
+		r.closed = true
+		return false
+	}
+	r.i++
+	return true
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	v := r.versions[r.i]
+	*dest[0].(*int) = v.Number
+	*dest[1].(*[]byte) = append([]byte(nil), v.Patch...)
+	*dest[2].(*time.Time) = v.CreatedAt
+	return nil
+}
+
+func (r *fakeRows) Values() ([]any, error) {
+	v := r.versions[r.i]
+	return []any{v.Number, []byte(v.Patch), v.CreatedAt}, nil
+}
+
+type testAddress struct {
+	Street nullable.Optional[string] `json:"street"`
+}
+
+type testDraft struct {
+	Name     nullable.Optional[string] `json:"name"`
+	Married  nullable.Optional[string] `json:"married"`
+	Age      nullable.Null[int32]      `json:"age"`
+	Current  *testAddress              `json:"current"`
+	Previous []testAddress             `json:"previous"`
+}
+
+var (
+	some    = nullable.OptionalFrom[string]
+	cleared = nullable.OptionalNull[string]()
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	steps := []struct {
+		name      string
+		draft     testDraft
+		want      *testDraft // as rebuilt, if different from draft
+		wantPatch string
+	}{
+		{
+			name:      "first",
+			draft:     testDraft{Name: some("Tan")},
+			wantPatch: `{"name":"Tan"}`,
+		},
+		{
+			name:      "explicit null",
+			draft:     testDraft{Name: some("Tan"), Married: cleared, Age: nullable.From[int32](30)},
+			wantPatch: `{"age":30,"married":null}`,
+		},
+		{
+			name:      "nested",
+			draft:     testDraft{Name: some("Tan"), Married: cleared, Age: nullable.From[int32](30), Current: &testAddress{Street: some("Orchard Rd")}, Previous: []testAddress{{}, {Street: cleared}}},
+			wantPatch: `{"current":{"street":"Orchard Rd"},"previous":[{},{"street":null}]}`,
+		},
+		{
+			name:      "back to undefined is stored as null",
+			draft:     testDraft{Age: nullable.From[int32](30), Current: &testAddress{}},
+			want:      &testDraft{Name: cleared, Married: cleared, Age: nullable.From[int32](30), Current: &testAddress{Street: cleared}},
+			wantPatch: `{"current":{"street":null},"name":null,"previous":null}`,
+		},
+	}
+	conn := newFakeConn()
+	s := New(conn, "form_history")
+	ctx := context.Background()
+	for i, step := range steps {
+		n, err := s.Save(ctx, "d1", &step.draft)
+		if err != nil {
+			t.Fatalf("%s: Save: %v", step.name, err)
+		}
+		if n != i+1 {
+			t.Errorf("%s: Save = %d, want %d", step.name, n, i+1)
+		}
+		if got := string(conn.rows["d1"][i].Patch); got != step.wantPatch {
+			t.Errorf("%s: patch = %s, want %s", step.name, got, step.wantPatch)
+		}
+	}
+	for i, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			var got testDraft
+			if err := s.Load(ctx, "d1", i+1, &got); err != nil {
+				t.Fatal(err)
+			}
+			want := step.draft
+			if step.want != nil {
+				want = *step.want
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("Load(%d) = %#v, want %#v", i+1, got, want)
+			}
+		})
+	}
+}
+
+func TestSaveMapDropsRemovedKeys(t *testing.T) {
+	s := New(newFakeConn(), "form_history")
+	ctx := context.Background()
+	type item struct {
+		Attrs map[string]string `json:"attrs"`
+	}
+	drafts := []item{
+		{Attrs: map[string]string{"color": "red"}},
+		{Attrs: map[string]string{"size": "L"}},
+		{Attrs: map[string]string{}},
+	}
+	for i, d := range drafts {
+		if _, err := s.Save(ctx, "d1", d); err != nil {
+			t.Fatal(err)
+		}
+		var got item
+		if n, err := s.Latest(ctx, "d1", &got); err != nil || n != i+1 || !reflect.DeepEqual(got, d) {
+			t.Errorf("Latest = %d, %#v, %v, want %d, %#v", n, got, err, i+1, d)
+		}
+	}
+}
+
+func TestSaveUnchanged(t *testing.T) {
+	s := New(newFakeConn(), "form_history")
+	ctx := context.Background()
+	d := testDraft{Name: some("Tan")}
+	for range 2 {
+		if n, err := s.Save(ctx, "d1", d); err != nil || n != 1 {
+			t.Fatalf("Save = %d, %v, want 1", n, err)
+		}
+	}
+	// An empty first draft is still stored, so the draft exists.
+	if n, err := s.Save(ctx, "d2", testDraft{}); err != nil || n != 1 {
+		t.Errorf("Save of an empty draft = %d, %v, want 1", n, err)
+	}
+}
+
+func TestLatestReplayChangesRevert(t *testing.T) {
+	conn := newFakeConn()
+	s := New(conn, "public.form_history")
+	ctx := context.Background()
+	drafts := []testDraft{
+		{Name: some("Tan")},
+		{Name: some("Tan"), Married: some("Lee")},
+		{Name: some("Lim"), Married: cleared},
+	}
+	for _, d := range drafts {
+		if _, err := s.Save(ctx, "d1", d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !strings.Contains(conn.queries[0], `"public"."form_history"`) {
+		t.Errorf("query %q, want the table sanitized", conn.queries[0])
+	}
+
+	var latest testDraft
+	if n, err := s.Latest(ctx, "d1", &latest); err != nil || n != 3 || !reflect.DeepEqual(latest, drafts[2]) {
+		t.Errorf("Latest = %d, %#v, %v, want 3, %#v", n, latest, err, drafts[2])
+	}
+
+	var replayed testDraft
+	var seen []int
+	err := s.Replay(ctx, "d1", &replayed, func(v Version) error {
+		seen = append(seen, v.Number)
+		if !reflect.DeepEqual(replayed, drafts[v.Number-1]) {
+			t.Errorf("Replay at %d = %#v, want %#v", v.Number, replayed, drafts[v.Number-1])
+		}
+		return nil
+	})
+	if err != nil || !reflect.DeepEqual(seen, []int{1, 2, 3}) {
+		t.Errorf("Replay visited %v, %v, want [1 2 3]", seen, err)
+	}
+	stop := errors.New("stop")
+	if err := s.Replay(ctx, "d1", &testDraft{}, func(Version) error { return stop }); !errors.Is(err, stop) {
+		t.Errorf("Replay = %v, want the error of fn", err)
+	}
+
+	changes, err := s.Changes(ctx, "d1", 1, 3, &testDraft{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Married is undefined in version 1, so forms.Diff ignores it.
+	want := forms.Changes{"Name": {Old: "Tan", New: "Lim"}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Changes(1, 3) = %#v, want %#v", changes, want)
+	}
+
+	restored := testDraft{Age: nullable.From[int32](99)}
+	n, err := s.Revert(ctx, "d1", 2, &restored)
+	if err != nil || n != 4 {
+		t.Fatalf("Revert = %d, %v, want 4", n, err)
+	}
+	if !reflect.DeepEqual(restored, drafts[1]) {
+		t.Errorf("Revert restored %#v, want %#v", restored, drafts[1])
+	}
+	if err := s.Load(ctx, "d1", 4, &latest); err != nil || !reflect.DeepEqual(latest, drafts[1]) {
+		t.Errorf("Load(4) = %#v, %v, want %#v", latest, err, drafts[1])
+	}
+}
+
+func TestErrors(t *testing.T) {
+	conn := newFakeConn()
+	s := New(conn, "form_history")
+	ctx := context.Background()
+	if _, err := s.Save(ctx, "d1", testDraft{Name: some("Tan")}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		call    func() error
+		wantNF  bool
+		wantMsg string
+	}{
+		{name: "load version 0", call: func() error { return s.Load(ctx, "d1", 0, &testDraft{}) }, wantNF: true},
+		{name: "load past the latest", call: func() error { return s.Load(ctx, "d1", 2, &testDraft{}) }, wantNF: true},
+		{name: "latest of an unknown draft", call: func() error { _, err := s.Latest(ctx, "nope", &testDraft{}); return err }, wantNF: true},
+		{name: "save a non-struct", call: func() error { _, err := s.Save(ctx, "d1", 1); return err }, wantMsg: "history: dto must be a struct"},
+		{name: "changes into a value", call: func() error { _, err := s.Changes(ctx, "d1", 1, 1, testDraft{}); return err }, wantMsg: "history: dto must be a pointer"},
+		{name: "revert into nil", call: func() error { _, err := s.Revert(ctx, "d1", 1, (*testDraft)(nil)); return err }, wantMsg: "history: dto must be a non-nil pointer"},
+		{
+			name: "exec failure",
+			call: func() error {
+				conn.execErr = errors.New("connection reset")
+				defer func() { conn.execErr = nil }()
+				_, err := s.Save(ctx, "d1", testDraft{Name: some("Lim")})
+				return err
+			},
+			wantMsg: "history: connection reset",
+		},
+		{
+			name: "create table failure",
+			call: func() error {
+				conn.execErr = errors.New("denied")
+				defer func() { conn.execErr = nil }()
+				return s.CreateTable(ctx)
+			},
+			wantMsg: "history: denied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if tt.wantNF && !errors.Is(err, ErrNotFound) {
+				t.Errorf("err = %v, want ErrNotFound", err)
+			}
+			if tt.wantMsg != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.wantMsg)) {
+				t.Errorf("err = %v, want %q", err, tt.wantMsg)
+			}
+		})
+	}
+	if err := s.CreateTable(ctx); err != nil {
+		t.Errorf("CreateTable: %v", err)
+	}
+}
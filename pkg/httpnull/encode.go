@@ -0,0 +1,59 @@
+package httpnull
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// NullPolicy says how null fields are encoded.
+type NullPolicy int
+
+const (
+	// EmitNull encodes null fields as "field": null, like encoding/json does.
+	EmitNull NullPolicy = iota
+	// Omit drops null fields from objects.
+	Omit
+)
+
+// EncodePolicy configures Encode and Marshal, so API versions can differ in how
+// they render nulls while sharing the DTOs and their struct tags.
+type EncodePolicy struct {
+	NullAs NullPolicy
+}
+
+// Marshal returns the JSON encoding of dto according to p.
+//
+// dto is encoded like encoding/json does, honouring its struct tags. Undefined top-level
+// fields, such as unset nullable.Optional, are left out under either policy, and
+// with Omit every object member that encoded to null is dropped too, including those of
+// nested objects. Array elements are kept as they are.
+//
+// Top-level fields tagged with `nulljson`, as described by nulljson.Marshal, encode
+// null the way their tag says regardless of p, even when undefined.
+func Marshal(dto any, p EncodePolicy) ([]byte, error) {
+	return EncodeAppend(nil, dto, p)
+}
+
+// EncodeAppend appends the encoding of dto according to p, as Marshal returns it, to dst
+// and returns the extended buffer. Batch exports encoding one record at a time can reuse
+// dst across records instead of allocating the output of each.
+func EncodeAppend(dst []byte, dto any, p EncodePolicy) ([]byte, error) {
+	out, err := nullreflect.AppendJSON(dst, dto, nullreflect.JSONPolicy{OmitUndefined: true, OmitNull: p.NullAs == Omit})
+	if err != nil {
+		return dst, fmt.Errorf("httpnull: %w", err)
+	}
+	return out, nil
+}
+
+// Encode writes dto to w as an application/json response encoded according to p.
+func Encode(w http.ResponseWriter, dto any, p EncodePolicy) error {
+	data, err := Marshal(dto, p)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
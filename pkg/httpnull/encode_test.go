@@ -0,0 +1,102 @@
+package httpnull
+
+import (
+	"math"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type profile struct {
+	Name     nullable.Optional[string] `json:"name"`
+	Married  nullable.Optional[string] `json:"married_name"`
+	Nickname nullable.Optional[string] `json:"nickname" nulljson:"emit"`
+	Age      nullable.Null[int32]      `json:"age"`
+	Address  *address                  `json:"address"`
+	Tags     []nullable.Null[string]   `json:"tags"`
+}
+
+type address struct {
+	Street nullable.Null[string] `json:"street"`
+	Unit   nullable.Null[string] `json:"unit"`
+}
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name   string
+		dto    any
+		policy EncodePolicy
+		want   string
+	}{
+		{
+			name: "emit null",
+			dto:  profile{Name: nullable.OptionalFrom("Tan"), Married: nullable.OptionalNull[string]()},
+			want: `{"name":"Tan","married_name":null,"nickname":null,"age":null,"address":null,"tags":null}`,
+		},
+		{
+			name:   "omit",
+			dto:    profile{Name: nullable.OptionalFrom("Tan"), Married: nullable.OptionalNull[string]()},
+			policy: EncodePolicy{NullAs: Omit},
+			want:   `{"name":"Tan","nickname":null}`,
+		},
+		{
+			name: "omit drops nested nulls but keeps array elements",
+			dto: &profile{
+				Age:     nullable.From[int32](30),
+				Address: &address{Street: nullable.From("Orchard Rd")},
+				Tags:    []nullable.Null[string]{nullable.From("a"), {}},
+			},
+			policy: EncodePolicy{NullAs: Omit},
+			want:   `{"nickname":null,"age":30,"address":{"street":"Orchard Rd"},"tags":["a",null]}`,
+		},
+		{
+			name: "emit null keeps nested nulls",
+			dto:  profile{Address: &address{}},
+			want: `{"nickname":null,"age":null,"address":{"street":null,"unit":null},"tags":null}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.dto, tt.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal = %s, want %s", got, tt.want)
+			}
+			appended, err := EncodeAppend([]byte("x"), tt.dto, tt.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(appended) != "x"+tt.want {
+				t.Errorf("EncodeAppend = %s, want x%s", appended, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalError(t *testing.T) {
+	dst := []byte("kept")
+	got, err := EncodeAppend(dst, struct{ F nullable.Null[float64] }{nullable.From(math.NaN())}, EncodePolicy{})
+	if err == nil || !strings.HasPrefix(err.Error(), "httpnull: ") {
+		t.Errorf("EncodeAppend = %v, want a httpnull error", err)
+	}
+	if string(got) != "kept" {
+		t.Errorf("EncodeAppend returned %q, want dst unchanged", got)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	w := httptest.NewRecorder()
+	if err := Encode(w, profile{Name: nullable.OptionalFrom("Tan")}, EncodePolicy{NullAs: Omit}); err != nil {
+		t.Fatal(err)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	if want := `{"name":"Tan","nickname":null}` + "\n"; w.Body.String() != want {
+		t.Errorf("body = %q, want %q", w.Body.String(), want)
+	}
+}
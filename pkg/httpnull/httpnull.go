@@ -0,0 +1,264 @@
+// Package httpnull decodes net/http request bodies into nullable DTOs, negotiating
+// JSON, form-encoded and multipart bodies with the same tri-state semantics:
+// absent keys are undefined, null (or an empty form value) is null and everything
+// else is parsed into the field's type. Failures are reported as forms.FieldErrors
+// whichever encoding the client used. In the other direction, Encode writes responses
+// with a per API version policy for null fields.
+//
+//	mux.Handle("POST /applicants", httpnull.Middleware[dtos.UinfinNamesForm]()(http.HandlerFunc(create)))
+//
+//	func create(w http.ResponseWriter, r *http.Request) {
+//		form, _ := httpnull.FromContext[dtos.UinfinNamesForm](r.Context())
+//		// ...
+//	}
+package httpnull
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/bind"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/observe"
+)
+
+// ErrUnsupportedMediaType is returned by DecodeBody for bodies it cannot decode.
+var ErrUnsupportedMediaType = errors.New("httpnull: unsupported media type")
+
+type options struct {
+	bind      []bind.Option
+	maxMemory int64
+	maxBytes  int64
+}
+
+// Option configures decoding.
+type Option func(*options)
+
+// BindOptions passes opts to bind.Form and bind.Multipart.
+func BindOptions(opts ...bind.Option) Option {
+	return func(o *options) {
+		o.bind = append(o.bind, opts...)
+	}
+}
+
+// MaxMemory is passed to http.Request.ParseMultipartForm; the default is 32 MiB.
+func MaxMemory(n int64) Option {
+	return func(o *options) {
+		o.maxMemory = n
+	}
+}
+
+// MaxBytes limits the size of the body with http.MaxBytesReader. Zero, the default, means no limit.
+func MaxBytes(n int64) Option {
+	return func(o *options) {
+		o.maxBytes = n
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{maxMemory: 32 << 20}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// DecodeBody decodes the body of r into dst, a non-nil pointer to a struct, according
+// to its Content-Type:
+//
+//   - application/json and +json types are decoded with encoding/json; an empty body is
+//     decoded like an empty object.
+//   - application/x-www-form-urlencoded bodies are bound with bind.Form.
+//   - multipart/form-data bodies are bound with bind.Multipart.
+//
+// Every media type treats absent keys alike: nullable.Optional fields become undefined
+// and fields that cannot be undefined keep the value dst held. Only the body is decoded,
+// never the query string. Values that cannot be decoded are reported as
+// forms.FieldErrors keyed by JSON name or form key; other media types return
+// ErrUnsupportedMediaType.
+func DecodeBody(r *http.Request, dst any, opts ...Option) error {
+	o := newOptions(opts)
+	if v := reflect.ValueOf(dst); v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("httpnull: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	if o.maxBytes > 0 {
+		r.Body = http.MaxBytesReader(nil, r.Body, o.maxBytes)
+	}
+
+	ctype := r.Header.Get("Content-Type")
+	if ctype == "" {
+		ctype = "application/octet-stream"
+	}
+	mediaType, _, err := mime.ParseMediaType(ctype)
+	if err != nil {
+		return fmt.Errorf("%w %q", ErrUnsupportedMediaType, ctype)
+	}
+	switch {
+	case mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		err := decodeJSON(r.Body, dst)
+		observe.Decoded(reflect.ValueOf(dst).Elem(), "json", err)
+		return err
+	case mediaType == "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return fmt.Errorf("httpnull: %w", err)
+		}
+		return bind.Form(r.PostForm, dst, o.bind...)
+	case mediaType == "multipart/form-data":
+		if err := r.ParseMultipartForm(o.maxMemory); err != nil {
+			return fmt.Errorf("httpnull: %w", err)
+		}
+		return bind.Multipart(r.MultipartForm, dst, o.bind...)
+	}
+	return fmt.Errorf("%w %q", ErrUnsupportedMediaType, mediaType)
+}
+
+// decodeJSON unmarshals body into dst. When decoding fails it decodes each field
+// on its own to report which ones are invalid.
+func decodeJSON(body io.Reader, dst any) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return fmt.Errorf("httpnull: %w", err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		data = []byte("{}")
+	}
+	err = json.Unmarshal(data, dst)
+	if err == nil {
+		resetAbsent(data, reflect.ValueOf(dst).Elem())
+		return nil
+	}
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Errorf("httpnull: %w", err)
+	}
+	if fe := jsonFieldErrors(data, reflect.TypeOf(dst).Elem()); len(fe) > 0 {
+		return fe
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		var fe forms.FieldErrors
+		fe.Add(typeErr.Field, "invalid value")
+		return fe
+	}
+	return fmt.Errorf("httpnull: %w", err)
+}
+
+// resetAbsent makes the fields of v that can be undefined and have no member in the JSON
+// object data undefined, as bind does for absent form keys; encoding/json leaves them as
+// they were.
+func resetAbsent(data []byte, v reflect.Value) {
+	var members map[string]json.RawMessage
+	if json.Unmarshal(data, &members) != nil {
+		return
+	}
+	for _, f := range nullreflect.Fields(v.Type()) {
+		name, ok := f.JSONName()
+		if !ok || !nullreflect.CanBeUndefined(f.Type) {
+			continue
+		}
+		if _, ok := lookupMember(members, name); !ok {
+			v.FieldByIndex(f.Index).SetZero()
+		}
+	}
+}
+
+// lookupMember finds the member name of members the way encoding/json does,
+// preferring an exact match and falling back to case-insensitive matching.
+func lookupMember(members map[string]json.RawMessage, name string) (json.RawMessage, bool) {
+	if raw, ok := members[name]; ok {
+		return raw, true
+	}
+	for key, raw := range members {
+		if strings.EqualFold(key, name) {
+			return raw, true
+		}
+	}
+	return nil, false
+}
+
+// jsonFieldErrors decodes the members of the JSON object data into fresh values of
+// the matching fields of t, matching keys like encoding/json does.
+func jsonFieldErrors(data []byte, t reflect.Type) forms.FieldErrors {
+	var members map[string]json.RawMessage
+	if json.Unmarshal(data, &members) != nil {
+		return nil
+	}
+	var fe forms.FieldErrors
+	for _, f := range nullreflect.Fields(t) {
+		name, ok := f.JSONName()
+		if !ok {
+			continue
+		}
+		raw, ok := lookupMember(members, name)
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(raw, reflect.New(f.Type).Interface()); err != nil {
+			fe.Add(name, jsonMessage(raw, f.Type))
+		}
+	}
+	return fe
+}
+
+// jsonMessage describes the failure to decode raw into a value of type t,
+// such as cannot parse "abc" as int32.
+func jsonMessage(raw json.RawMessage, t reflect.Type) string {
+	var buf bytes.Buffer
+	if json.Compact(&buf, raw) == nil {
+		raw = buf.Bytes()
+	}
+	return fmt.Sprintf("cannot parse %s as %s", raw, nullreflect.TypeName(t))
+}
+
+type contextKey struct{ t reflect.Type }
+
+// FromContext returns the DTO decoded by Middleware[T].
+func FromContext[T any](ctx context.Context) (*T, bool) {
+	dto, ok := ctx.Value(contextKey{reflect.TypeFor[T]()}).(*T)
+	return dto, ok
+}
+
+// Middleware decodes every request body into a new T with DecodeBody and stores it in
+// the request context for FromContext. Requests that fail to decode are answered with
+// 400 Bad Request and the forms.FieldErrors JSON, 413 Request Entity Too Large or
+// 415 Unsupported Media Type, without calling the next handler.
+func Middleware[T any](opts ...Option) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			dto := new(T)
+			if err := DecodeBody(r, dto, opts...); err != nil {
+				writeError(w, err)
+				return
+			}
+			ctx := context.WithValue(r.Context(), contextKey{reflect.TypeFor[T]()}, dto)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// writeError answers a request that DecodeBody failed to decode.
+func writeError(w http.ResponseWriter, err error) {
+	var fe forms.FieldErrors
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &fe):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(fe)
+	case errors.As(err, &tooLarge):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, ErrUnsupportedMediaType):
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,296 @@
+package httpnull
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type applicant struct {
+	Name    nullable.Optional[string] `json:"name" form:"name"`
+	Married nullable.Optional[string] `json:"married_name" form:"married_name"`
+	Age     nullable.Optional[int32]  `json:"age" form:"age"`
+	Email   nullable.Null[string]     `json:"email" form:"email"`
+}
+
+// request builds a POST request of the given media type carrying the members of body:
+// JSON values for JSON bodies and raw text, "" standing for null, for the others.
+func request(t *testing.T, mediaType string, body map[string]string) *http.Request {
+	t.Helper()
+	var buf bytes.Buffer
+	ctype := mediaType
+	switch base, _, _ := strings.Cut(mediaType, ";"); base {
+	case "application/json":
+		buf.WriteByte('{')
+		first := true
+		for k, v := range body {
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+			buf.WriteString(`"` + k + `":` + v)
+		}
+		buf.WriteByte('}')
+	case "application/x-www-form-urlencoded":
+		values := url.Values{}
+		for k, v := range body {
+			values.Set(k, v)
+		}
+		buf.WriteString(values.Encode())
+	case "multipart/form-data":
+		mw := multipart.NewWriter(&buf)
+		for k, v := range body {
+			if err := mw.WriteField(k, v); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := mw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		ctype = mw.FormDataContentType()
+	}
+	r := httptest.NewRequest(http.MethodPost, "/applicants", &buf)
+	r.Header.Set("Content-Type", ctype)
+	return r
+}
+
+// serve runs r through Middleware[applicant] and returns the response and the decoded DTO,
+// nil when the next handler was not called.
+func serve(r *http.Request, opts ...Option) (*httptest.ResponseRecorder, *applicant) {
+	var got *applicant
+	h := Middleware[applicant](opts...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = FromContext[applicant](r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w, got
+}
+
+func TestMiddlewareStates(t *testing.T) {
+	tests := []struct {
+		name      string
+		mediaType string
+		body      map[string]string
+	}{
+		{
+			name:      "json",
+			mediaType: "application/json",
+			body:      map[string]string{"name": `"Tan"`, "married_name": "null", "age": "30", "email": "null"},
+		},
+		{
+			name:      "json with parameters and case-insensitive keys",
+			mediaType: "application/json; charset=utf-8",
+			body:      map[string]string{"NAME": `"Tan"`, "Married_Name": "null", "age": "30", "email": "null"},
+		},
+		{
+			name:      "form",
+			mediaType: "application/x-www-form-urlencoded",
+			body:      map[string]string{"name": "Tan", "married_name": "", "age": "30", "email": ""},
+		},
+		{
+			name:      "multipart",
+			mediaType: "multipart/form-data",
+			body:      map[string]string{"name": "Tan", "married_name": "", "age": "30", "email": ""},
+		},
+	}
+	// Every body sets name and age and clears married_name and email.
+	want := &applicant{
+		Name:    nullable.OptionalFrom("Tan"),
+		Married: nullable.OptionalNull[string](),
+		Age:     nullable.OptionalFrom[int32](30),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, got := serve(request(t, tt.mediaType, tt.body))
+			if w.Code != http.StatusNoContent {
+				t.Fatalf("status = %d %s, want 204", w.Code, w.Body)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("decoded %#v, want %#v", got, want)
+			}
+		})
+		t.Run(tt.name+" absent", func(t *testing.T) {
+			w, got := serve(request(t, tt.mediaType, map[string]string{}))
+			if w.Code != http.StatusNoContent {
+				t.Fatalf("status = %d %s, want 204", w.Code, w.Body)
+			}
+			if !reflect.DeepEqual(got, &applicant{}) {
+				t.Errorf("decoded %#v, want every field undefined or null", got)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyResetsAbsentFields(t *testing.T) {
+	for _, mediaType := range []string{"application/json", "application/x-www-form-urlencoded", "multipart/form-data"} {
+		t.Run(mediaType, func(t *testing.T) {
+			dst := applicant{
+				Name:  nullable.OptionalFrom("stale"),
+				Age:   nullable.OptionalFrom[int32](1),
+				Email: nullable.From("kept@example.com"),
+			}
+			if err := DecodeBody(request(t, mediaType, map[string]string{}), &dst); err != nil {
+				t.Fatal(err)
+			}
+			want := applicant{Email: nullable.From("kept@example.com")}
+			if !reflect.DeepEqual(dst, want) {
+				t.Errorf("decoded %#v, want %#v", dst, want)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyEmptyJSON(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("  \n"))
+	r.Header.Set("Content-Type", "application/problem+json")
+	var dst applicant
+	if err := DecodeBody(r, &dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst != (applicant{}) {
+		t.Errorf("decoded %#v, want the zero value", dst)
+	}
+}
+
+func TestMiddlewareErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		r          func(t *testing.T) *http.Request
+		opts       []Option
+		wantStatus int
+		wantFields forms.FieldErrors
+	}{
+		{
+			name: "json field errors",
+			r: func(t *testing.T) *http.Request {
+				return request(t, "application/json", map[string]string{"name": "1", "age": `"abc"`, "email": `"ok"`})
+			},
+			wantStatus: http.StatusBadRequest,
+			wantFields: forms.FieldErrors{
+				"age":  {`cannot parse "abc" as int32`},
+				"name": {"cannot parse 1 as string"},
+			},
+		},
+		{
+			name: "form field errors",
+			r: func(t *testing.T) *http.Request {
+				return request(t, "application/x-www-form-urlencoded", map[string]string{"age": "abc"})
+			},
+			wantStatus: http.StatusBadRequest,
+			wantFields: forms.FieldErrors{"age": {`cannot parse "abc" as int32`}},
+		},
+		{
+			name: "multipart field errors",
+			r: func(t *testing.T) *http.Request {
+				return request(t, "multipart/form-data", map[string]string{"age": "1e10"})
+			},
+			wantStatus: http.StatusBadRequest,
+			wantFields: forms.FieldErrors{"age": {`cannot parse "1e10" as int32`}},
+		},
+		{
+			name: "json syntax error",
+			r: func(t *testing.T) *http.Request {
+				r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":`))
+				r.Header.Set("Content-Type", "application/json")
+				return r
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "too large",
+			r: func(t *testing.T) *http.Request {
+				return request(t, "application/json", map[string]string{"name": `"` + strings.Repeat("x", 64) + `"`})
+			},
+			opts:       []Option{MaxBytes(32)},
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "too large form",
+			r: func(t *testing.T) *http.Request {
+				return request(t, "application/x-www-form-urlencoded", map[string]string{"name": strings.Repeat("x", 64)})
+			},
+			opts:       []Option{MaxBytes(32)},
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name: "unsupported media type",
+			r: func(t *testing.T) *http.Request {
+				r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=Tan"))
+				r.Header.Set("Content-Type", "text/plain")
+				return r
+			},
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name: "missing content type",
+			r: func(t *testing.T) *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+			},
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name: "malformed content type",
+			r: func(t *testing.T) *http.Request {
+				r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+				r.Header.Set("Content-Type", "application/json; =")
+				return r
+			},
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, got := serve(tt.r(t), tt.opts...)
+			if got != nil {
+				t.Error("the next handler was called")
+			}
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d %s, want %d", w.Code, w.Body, tt.wantStatus)
+			}
+			if tt.wantFields == nil {
+				return
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+			var fe forms.FieldErrors
+			if err := json.Unmarshal(w.Body.Bytes(), &fe); err != nil {
+				t.Fatalf("body %s: %v", w.Body, err)
+			}
+			if !reflect.DeepEqual(fe, tt.wantFields) {
+				t.Errorf("errors = %v, want %v", fe, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestDecodeBodyErrors(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("<a/>"))
+	r.Header.Set("Content-Type", "application/xml")
+	if err := DecodeBody(r, &applicant{}); !errors.Is(err, ErrUnsupportedMediaType) {
+		t.Errorf("DecodeBody = %v, want ErrUnsupportedMediaType", err)
+	}
+	r = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("{}"))
+	r.Header.Set("Content-Type", "application/json")
+	if err := DecodeBody(r, applicant{}); err == nil || !strings.HasPrefix(err.Error(), "httpnull: ") {
+		t.Errorf("DecodeBody into a struct value = %v, want a httpnull error", err)
+	}
+}
+
+func TestFromContextWithoutMiddleware(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if dto, ok := FromContext[applicant](r.Context()); ok || dto != nil {
+		t.Errorf("FromContext = %v, %v, want nil, false", dto, ok)
+	}
+}
@@ -0,0 +1,31 @@
+// Package bufpool pools the scratch buffers of the encoders, so batch exports
+// encoding millions of rows reuse memory instead of allocating it per row.
+package bufpool
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxSize is the capacity above which buffers are dropped instead of pooled, so a
+// single huge row does not pin its memory for the lifetime of the process.
+const maxSize = 64 << 10
+
+var pool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+// Get returns an empty buffer from the pool.
+func Get() *bytes.Buffer {
+	return pool.Get().(*bytes.Buffer)
+}
+
+// Put resets buf and returns it to the pool. buf must not be used afterwards,
+// including slices of its contents.
+func Put(buf *bytes.Buffer) {
+	if buf.Cap() > maxSize {
+		return
+	}
+	buf.Reset()
+	pool.Put(buf)
+}
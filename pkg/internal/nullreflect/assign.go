@@ -0,0 +1,143 @@
+package nullreflect
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Assign stores src into the plain value v, converting between
+// strings, byte slices, numbers and booleans when needed.
+func Assign(v reflect.Value, src any) error {
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(v.Type()) {
+		v.Set(sv)
+		return nil
+	}
+	switch s := src.(type) {
+	case string:
+		return assignString(v, s)
+	case []byte:
+		return assignString(v, string(s))
+	case time.Time:
+		if v.Kind() == reflect.String {
+			v.SetString(s.Format(time.RFC3339Nano))
+			return nil
+		}
+	}
+	switch v.Kind() {
+	case reflect.String:
+		switch sv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v.SetString(strconv.FormatInt(sv.Int(), 10))
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v.SetString(strconv.FormatUint(sv.Uint(), 10))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			v.SetString(strconv.FormatFloat(sv.Float(), 'g', -1, sv.Type().Bits()))
+			return nil
+		case reflect.Bool:
+			v.SetString(strconv.FormatBool(sv.Bool()))
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch sv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v.OverflowInt(sv.Int()) {
+				return fmt.Errorf("value %d overflows %s", sv.Int(), v.Type())
+			}
+			v.SetInt(sv.Int())
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if sv.Uint() > 1<<63-1 || v.OverflowInt(int64(sv.Uint())) {
+				return fmt.Errorf("value %d overflows %s", sv.Uint(), v.Type())
+			}
+			v.SetInt(int64(sv.Uint()))
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		switch sv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if sv.Int() < 0 || v.OverflowUint(uint64(sv.Int())) {
+				return fmt.Errorf("value %d overflows %s", sv.Int(), v.Type())
+			}
+			v.SetUint(uint64(sv.Int()))
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if v.OverflowUint(sv.Uint()) {
+				return fmt.Errorf("value %d overflows %s", sv.Uint(), v.Type())
+			}
+			v.SetUint(sv.Uint())
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		switch sv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v.SetFloat(float64(sv.Int()))
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v.SetFloat(float64(sv.Uint()))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			v.SetFloat(sv.Float())
+			return nil
+		}
+	}
+	if sv.Type().ConvertibleTo(v.Type()) && sv.Kind() == v.Kind() {
+		v.Set(sv.Convert(v.Type()))
+		return nil
+	}
+	return fmt.Errorf("cannot assign %T to %s", src, v.Type())
+}
+
+func assignString(v reflect.Value, s string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte(s))
+			return nil
+		}
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return fmt.Errorf("cannot parse %q as %s", s, v.Type())
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot parse %q as %s", s, v.Type())
+		}
+		v.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot parse %q as %s", s, v.Type())
+		}
+		v.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("cannot parse %q as %s", s, v.Type())
+		}
+		v.SetFloat(f)
+		return nil
+	}
+	if v.Type() == timeType {
+		t, err := ParseTime(s)
+		if err != nil {
+			return fmt.Errorf("cannot parse %q as %s", s, v.Type())
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	return fmt.Errorf("cannot assign string to %s", v.Type())
+}
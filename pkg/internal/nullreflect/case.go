@@ -0,0 +1,31 @@
+package nullreflect
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SnakeCase converts a Go identifier such as OrgID, HanyupinName or AddressLine1
+// into org_id, hanyupin_name or address_line_1. It only splits words, so fields named
+// differently from their column, such as HanyupinName for hanyupinyin_name, need a
+// `db` tag.
+func SnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])
+			if prevLower || nextLower {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		if unicode.IsDigit(r) && i > 0 && unicode.IsLetter(runes[i-1]) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
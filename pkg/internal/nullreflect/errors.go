@@ -0,0 +1,61 @@
+package nullreflect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ConvError reports a value that could not be stored into a field, naming the
+// source value and the target type instead of the reflection or driver details
+// of the failure, which are kept as its cause.
+type ConvError struct {
+	Value any
+	Type  reflect.Type
+	Err   error
+}
+
+func (e *ConvError) Error() string {
+	target := TypeName(e.Type)
+	switch v := e.Value.(type) {
+	case string:
+		return fmt.Sprintf("cannot parse %q as %s", v, target)
+	case []byte:
+		return fmt.Sprintf("cannot parse %q as %s", v, target)
+	}
+	return fmt.Sprintf("cannot convert %v (%T) to %s", e.Value, e.Value, target)
+}
+
+func (e *ConvError) Unwrap() error {
+	return e.Err
+}
+
+// convError wraps err, the failure to store val into a value of type t, into a ConvError,
+// keeping errors that already are one.
+func convError(val any, t reflect.Type, err error) error {
+	if err == nil {
+		return nil
+	}
+	var ce *ConvError
+	if errors.As(err, &ce) {
+		return err
+	}
+	return &ConvError{Value: val, Type: t, Err: err}
+}
+
+// TypeName names the type of the value held by nullable type t, such as int32 for
+// nullable.Int32, pgtype.Int4 or *int32, for messages addressed to users.
+// Types validating their own values, such as nullable.Uinfin or pgtype.UUID,
+// are named themselves.
+func TypeName(t reflect.Type) string {
+	if t.PkgPath() == nullablePkgPath && !strings.Contains(t.Name(), "[") &&
+		(t.NumField() != 1 || !t.Field(0).Anonymous) {
+		return t.String()
+	}
+	inner := valueType(t)
+	if inner.Kind() == reflect.Array {
+		return t.String()
+	}
+	return inner.String()
+}
@@ -0,0 +1,105 @@
+package nullreflect
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+var (
+	valuerType          = reflect.TypeFor[driver.Valuer]()
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// IsNested reports whether t is a nested DTO, a struct or pointer to one that is not
+// itself a value: it is neither a Valuer, a Scanner, nor does it decode text or JSON
+// on its own, as nullable types, pgtype types and time.Time all do.
+func IsNested(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	pt := reflect.PointerTo(t)
+	for _, it := range []reflect.Type{valuerType, scannerType, setterType, textUnmarshalerType, jsonUnmarshalerType} {
+		if t.Implements(it) || pt.Implements(it) {
+			return false
+		}
+	}
+	return true
+}
+
+// NestedSlice returns the element type of t if it is a slice of nested DTOs, such as
+// []AddressForm or []*AddressForm.
+func NestedSlice(t reflect.Type) (reflect.Type, bool) {
+	if t.Kind() != reflect.Slice || !IsNested(t.Elem()) {
+		return nil, false
+	}
+	return t.Elem(), true
+}
+
+// KeyField returns the field of nested DTO type t tagged `forms:"key"`, which identifies
+// its elements in slices of it, such as the ID of a previous address.
+func KeyField(t reflect.Type) (Field, bool) {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	for _, f := range Fields(t) {
+		if f.Tag.Get("forms") == "key" {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Key returns the value of the key field f of the nested DTO v, or false if v is a nil
+// pointer or its key is not present, as for elements that were never stored.
+func Key(v reflect.Value, f Field) (driver.Value, bool) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	fv, err := v.FieldByIndexErr(f.Index)
+	if err != nil {
+		return nil, false
+	}
+	val, state, err := Read(fv)
+	if err != nil || val == nil || state != nullstate.Present {
+		return nil, false
+	}
+	return val, true
+}
+
+// SameKey compares two keys returned by Key, which may come from fields of different types.
+func SameKey(a, b driver.Value) bool {
+	switch av := a.(type) {
+	case []byte:
+		bv, ok := b.([]byte)
+		return ok && bytes.Equal(av, bv)
+	case time.Time:
+		bv, ok := b.(time.Time)
+		return ok && av.Equal(bv)
+	}
+	return a == b
+}
+
+// ElemPath returns the path of element i of the slice field at path, naming it by the
+// value of its key field f if it has one, such as PreviousAddresses[ID=3] for the
+// address with ID 3, and by position otherwise, such as PreviousAddresses[0].
+func ElemPath(path string, i int, f Field, key driver.Value, hasKey bool) string {
+	if hasKey {
+		if b, ok := key.([]byte); ok {
+			key = string(b)
+		}
+		return fmt.Sprintf("%s[%s=%v]", path, f.Name, key)
+	}
+	return fmt.Sprintf("%s[%d]", path, i)
+}
@@ -0,0 +1,535 @@
+package nullreflect
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/bufpool"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// Values of the `nulljson` tag, choosing how a field encodes null.
+const (
+	NullJSONEmit  = "emit"  // "field": null, whatever the encoder's policy
+	NullJSONOmit  = "omit"  // the member is left out
+	NullJSONEmpty = "empty" // the empty value of the field's type, such as "" or []
+)
+
+// JSONPolicy says which null object members AppendJSON leaves out. Fields tagged with
+// `nulljson` encode null the way their tag says regardless of it.
+type JSONPolicy struct {
+	OmitUndefined bool // members of undefined top-level fields
+	OmitNull      bool // every member encoding to null, nested objects included
+}
+
+// AppendJSON appends the JSON encoding of v to dst following p, and returns the extended
+// buffer. v is encoded like json.Marshal does, except for the null members of the
+// top-level fields of a struct v, which follow their `nulljson` tag and p.
+//
+// Structs whose fields can all be encoded one by one are written member by member with
+// their keys quoted once per type, and the nullable types appending their own encoding
+// do so straight into dst. Others, such as structs embedding other structs or
+// implementing json.Marshaler, are marshaled whole and their members rewritten.
+func AppendJSON(dst []byte, v any, p JSONPolicy) ([]byte, error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	var plan *jsonPlan
+	if rv.Kind() == reflect.Struct {
+		if plan = jsonPlanOf(rv.Type()); plan.direct {
+			return plan.appendStruct(dst, rv, p)
+		}
+	}
+	raw := bufpool.Get()
+	defer bufpool.Put(raw)
+	if err := json.NewEncoder(raw).Encode(v); err != nil {
+		return dst, err
+	}
+	// Encode terminates the value with a newline that json.Marshal does not write.
+	data := bytes.TrimSuffix(raw.Bytes(), []byte("\n"))
+	if plan == nil || len(data) == 0 || data[0] != '{' {
+		if p.OmitNull {
+			return appendOmitNull(dst, data), nil
+		}
+		return append(dst, data...), nil
+	}
+	return plan.rewriteMembers(dst, data, rv, p)
+}
+
+// jsonAppender is implemented by the nullable types that append their JSON encoding.
+type jsonAppender interface {
+	AppendJSON(b []byte) ([]byte, error)
+}
+
+var (
+	jsonAppenderType  = reflect.TypeFor[jsonAppender]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalType   = reflect.TypeFor[encoding.TextMarshaler]()
+	isZeroerType      = reflect.TypeFor[interface{ IsZero() bool }]()
+)
+
+// jsonEncoding says how a field is encoded.
+type jsonEncoding int
+
+const (
+	encodeMarshal jsonEncoding = iota // json.Marshal
+	encodeAppender
+	encodeString
+	encodeBool
+	encodeInt
+	encodeUint
+	encodeFloat
+)
+
+// jsonField is a field of a jsonPlan.
+type jsonField struct {
+	Field
+	key       []byte // the quoted name, as encoding/json escapes it
+	encoding  jsonEncoding
+	omitEmpty bool
+	omitZero  bool
+	mode      string // the `nulljson` tag
+}
+
+// jsonPlan is the cached encoding of a struct type.
+type jsonPlan struct {
+	fields []jsonField
+	byKey  map[string]*jsonField // by quoted name, to rewrite marshaled objects
+	// direct is set if encoding the fields one by one gives the output of json.Marshal.
+	direct bool
+}
+
+var jsonPlanCache sync.Map // map[reflect.Type]*jsonPlan
+
+func jsonPlanOf(t reflect.Type) *jsonPlan {
+	if cached, ok := jsonPlanCache.Load(t); ok {
+		return cached.(*jsonPlan)
+	}
+	plan := &jsonPlan{byKey: make(map[string]*jsonField), direct: true}
+	pt := reflect.PointerTo(t)
+	if pt.Implements(jsonMarshalerType) || pt.Implements(textMarshalType) {
+		plan.direct = false
+	}
+	for i := range t.NumField() {
+		if t.Field(i).Anonymous {
+			plan.direct = false
+		}
+	}
+	for _, f := range Fields(t) {
+		name, ok := f.JSONName()
+		if !ok {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		tagName, opts, _ := strings.Cut(tag, ",")
+		if tagName != "" && !isValidJSONName(tagName) {
+			plan.direct = false
+		}
+		key, _ := json.Marshal(name)
+		jf := jsonField{
+			Field:     f,
+			key:       key,
+			encoding:  fieldEncoding(f.Type),
+			omitEmpty: hasOption(opts, "omitempty"),
+			omitZero:  hasOption(opts, "omitzero"),
+			mode:      f.Tag.Get("nulljson"),
+		}
+		if hasOption(opts, "string") || pointerOnly(f.Type, jsonMarshalerType) || pointerOnly(f.Type, textMarshalType) ||
+			jf.omitZero && pointerOnly(f.Type, isZeroerType) {
+			// encoding/json calls pointer methods on addressable fields only.
+			plan.direct = false
+		}
+		if _, dup := plan.byKey[string(key)]; dup {
+			plan.direct = false
+		}
+		plan.byKey[string(key)] = nil
+		plan.fields = append(plan.fields, jf)
+	}
+	for i := range plan.fields {
+		plan.byKey[string(plan.fields[i].key)] = &plan.fields[i]
+	}
+	cached, _ := jsonPlanCache.LoadOrStore(t, plan)
+	return cached.(*jsonPlan)
+}
+
+// pointerOnly reports whether *t implements iface and t does not.
+func pointerOnly(t, iface reflect.Type) bool {
+	return !t.Implements(iface) && reflect.PointerTo(t).Implements(iface)
+}
+
+// fieldEncoding picks the fastest way to encode values of t like encoding/json does.
+func fieldEncoding(t reflect.Type) jsonEncoding {
+	if t.Kind() == reflect.Pointer || t.Kind() == reflect.Interface {
+		return encodeMarshal
+	}
+	if t.Implements(jsonAppenderType) {
+		return encodeAppender
+	}
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalType) {
+		return encodeMarshal
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return encodeString
+	case reflect.Bool:
+		return encodeBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return encodeInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return encodeUint
+	case reflect.Float32, reflect.Float64:
+		return encodeFloat
+	}
+	return encodeMarshal
+}
+
+// appendStruct appends the JSON object of struct v member by member.
+func (plan *jsonPlan) appendStruct(dst []byte, v reflect.Value, p JSONPolicy) ([]byte, error) {
+	dst = append(dst, '{')
+	first := true
+	for i := range plan.fields {
+		f := &plan.fields[i]
+		fv := v.FieldByIndex(f.Index)
+		if f.omitEmpty && isEmptyJSON(fv) || f.omitZero && isZeroJSON(fv) {
+			continue
+		}
+		mark := len(dst)
+		if !first {
+			dst = append(dst, ',')
+		}
+		dst = append(append(dst, f.key...), ':')
+		start := len(dst)
+		var err error
+		if dst, err = f.appendValue(dst, fv); err != nil {
+			return dst[:mark], err
+		}
+		switch val := dst[start:]; {
+		case string(val) == "null":
+			repl, keep, err := f.replaceNull(fv, p)
+			if err != nil {
+				return dst[:mark], err
+			}
+			if !keep {
+				dst = dst[:mark]
+				continue
+			}
+			dst = append(dst[:start], repl...)
+		case p.OmitNull && len(val) > 0 && (val[0] == '{' || val[0] == '['):
+			buf := bufpool.Get()
+			buf.Write(val)
+			dst = appendOmitNull(dst[:start], buf.Bytes())
+			bufpool.Put(buf)
+		}
+		first = false
+	}
+	return append(dst, '}'), nil
+}
+
+// appendValue appends the JSON encoding of fv, the value of f.
+func (f *jsonField) appendValue(dst []byte, fv reflect.Value) ([]byte, error) {
+	switch f.encoding {
+	case encodeAppender:
+		return methods(fv).(jsonAppender).AppendJSON(dst)
+	case encodeString:
+		return AppendJSONString(dst, fv.String()), nil
+	case encodeBool:
+		return strconv.AppendBool(dst, fv.Bool()), nil
+	case encodeInt:
+		return strconv.AppendInt(dst, fv.Int(), 10), nil
+	case encodeUint:
+		return strconv.AppendUint(dst, fv.Uint(), 10), nil
+	case encodeFloat:
+		if x := fv.Float(); !math.IsNaN(x) && !math.IsInf(x, 0) {
+			return AppendJSONFloat(dst, x, fv.Type().Bits()), nil
+		}
+	}
+	if isNilJSON(fv) {
+		return append(dst, null...), nil
+	}
+	data, err := json.Marshal(fv.Interface())
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, data...), nil
+}
+
+// isNilJSON reports whether encoding/json encodes v as null without calling a method,
+// which it does for nil pointers and interfaces, and for nil maps and slices without
+// MarshalJSON or MarshalText methods.
+func isNilJSON(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	case reflect.Map, reflect.Slice:
+		t := v.Type()
+		return v.IsNil() && !t.Implements(jsonMarshalerType) && !t.Implements(textMarshalType)
+	}
+	return false
+}
+
+// replaceNull returns the member the null value of top-level field f, holding fv, is
+// written as, keep being false if the member is left out.
+func (f *jsonField) replaceNull(fv reflect.Value, p JSONPolicy) (repl []byte, keep bool, err error) {
+	if f.mode != "" {
+		_, state, err := Read(fv)
+		if err != nil {
+			return nil, false, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		if state != nullstate.Present {
+			switch f.mode {
+			case NullJSONEmit:
+				return null, true, nil
+			case NullJSONOmit:
+				return nil, false, nil
+			case NullJSONEmpty:
+				repl, err := EmptyJSON(f.Type)
+				if err != nil {
+					return nil, false, fmt.Errorf("field %s: %w", f.Name, err)
+				}
+				return repl, true, nil
+			}
+			return nil, false, fmt.Errorf("field %s: unknown nulljson mode %q", f.Name, f.mode)
+		}
+	}
+	if p.OmitNull {
+		return nil, false, nil
+	}
+	if !p.OmitUndefined || isNilJSON(fv) {
+		return null, true, nil
+	}
+	if d, ok := methods(fv).(definer); ok && !d.IsDefined() {
+		return nil, false, nil
+	}
+	return null, true, nil
+}
+
+// methods returns v as an interface to call its methods on, going through its address
+// when it has one so the value is not copied to the heap.
+func methods(v reflect.Value) any {
+	if v.CanAddr() && v.Kind() != reflect.Pointer && v.Kind() != reflect.Interface {
+		return v.Addr().Interface()
+	}
+	return v.Interface()
+}
+
+var null = []byte("null")
+
+// rewriteMembers appends the JSON object data, the encoding of struct v, to dst with its
+// null members replaced following p.
+func (plan *jsonPlan) rewriteMembers(dst, data []byte, v reflect.Value, p JSONPolicy) ([]byte, error) {
+	dst = append(dst, '{')
+	first := true
+	for i := 1; i < len(data) && data[i] != '}'; {
+		end := scanJSON(data, i)
+		key := data[i:end]
+		i = end + 1 // the colon
+		end = scanJSON(data, i)
+		val := data[i:end]
+		i = end
+		if i < len(data) && data[i] == ',' {
+			i++
+		}
+		if f, ok := plan.byKey[string(key)]; ok && string(val) == "null" {
+			repl, keep, err := f.replaceNull(v.FieldByIndex(f.Index), p)
+			if err != nil {
+				return dst, err
+			}
+			if !keep {
+				continue
+			}
+			val = repl
+		} else if p.OmitNull && string(val) == "null" {
+			continue
+		}
+		if !first {
+			dst = append(dst, ',')
+		}
+		first = false
+		dst = append(append(dst, key...), ':')
+		if p.OmitNull {
+			dst = appendOmitNull(dst, val)
+		} else {
+			dst = append(dst, val...)
+		}
+	}
+	return append(dst, '}'), nil
+}
+
+// appendOmitNull appends the compact JSON value data to dst without the null members of
+// its objects, nested ones included. Array elements are kept as they are.
+func appendOmitNull(dst, data []byte) []byte {
+	if len(data) == 0 || data[0] != '{' && data[0] != '[' {
+		return append(dst, data...)
+	}
+	open := data[0]
+	closing := byte('}')
+	if open == '[' {
+		closing = ']'
+	}
+	dst = append(dst, open)
+	first := true
+	for i := 1; i < len(data) && data[i] != closing; {
+		var key []byte
+		if open == '{' {
+			end := scanJSON(data, i)
+			key = data[i : end+1] // with the colon
+			i = end + 1
+		}
+		end := scanJSON(data, i)
+		val := data[i:end]
+		i = end
+		if i < len(data) && data[i] == ',' {
+			i++
+		}
+		if open == '{' && string(val) == "null" {
+			continue
+		}
+		if !first {
+			dst = append(dst, ',')
+		}
+		first = false
+		dst = appendOmitNull(append(dst, key...), val)
+	}
+	return append(dst, closing)
+}
+
+// scanJSON returns the end of the compact JSON value starting at data[i].
+func scanJSON(data []byte, i int) int {
+	switch data[i] {
+	case '"':
+		for i++; i < len(data); i++ {
+			switch data[i] {
+			case '\\':
+				i++
+			case '"':
+				return i + 1
+			}
+		}
+		return i
+	case '{', '[':
+		depth := 0
+		for ; i < len(data); i++ {
+			switch data[i] {
+			case '"':
+				i = scanJSON(data, i) - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				if depth--; depth == 0 {
+					return i + 1
+				}
+			}
+		}
+		return i
+	}
+	for ; i < len(data); i++ {
+		if c := data[i]; c == ',' || c == '}' || c == ']' {
+			break
+		}
+	}
+	return i
+}
+
+// EmptyJSON returns the JSON encoding of the empty value held by nullable type t:
+// "" for strings and bytes, [] for slices, {} for maps and the zero value otherwise.
+func EmptyJSON(t reflect.Type) ([]byte, error) {
+	inner := valueType(t)
+	switch inner.Kind() {
+	case reflect.String:
+		return []byte(`""`), nil
+	case reflect.Slice:
+		if inner.Elem().Kind() == reflect.Uint8 {
+			return []byte(`""`), nil
+		}
+		return []byte("[]"), nil
+	case reflect.Map:
+		return []byte("{}"), nil
+	}
+	data, err := json.Marshal(reflect.Zero(inner).Interface())
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(data, []byte("null")) {
+		return nil, fmt.Errorf("no empty JSON value for %s", t)
+	}
+	return data, nil
+}
+
+// valueType returns the type of the value held by t: the inner value of nullable
+// types, the element of pointers, or the value field of Valid-flagged structs such as
+// pgtype.Text and null.String.
+func valueType(t reflect.Type) reflect.Type {
+	if inner, ok := Inner(t); ok {
+		return inner
+	}
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return t
+	}
+	if t.NumField() == 1 && t.Field(0).Anonymous {
+		return valueType(t.Field(0).Type)
+	}
+	if _, ok := t.FieldByName("Valid"); ok && t.NumField() == 2 {
+		for i := range t.NumField() {
+			if f := t.Field(i); f.Name != "Valid" {
+				return f.Type
+			}
+		}
+	}
+	return t
+}
+
+// isEmptyJSON reports whether encoding/json treats v as empty for omitempty.
+func isEmptyJSON(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	}
+	return false
+}
+
+// isZeroJSON reports whether encoding/json treats v as zero for omitzero, calling its
+// IsZero method if it has one.
+func isZeroJSON(v reflect.Value) bool {
+	if !v.Type().Implements(isZeroerType) {
+		return v.IsZero()
+	}
+	if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return true
+	}
+	return v.Interface().(interface{ IsZero() bool }).IsZero()
+}
+
+// isValidJSONName reports whether encoding/json accepts name from a `json` tag.
+func isValidJSONName(name string) bool {
+	for _, c := range name {
+		if !strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c) && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasOption reports whether the comma-separated tag options opts include name.
+func hasOption(opts, name string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+// Package nullreflect reads and writes nullable struct fields through the
+// database/sql/driver.Valuer and database/sql.Scanner interfaces, so that
+// nullable.XxX, null.XxX, pgtype.XxX, pointers and plain values can be handled uniformly.
+package nullreflect
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// Field describes an exported struct field.
+type Field struct {
+	Name  string
+	Index []int
+	Type  reflect.Type
+	Tag   reflect.StructTag
+}
+
+var fieldsCache sync.Map // map[reflect.Type][]Field
+
+// Fields returns the exported fields of struct type t, in declaration order.
+//
+// The fields of embedded nested DTOs are promoted in their place, with Index the full
+// path to them, following Go's rules as encoding/json does: a field hides the deeper
+// fields of the same name, and same-named fields at the same depth hide each other.
+// Embedded values, such as an sql.NullString, and embedded pointers, which could be nil,
+// are fields of their own named after their type.
+func Fields(t reflect.Type) []Field {
+	if cached, ok := fieldsCache.Load(t); ok {
+		return cached.([]Field)
+	}
+	all := appendFields(nil, t, nil)
+	depth := make(map[string]int) // name -> depth of its shallowest fields
+	count := make(map[string]int) // name -> number of fields at that depth
+	for _, f := range all {
+		d, ok := depth[f.Name]
+		switch {
+		case !ok || len(f.Index) < d:
+			depth[f.Name], count[f.Name] = len(f.Index), 1
+		case len(f.Index) == d:
+			count[f.Name]++
+		}
+	}
+	var fields []Field
+	for _, f := range all {
+		if len(f.Index) == depth[f.Name] && count[f.Name] == 1 {
+			fields = append(fields, f)
+		}
+	}
+	cached, _ := fieldsCache.LoadOrStore(t, fields)
+	return cached.([]Field)
+}
+
+// appendFields appends the exported fields of struct type t, found at index, to fields,
+// expanding embedded nested DTOs, exported or not, into their own fields.
+func appendFields(fields []Field, t reflect.Type, index []int) []Field {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		idx := append(slices.Clip(index), i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct && IsNested(sf.Type) {
+			fields = appendFields(fields, sf.Type, idx)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		fields = append(fields, Field{Name: sf.Name, Index: idx, Type: sf.Type, Tag: sf.Tag})
+	}
+	return fields
+}
+
+type definer interface {
+	IsDefined() bool
+}
+
+// getter and setter are the accessors of nullable.Nullable, used for types
+// that implement it without being Valuers and Scanners.
+type getter interface {
+	Get() (any, bool)
+}
+
+type setter interface {
+	Set(v any) error
+}
+
+// Read returns the driver value held by v along with its state.
+// Only types reporting IsDefined() can be undefined; nil pointers and
+// Valuers returning nil are null. Types that are not Valuers but have a
+// Get() (any, bool) method, as nullable.Nullable does, are read through it.
+func Read(v reflect.Value) (driver.Value, nullstate.State, error) {
+	x := v.Interface()
+	if d, ok := x.(definer); ok && !d.IsDefined() {
+		return nil, nullstate.Undefined, nil
+	}
+	if _, ok := x.(driver.Valuer); !ok {
+		if g, ok := x.(getter); ok {
+			val, ok := g.Get()
+			if !ok {
+				return nil, nullstate.Null, nil
+			}
+			x = val
+		}
+	}
+	dv, err := driver.DefaultParameterConverter.ConvertValue(x)
+	if err != nil {
+		return nil, nullstate.Undefined, err
+	}
+	if dv == nil {
+		return nil, nullstate.Null, nil
+	}
+	return dv, nullstate.Present, nil
+}
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	setterType  = reflect.TypeFor[setter]()
+)
+
+// Write stores val into v according to state. Undefined resets v to its zero value.
+// Scanners receive val through Scan, other types with a Set(any) error method,
+// as nullable.Nullable has, through Set, pointers are allocated as needed, and
+// plain values receive null as their zero value. Failures are reported as *ConvError.
+func Write(v reflect.Value, val any, state nullstate.State) error {
+	return convError(val, v.Type(), write(v, val, state))
+}
+
+func write(v reflect.Value, val any, state nullstate.State) error {
+	if state == nullstate.Undefined {
+		v.SetZero()
+		return nil
+	}
+	if state == nullstate.Null {
+		val = nil
+	}
+	pt := reflect.PointerTo(v.Type())
+	if pt.Implements(scannerType) {
+		return v.Addr().Interface().(sql.Scanner).Scan(val)
+	}
+	if pt.Implements(setterType) {
+		return v.Addr().Interface().(setter).Set(val)
+	}
+	if val == nil {
+		v.SetZero()
+		return nil
+	}
+	if sv := reflect.ValueOf(val); sv.Type().AssignableTo(v.Type()) {
+		v.Set(sv)
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		p := reflect.New(v.Type().Elem())
+		if err := write(p.Elem(), val, state); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	}
+	return Assign(v, val)
+}
+
+// JSONName returns the JSON object key of f following encoding/json rules.
+// It returns false if the field is skipped with `json:"-"`.
+func (f Field) JSONName() (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	return name, true
+}
+
+// Column returns the SQL column name of f, taken from the `db` tag or
+// derived from the field name in snake_case. It returns false if the field
+// is skipped with `db:"-"`.
+func (f Field) Column() (string, bool) {
+	tag := f.Tag.Get("db")
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = SnakeCase(f.Name)
+	}
+	return name, true
+}
+
+var definerType = reflect.TypeFor[definer]()
+
+// CanBeUndefined reports whether values of t tell undefined apart from null,
+// as nullable.Optional does.
+func CanBeUndefined(t reflect.Type) bool {
+	return t.Implements(definerType)
+}
+
+// IsUndefined reports whether v holds a value that reports itself as not defined.
+func IsUndefined(v reflect.Value) bool {
+	d, ok := v.Interface().(definer)
+	return ok && !d.IsDefined()
+}
@@ -0,0 +1,99 @@
+package nullreflect
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+)
+
+type base struct {
+	ID      int64
+	Name    string
+	Created string
+}
+
+type Audit struct {
+	Created string
+	Updated string
+}
+
+type other struct {
+	Updated string
+}
+
+type embedding struct {
+	base
+	*Audit
+	other
+	sql.NullString
+	Name    string
+	hidden  string
+	Deleted string `json:"deleted"`
+}
+
+type embeddingBoth struct {
+	base
+	Audit
+	other
+}
+
+func TestFields(t *testing.T) {
+	type field struct {
+		Name  string
+		Index []int
+	}
+	tests := []struct {
+		name string
+		t    reflect.Type
+		want []field
+	}{
+		{
+			name: "embedded DTOs are promoted unless hidden",
+			t:    reflect.TypeFor[embedding](),
+			want: []field{
+				{"ID", []int{0, 0}},
+				{"Created", []int{0, 2}},
+				{"Audit", []int{1}},
+				{"Updated", []int{2, 0}},
+				{"NullString", []int{3}},
+				{"Name", []int{4}},
+				{"Deleted", []int{6}},
+			},
+		},
+		{
+			name: "same-named fields at the same depth hide each other",
+			t:    reflect.TypeFor[embeddingBoth](),
+			want: []field{
+				{"ID", []int{0, 0}},
+				{"Name", []int{0, 1}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []field
+			for _, f := range Fields(tt.t) {
+				got = append(got, field{f.Name, f.Index})
+				if sf := tt.t.FieldByIndex(f.Index); sf.Type != f.Type || sf.Tag != f.Tag {
+					t.Errorf("field %s has type %v and tag %q, want %v and %q", f.Name, f.Type, f.Tag, sf.Type, sf.Tag)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldsOfUnexportedEmbeddedAreSettable(t *testing.T) {
+	var v embedding
+	rv := reflect.ValueOf(&v).Elem()
+	for _, f := range Fields(rv.Type()) {
+		if f.Name == "ID" {
+			rv.FieldByIndex(f.Index).SetInt(7)
+		}
+	}
+	if v.ID != 7 {
+		t.Errorf("ID = %d, want 7 set through the embedded base", v.ID)
+	}
+}
@@ -0,0 +1,39 @@
+package nullreflect
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Pair links same-named fields of two struct types.
+type Pair struct {
+	Name     string
+	Src, Dst []int
+}
+
+type pairsKey struct {
+	src, dst reflect.Type
+}
+
+var pairsCache sync.Map // map[pairsKey][]Pair
+
+// Pairs returns the fields of struct type src that have a same-named field in dst.
+// The result is cached per type pair.
+func Pairs(src, dst reflect.Type) []Pair {
+	key := pairsKey{src, dst}
+	if cached, ok := pairsCache.Load(key); ok {
+		return cached.([]Pair)
+	}
+	dstFields := make(map[string][]int)
+	for _, f := range Fields(dst) {
+		dstFields[f.Name] = f.Index
+	}
+	var pairs []Pair
+	for _, f := range Fields(src) {
+		if idx, ok := dstFields[f.Name]; ok {
+			pairs = append(pairs, Pair{Name: f.Name, Src: f.Index, Dst: idx})
+		}
+	}
+	cached, _ := pairsCache.LoadOrStore(key, pairs)
+	return cached.([]Pair)
+}
@@ -0,0 +1,162 @@
+package nullreflect
+
+import (
+	"database/sql"
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+const nullablePkgPath = "github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+
+// LayoutUnixMilli is a pseudo layout of TimeLayouts standing for milliseconds since
+// the Unix epoch.
+const LayoutUnixMilli = "unixmilli"
+
+// TimeLayouts are tried in order when parsing strings into time values. Besides RFC 3339
+// and dates they cover MySQL's and PostgreSQL's textual timestamps and LayoutUnixMilli.
+// Package nullable exposes them as nullable.TimeLayouts.
+var TimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	time.DateTime,
+	time.DateOnly,
+	LayoutUnixMilli,
+}
+
+// TimeLocation returns the location ParseTime parses layouts without a zone offset in.
+// Package nullable points it at nullable.TimeLocation.
+var TimeLocation = func() *time.Location { return time.UTC }
+
+// ParseTime parses s with the first of TimeLayouts that accepts it, in TimeLocation
+// for layouts without a zone offset.
+func ParseTime(s string) (time.Time, error) {
+	loc := TimeLocation()
+	for _, layout := range TimeLayouts {
+		if layout == LayoutUnixMilli {
+			if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return time.UnixMilli(ms).In(loc), nil
+			}
+			continue
+		}
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("couldn't parse time %q with layouts %q", s, TimeLayouts)
+}
+
+// isNullableStruct reports whether t is nullable.Null, nullable.Optional or another
+// nullable type holding its value in a V field, such as nullable.Time.
+func isNullableStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t.PkgPath() != nullablePkgPath {
+		return false
+	}
+	_, hasV := t.FieldByName("V")
+	_, hasValid := t.FieldByName("Valid")
+	return hasV && hasValid
+}
+
+// hasOwnText reports whether nullable type t parses text itself instead of leaving
+// it to its inner value, which is the case for every type but Null and Optional.
+func hasOwnText(t reflect.Type) bool {
+	name := t.Name()
+	if strings.HasPrefix(name, "Null[") || strings.HasPrefix(name, "Optional[") {
+		return false
+	}
+	return reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// Inner returns the type of the value held by nullable type t.
+func Inner(t reflect.Type) (reflect.Type, bool) {
+	if !isNullableStruct(t) {
+		return nil, false
+	}
+	f, _ := t.FieldByName("V")
+	return f.Type, true
+}
+
+// WriteString parses the non-null textual input s into v. Values of the nullable
+// package are parsed into their inner value like any other value, unless they parse
+// text themselves like nullable.Time and nullable.Enum do, Scanners get s (or s parsed
+// as a time when they reject it), TextUnmarshalers decode s, and plain values use
+// Assign, as do times so TimeLayouts apply. Failures are reported as *ConvError.
+func WriteString(v reflect.Value, s string) error {
+	return convError(s, v.Type(), writeString(v, s))
+}
+
+func writeString(v reflect.Value, s string) error {
+	t := v.Type()
+	pt := reflect.PointerTo(t)
+	if isNullableStruct(t) && hasOwnText(t) {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	if isNullableStruct(t) {
+		if err := writeString(v.FieldByName("V"), s); err != nil {
+			return err
+		}
+		v.FieldByName("Valid").SetBool(true)
+		if d := v.FieldByName("Defined"); d.IsValid() {
+			d.SetBool(true)
+		}
+		return nil
+	}
+	if pt.Implements(scannerType) {
+		sc := v.Addr().Interface().(sql.Scanner)
+		err := sc.Scan(s)
+		if err == nil {
+			return nil
+		}
+		if tm, terr := ParseTime(s); terr == nil {
+			if sc.Scan(tm) == nil {
+				return nil
+			}
+		}
+		return err
+	}
+	if pt.Implements(textUnmarshalerType) && t != timeType {
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	if v.Kind() == reflect.Pointer {
+		p := reflect.New(t.Elem())
+		if err := writeString(p.Elem(), s); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	}
+	return Assign(v, s)
+}
+
+var (
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	timeType            = reflect.TypeFor[time.Time]()
+)
+
+var textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+
+// ReadString renders the value held by v as text, the inverse of WriteString.
+// Values implementing encoding.TextMarshaler render themselves, others are read
+// as driver values and rendered like Assign renders them into a string.
+// The string is empty unless the state is present.
+func ReadString(v reflect.Value) (string, nullstate.State, error) {
+	dv, state, err := Read(v)
+	if err != nil || state != nullstate.Present {
+		return "", state, err
+	}
+	if v.Type().Implements(textMarshalerType) {
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), state, err
+	}
+	var s string
+	if err := Assign(reflect.ValueOf(&s).Elem(), dv); err != nil {
+		return "", state, err
+	}
+	return s, state, nil
+}
@@ -0,0 +1,27 @@
+// Package nullstate defines the presence state shared by package nullable and
+// the reflection helpers it builds on, keeping them free of import cycles.
+package nullstate
+
+import "fmt"
+
+// State describes whether a value was left undefined, explicitly set to null, or holds a value.
+type State uint8
+
+const (
+	Undefined State = iota
+	Null
+	Present
+)
+
+// String implements fmt.Stringer.
+func (s State) String() string {
+	switch s {
+	case Undefined:
+		return "undefined"
+	case Null:
+		return "null"
+	case Present:
+		return "present"
+	}
+	return fmt.Sprintf("State(%d)", uint8(s))
+}
@@ -0,0 +1,38 @@
+// Package observe carries instrumentation hooks from the binding and validation
+// packages to package metrics, so they do not depend on a metrics library themselves.
+package observe
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// Observer receives binding and validation outcomes.
+type Observer interface {
+	// Decoded reports a decode of dto, a struct, from source such as "json" or "query".
+	// err is nil on success, in which case dto holds the decoded fields.
+	Decoded(dto reflect.Value, source string, err error)
+	// Invalid reports a field of the DTO type named dto that failed validation with tag.
+	Invalid(dto, field, tag string)
+}
+
+var current atomic.Pointer[Observer]
+
+// Set installs o as the Observer, replacing any previous one.
+func Set(o Observer) {
+	current.Store(&o)
+}
+
+// Decoded forwards to the installed Observer, if any.
+func Decoded(dto reflect.Value, source string, err error) {
+	if o := current.Load(); o != nil {
+		(*o).Decoded(dto, source, err)
+	}
+}
+
+// Invalid forwards to the installed Observer, if any.
+func Invalid(dto, field, tag string) {
+	if o := current.Load(); o != nil {
+		(*o).Invalid(dto, field, tag)
+	}
+}
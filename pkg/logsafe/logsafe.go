@@ -0,0 +1,53 @@
+// Package logsafe renders nullable DTOs for log/slog without leaking
+// personally identifiable information or nullable type internals.
+package logsafe
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Value renders dto, a struct or pointer to one, as a slog group with one attribute per field.
+// Nulls are rendered as <null> and undefined fields are left out.
+// Fields tagged `pii:"mask"` have their value masked with Mask.
+//
+//	slog.Info("form submitted", "form", logsafe.Value(form))
+func Value(dto any) slog.Value {
+	v := reflect.Indirect(reflect.ValueOf(dto))
+	if v.Kind() != reflect.Struct {
+		return slog.AnyValue(dto)
+	}
+
+	var attrs []slog.Attr
+	for _, f := range nullreflect.Fields(v.Type()) {
+		val, state, err := nullreflect.Read(v.FieldByIndex(f.Index))
+		switch {
+		case err != nil:
+			attrs = append(attrs, slog.String(f.Name, "!ERROR:"+err.Error()))
+		case state == nullable.StateUndefined:
+		case state == nullable.StateNull:
+			attrs = append(attrs, slog.String(f.Name, nullable.NullPlaceholder))
+		case f.Tag.Get("pii") == "mask":
+			attrs = append(attrs, slog.String(f.Name, Mask(fmt.Sprint(val))))
+		default:
+			attrs = append(attrs, slog.Any(f.Name, val))
+		}
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// Mask hides all but the last few characters of s, keeping longer values
+// recognizable in logs without revealing them, e.g. S1234567A becomes *****567A.
+func Mask(s string) string {
+	runes := []rune(s)
+	keep := 0
+	if len(runes) >= 8 {
+		keep = 4
+	}
+	return strings.Repeat("*", len(runes)-keep) + string(runes[len(runes)-keep:])
+}
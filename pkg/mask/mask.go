@@ -0,0 +1,182 @@
+// Package mask applies google.protobuf.FieldMask style masks to nullable DTOs,
+// for gRPC read and update endpoints that take the mask's paths alongside the message.
+//
+// Paths are dot-separated field names, e.g. "name" or "address.street", the way
+// FieldMask.GetPaths reports them. Each segment matches a field's JSON name, or
+// case-insensitively its JSON name or snake_case Go name, so both proto and JSON
+// spellings work. A path naming a struct field covers all of its subfields.
+package mask
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// tree holds the parsed paths; a nil subtree selects the whole field.
+type tree map[string]tree
+
+// Project returns a copy of dto, a struct, with only the fields selected by paths.
+// Other fields are left at their zero value, which is undefined for nullable.Optional
+// and null for types that cannot be undefined.
+func Project[T any](dto T, paths []string) (T, error) {
+	var out T
+	v := reflect.ValueOf(dto)
+	if v.Kind() != reflect.Struct {
+		return out, fmt.Errorf("mask: dto must be a struct, got %T", dto)
+	}
+	t, err := parse(v.Type(), paths)
+	if err != nil {
+		return out, err
+	}
+	project(reflect.ValueOf(&out).Elem(), v, t)
+	return out, nil
+}
+
+func project(dst, src reflect.Value, t tree) {
+	for name, sub := range t {
+		f, _ := field(src.Type(), name)
+		sv, dv := src.FieldByIndex(f.Index), dst.FieldByIndex(f.Index)
+		if sub == nil {
+			dv.Set(sv)
+			continue
+		}
+		if sv.Kind() == reflect.Pointer {
+			if sv.IsNil() {
+				continue
+			}
+			dv.Set(reflect.New(sv.Type().Elem()))
+			sv, dv = sv.Elem(), dv.Elem()
+		}
+		project(dv, sv, sub)
+	}
+}
+
+// ApplyUpdate copies the fields of src selected by paths onto dst, leaving the others
+// as they are. Fields are copied verbatim, so a selected field that is null or
+// undefined in src becomes so in dst, following the FieldMask update semantics where
+// a masked but unset field is cleared.
+//
+// dst must be a non-nil pointer to a struct, src a struct or a pointer to one of the same type.
+func ApplyUpdate(dst, src any, paths []string) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("mask: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	dv = dv.Elem()
+	sv := reflect.ValueOf(src)
+	if sv.Kind() == reflect.Pointer {
+		if sv.IsNil() {
+			return errors.New("mask: nil src")
+		}
+		sv = sv.Elem()
+	}
+	if sv.Type() != dv.Type() {
+		return fmt.Errorf("mask: src is %s, dst is %s", sv.Type(), dv.Type())
+	}
+	t, err := parse(dv.Type(), paths)
+	if err != nil {
+		return err
+	}
+	update(dv, sv, t)
+	return nil
+}
+
+func update(dst, src reflect.Value, t tree) {
+	for name, sub := range t {
+		f, _ := field(src.Type(), name)
+		sv, dv := src.FieldByIndex(f.Index), dst.FieldByIndex(f.Index)
+		if sub == nil {
+			dv.Set(sv)
+			continue
+		}
+		if sv.Kind() == reflect.Pointer {
+			if sv.IsNil() {
+				// Subfields of a missing message are unset.
+				sv = reflect.New(sv.Type().Elem())
+			}
+			if dv.IsNil() {
+				dv.Set(reflect.New(dv.Type().Elem()))
+			}
+			sv, dv = sv.Elem(), dv.Elem()
+		}
+		update(dv, sv, sub)
+	}
+}
+
+// parse builds the tree of paths over struct type st, rejecting unknown fields
+// and paths descending into non-struct fields.
+func parse(st reflect.Type, paths []string) (tree, error) {
+	root := tree{}
+	for _, path := range paths {
+		node, t := root, st
+		segments := strings.Split(path, ".")
+		for i, seg := range segments {
+			if t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			if !isMessage(t) {
+				return nil, fmt.Errorf("mask: path %q: cannot descend into %s", path, t)
+			}
+			f, ok := field(t, seg)
+			if !ok {
+				return nil, fmt.Errorf("mask: path %q: unknown field %q", path, seg)
+			}
+			sub, seen := node[seg]
+			if seen && sub == nil {
+				// An earlier path already selects the whole field.
+				break
+			}
+			if i == len(segments)-1 {
+				node[seg] = nil
+				break
+			}
+			if sub == nil {
+				sub = tree{}
+				node[seg] = sub
+			}
+			node, t = sub, f.Type
+		}
+	}
+	return root, nil
+}
+
+var (
+	scannerType     = reflect.TypeFor[interface{ Scan(any) error }]()
+	unmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// isMessage reports whether t is a struct with subfields of its own, as opposed to
+// nullable value types such as nullable.Optional or time.Time.
+func isMessage(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	pt := reflect.PointerTo(t)
+	return !pt.Implements(scannerType) && !pt.Implements(unmarshalerType)
+}
+
+// field finds the field of struct type t named by a mask path segment.
+func field(t reflect.Type, name string) (nullreflect.Field, bool) {
+	var fold *nullreflect.Field
+	for _, f := range nullreflect.Fields(t) {
+		n, ok := f.JSONName()
+		if !ok {
+			continue
+		}
+		if n == name {
+			return f, true
+		}
+		if fold == nil && (strings.EqualFold(n, name) || strings.EqualFold(nullreflect.SnakeCase(f.Name), name)) {
+			fold = &f
+		}
+	}
+	if fold == nil {
+		return nullreflect.Field{}, false
+	}
+	return *fold, true
+}
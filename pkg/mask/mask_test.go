@@ -0,0 +1,214 @@
+package mask
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type testAddress struct {
+	Street     nullable.Optional[string] `json:"street"`
+	PostalCode nullable.Optional[string] `json:"postalCode"`
+}
+
+type testProfile struct {
+	Name        nullable.Optional[string] `json:"name"`
+	MarriedName nullable.Optional[string] `json:"marriedName"`
+	Age         nullable.Null[int32]      `json:"age"`
+	Birthday    nullable.Date             `json:"birthday"`
+	Address     testAddress               `json:"address"`
+	Mailing     *testAddress              `json:"mailing"`
+	Secret      string                    `json:"-"`
+}
+
+var (
+	some    = nullable.OptionalFrom[string]
+	cleared = nullable.OptionalNull[string]()
+)
+
+func full() testProfile {
+	return testProfile{
+		Name:        some("Tan"),
+		MarriedName: cleared,
+		Age:         nullable.From[int32](30),
+		Birthday:    nullable.NewDate(1990, 5, 17),
+		Address:     testAddress{Street: some("Orchard Rd"), PostalCode: some("238801")},
+		Mailing:     &testAddress{Street: some("Raffles Pl")},
+		Secret:      "s",
+	}
+}
+
+func TestProject(t *testing.T) {
+	tests := []struct {
+		name  string
+		dto   testProfile
+		paths []string
+		want  testProfile
+	}{
+		{name: "no paths", dto: full(), want: testProfile{}},
+		{
+			name:  "top-level fields",
+			dto:   full(),
+			paths: []string{"name", "marriedName", "age"},
+			want:  testProfile{Name: some("Tan"), MarriedName: cleared, Age: nullable.From[int32](30)},
+		},
+		{
+			name:  "proto and case-insensitive spellings",
+			dto:   full(),
+			paths: []string{"married_name", "NAME", "address.postal_code"},
+			want:  testProfile{Name: some("Tan"), MarriedName: cleared, Address: testAddress{PostalCode: some("238801")}},
+		},
+		{
+			name:  "a struct path covers its subfields",
+			dto:   full(),
+			paths: []string{"address", "address.street"},
+			want:  testProfile{Address: testAddress{Street: some("Orchard Rd"), PostalCode: some("238801")}},
+		},
+		{
+			name:  "the whole field wins over an earlier subfield",
+			dto:   full(),
+			paths: []string{"mailing.street", "mailing"},
+			want:  testProfile{Mailing: &testAddress{Street: some("Raffles Pl")}},
+		},
+		{
+			name:  "subfields of a pointer are copied into a new value",
+			dto:   full(),
+			paths: []string{"mailing.postalCode"},
+			want:  testProfile{Mailing: &testAddress{}},
+		},
+		{
+			name:  "subfields of a nil pointer",
+			dto:   testProfile{},
+			paths: []string{"mailing.street"},
+			want:  testProfile{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Project(tt.dto, tt.paths)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Project(%q) = %#v, want %#v", tt.paths, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectDoesNotShareSubfieldPointers(t *testing.T) {
+	dto := full()
+	got, err := Project(dto, []string{"mailing.street"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Mailing == dto.Mailing {
+		t.Error("Project shares the Mailing pointer of dto")
+	}
+}
+
+func TestApplyUpdate(t *testing.T) {
+	tests := []struct {
+		name  string
+		dst   testProfile
+		src   testProfile
+		paths []string
+		want  testProfile
+	}{
+		{
+			name:  "selected fields are copied, others kept",
+			dst:   full(),
+			src:   testProfile{Name: some("Lim"), Age: nullable.From[int32](31)},
+			paths: []string{"name"},
+			want: func() testProfile {
+				p := full()
+				p.Name = some("Lim")
+				return p
+			}(),
+		},
+		{
+			name:  "masked but unset fields are cleared",
+			dst:   full(),
+			src:   testProfile{MarriedName: some("Lee")},
+			paths: []string{"name", "marriedName", "age", "birthday"},
+			want: func() testProfile {
+				p := full()
+				p.Name, p.MarriedName, p.Age, p.Birthday = nullable.Optional[string]{}, some("Lee"), nullable.Null[int32]{}, nullable.Date{}
+				return p
+			}(),
+		},
+		{
+			name:  "nested paths",
+			dst:   full(),
+			src:   testProfile{Address: testAddress{Street: cleared}},
+			paths: []string{"address.street"},
+			want: func() testProfile {
+				p := full()
+				p.Address.Street = cleared
+				return p
+			}(),
+		},
+		{
+			name:  "subfields of a missing source message are unset",
+			dst:   full(),
+			src:   testProfile{},
+			paths: []string{"mailing.street"},
+			want: func() testProfile {
+				p := full()
+				p.Mailing = &testAddress{}
+				return p
+			}(),
+		},
+		{
+			name:  "nil destination messages are allocated",
+			dst:   testProfile{},
+			src:   testProfile{Mailing: &testAddress{Street: some("Raffles Pl"), PostalCode: some("048616")}},
+			paths: []string{"mailing.postal_code"},
+			want:  testProfile{Mailing: &testAddress{PostalCode: some("048616")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.dst
+			if got.Mailing != nil {
+				m := *got.Mailing
+				got.Mailing = &m
+			}
+			if err := ApplyUpdate(&got, &tt.src, tt.paths); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyUpdate(%q) = %#v, want %#v", tt.paths, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	var p testProfile
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr string
+	}{
+		{name: "unknown field", call: func() error { _, err := Project(p, []string{"nope"}); return err }, wantErr: `unknown field "nope"`},
+		{name: "skipped field", call: func() error { _, err := Project(p, []string{"secret"}); return err }, wantErr: `unknown field "secret"`},
+		{name: "unknown subfield", call: func() error { return ApplyUpdate(&p, p, []string{"address.city"}) }, wantErr: `unknown field "city"`},
+		{name: "descend into a value", call: func() error { _, err := Project(p, []string{"birthday.year"}); return err }, wantErr: "cannot descend into"},
+		{name: "descend into an optional", call: func() error { _, err := Project(p, []string{"name.first"}); return err }, wantErr: "cannot descend into"},
+		{name: "project a pointer", call: func() error { _, err := Project(&p, nil); return err }, wantErr: "dto must be a struct"},
+		{name: "update a value", call: func() error { return ApplyUpdate(p, p, nil) }, wantErr: "dst must be a non-nil pointer"},
+		{name: "nil src", call: func() error { return ApplyUpdate(&p, (*testProfile)(nil), nil) }, wantErr: "nil src"},
+		{name: "other src type", call: func() error { return ApplyUpdate(&p, testAddress{}, nil) }, wantErr: "src is mask.testAddress"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			if err == nil || !strings.HasPrefix(err.Error(), "mask: ") || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want a mask error mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Package metrics exports Prometheus metrics on binding and validation outcomes, so
+// dashboards show which forms fail to decode, which fields fail validation and which
+// optional fields users actually fill in. Nothing is counted until Register is called:
+//
+//	if err := metrics.Register(prometheus.DefaultRegisterer); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Decodes are counted for bind.Form, bind.Query, bind.Multipart and httpnull.DecodeBody,
+// labeled with the DTO type name and the source: form, query, multipart or json.
+// Validation failures are counted for DTOs validated with nullvalidate.Struct,
+// nullvalidate.StructPartial, nullvalidate.Finalize or a wizard step. Labels only hold type, field and tag names, never
+// field values.
+package metrics
+
+import (
+	"database/sql/driver"
+	"reflect"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/observe"
+)
+
+// Register registers the metrics of this package with reg and starts counting:
+//
+//   - nullable_decodes_total{dto, source, result} counts decodes by result, ok or failed
+//   - nullable_validation_failures_total{dto, field, tag} counts failed validation tags
+//   - nullable_field_states_total{dto, field, state} counts the state, undefined, null or
+//     set, of each nullable field of successfully decoded DTOs
+//
+// It returns the error of reg if the metrics are already registered with it.
+func Register(reg prometheus.Registerer) error {
+	c := &collector{
+		decodes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "nullable_decodes_total",
+			Help: "Number of DTO decodes by source and result.",
+		}, []string{"dto", "source", "result"}),
+		invalid: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "nullable_validation_failures_total",
+			Help: "Number of DTO fields failing validation by tag.",
+		}, []string{"dto", "field", "tag"}),
+		states: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "nullable_field_states_total",
+			Help: "Number of decoded nullable DTO fields by state.",
+		}, []string{"dto", "field", "state"}),
+	}
+	for _, m := range []prometheus.Collector{c.decodes, c.invalid, c.states} {
+		if err := reg.Register(m); err != nil {
+			return err
+		}
+	}
+	observe.Set(c)
+	return nil
+}
+
+type collector struct {
+	decodes, invalid, states *prometheus.CounterVec
+}
+
+func (c *collector) Decoded(dto reflect.Value, source string, err error) {
+	name := dto.Type().Name()
+	if err != nil {
+		c.decodes.WithLabelValues(name, source, "failed").Inc()
+		return
+	}
+	c.decodes.WithLabelValues(name, source, "ok").Inc()
+	for _, f := range nullreflect.Fields(dto.Type()) {
+		if !isNullable(f.Type) {
+			continue
+		}
+		_, state, err := nullreflect.Read(dto.FieldByIndex(f.Index))
+		if err != nil {
+			continue
+		}
+		c.states.WithLabelValues(name, f.Name, stateLabel(state)).Inc()
+	}
+}
+
+func (c *collector) Invalid(dto, field, tag string) {
+	c.invalid.WithLabelValues(dto, field, tag).Inc()
+}
+
+func stateLabel(s nullstate.State) string {
+	switch s {
+	case nullstate.Undefined:
+		return "undefined"
+	case nullstate.Null:
+		return "null"
+	}
+	return "set"
+}
+
+var valuerType = reflect.TypeFor[driver.Valuer]()
+
+// isNullable reports whether fields of type t can be null, leaving out plain values
+// whose state is always set.
+func isNullable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		return true
+	}
+	return nullreflect.CanBeUndefined(t) || t.Implements(valuerType)
+}
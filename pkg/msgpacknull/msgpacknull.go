@@ -0,0 +1,39 @@
+// Package msgpacknull encodes nullable DTOs in MessagePack for high-throughput batch
+// exports, appending records to a caller-owned buffer instead of allocating one per
+// record:
+//
+//	var buf []byte
+//	for _, row := range rows {
+//		buf, err = msgpacknull.EncodeAppend(buf[:0], row)
+//		if err != nil {
+//			return err
+//		}
+//		if _, err := w.Write(buf); err != nil {
+//			return err
+//		}
+//	}
+//
+// Records are encoded like msgpack.Marshal encodes them, through the EncodeMsgpack
+// methods of the nullable types: null and undefined fields encode as nil, and fields
+// tagged `msgpack:",omitempty"` are left out when undefined.
+package msgpacknull
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// EncodeAppend appends the MessagePack encoding of dto to dst and returns the extended
+// buffer. The encoder is taken from the msgpack package's pool.
+func EncodeAppend(dst []byte, dto any) ([]byte, error) {
+	buf := bytes.NewBuffer(dst)
+	enc := msgpack.GetEncoder()
+	defer msgpack.PutEncoder(enc)
+	enc.Reset(buf)
+	if err := enc.Encode(dto); err != nil {
+		return dst, fmt.Errorf("msgpacknull: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,96 @@
+// Package normalize cleans up string fields of nullable DTOs from struct tags,
+// so handlers stop trimming input by hand before it reaches the database:
+//
+//	type Person struct {
+//		Name  nullable.Optional[string] `norm:"trim,collapse_spaces"`
+//		Email nullable.Null[string]     `norm:"trim,lower"`
+//		Code  string                    `norm:"trim,upper"`
+//	}
+//
+// Only present values are touched; null and undefined fields pass through as they are.
+package normalize
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+var funcs = map[string]func(string) string{
+	"trim":            strings.TrimSpace,
+	"lower":           strings.ToLower,
+	"upper":           strings.ToUpper,
+	"collapse_spaces": collapseSpaces,
+}
+
+// Apply runs the normalizers listed in the `norm` tag of every field of dto,
+// a non-nil pointer to a struct, in tag order. The normalizers are:
+//
+//   - trim: removes leading and trailing white space
+//   - lower, upper: change the case
+//   - collapse_spaces: replaces runs of white space with a single space
+//
+// Tagged fields must hold strings, either plainly or through a nullable type,
+// and only fields whose value is present are normalized.
+func Apply(dto any) error {
+	v := reflect.ValueOf(dto)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("normalize: dto must be a non-nil pointer to a struct, got %T", dto)
+	}
+	v = v.Elem()
+
+	for _, f := range nullreflect.Fields(v.Type()) {
+		tag, ok := f.Tag.Lookup("norm")
+		if !ok || tag == "" {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		dv, state, err := nullreflect.Read(fv)
+		if err != nil {
+			return fmt.Errorf("normalize: field %s: %w", f.Name, err)
+		}
+		if state != nullstate.Present {
+			continue
+		}
+		s, ok := dv.(string)
+		if !ok {
+			return fmt.Errorf("normalize: field %s: cannot normalize %s", f.Name, f.Type)
+		}
+		for _, name := range strings.Split(tag, ",") {
+			fn, ok := funcs[strings.TrimSpace(name)]
+			if !ok {
+				return fmt.Errorf("normalize: field %s: unknown normalizer %q", f.Name, name)
+			}
+			s = fn(s)
+		}
+		if err := nullreflect.Write(fv, s, nullstate.Present); err != nil {
+			return fmt.Errorf("normalize: field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func collapseSpaces(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	space := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
@@ -0,0 +1,56 @@
+package nullable
+
+import "sync/atomic"
+
+// Atomic is a Null[T] that is safe for concurrent use, for shared caches of
+// optional values such as lazily resolved display names.
+// The zero value holds null and is ready to use. An Atomic must not be copied after first use.
+type Atomic[T comparable] struct {
+	p atomic.Pointer[Null[T]]
+}
+
+// NewAtomic creates a new Atomic holding n.
+func NewAtomic[T comparable](n Null[T]) *Atomic[T] {
+	a := new(Atomic[T])
+	a.Store(n)
+	return a
+}
+
+// Load returns the value held by a.
+func (a *Atomic[T]) Load() Null[T] {
+	if p := a.p.Load(); p != nil {
+		return *p
+	}
+	return Null[T]{}
+}
+
+// Store sets the value held by a to n.
+func (a *Atomic[T]) Store(n Null[T]) {
+	a.p.Store(&n)
+}
+
+// Swap sets the value held by a to n and returns the previous value.
+func (a *Atomic[T]) Swap(n Null[T]) Null[T] {
+	if p := a.p.Swap(&n); p != nil {
+		return *p
+	}
+	return Null[T]{}
+}
+
+// CompareAndSwap sets the value held by a to new if it currently equals old,
+// as reported by Equal, and reports whether it did. Any null equals any other null.
+func (a *Atomic[T]) CompareAndSwap(old, new Null[T]) bool {
+	for {
+		p := a.p.Load()
+		cur := Null[T]{}
+		if p != nil {
+			cur = *p
+		}
+		if !Equal(cur, old) {
+			return false
+		}
+		if a.p.CompareAndSwap(p, &new) {
+			return true
+		}
+	}
+}
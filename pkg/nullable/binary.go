@@ -0,0 +1,387 @@
+package nullable
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/shopspring/decimal"
+)
+
+// Binary encodings start with one of these state bytes, followed by the value if present.
+// Implementing encoding.BinaryMarshaler also makes the nullable types usable with
+// encoding/gob, which prefers it over encoding their fields, so DTOs can be stored in
+// gob-based session stores or sent over net/rpc.
+const (
+	binaryNull byte = iota
+	binaryPresent
+	binaryUndefined
+)
+
+var errBinary = errors.New("nullable: couldn't unmarshal binary: invalid data")
+
+// marshalBinary encodes the state byte and, if valid, v.
+func marshalBinary(valid bool, v any) ([]byte, error) {
+	if !valid {
+		return []byte{binaryNull}, nil
+	}
+	b, err := appendBinary([]byte{binaryPresent}, v)
+	if err != nil {
+		return nil, fmt.Errorf("nullable: couldn't marshal binary: %w", err)
+	}
+	return b, nil
+}
+
+// unmarshalBinary decodes data produced by marshalBinary into p, reporting whether
+// it held a value. p is left untouched for null and undefined data.
+func unmarshalBinary(data []byte, p any) (valid bool, err error) {
+	if len(data) == 0 {
+		return false, errBinary
+	}
+	switch data[0] {
+	case binaryNull, binaryUndefined:
+		if len(data) != 1 {
+			return false, errBinary
+		}
+		return false, nil
+	case binaryPresent:
+		if err := parseBinary(data[1:], p); err != nil {
+			return false, fmt.Errorf("nullable: couldn't unmarshal binary: %w", err)
+		}
+		return true, nil
+	}
+	return false, errBinary
+}
+
+// appendBinary appends the encoding of v: its own binary encoding if it implements
+// encoding.BinaryMarshaler, a fixed or variable length encoding for booleans and numbers,
+// the bytes of strings and byte slices, and JSON for anything else.
+func appendBinary(b []byte, v any) ([]byte, error) {
+	if bm, ok := v.(encoding.BinaryMarshaler); ok {
+		data, err := bm.MarshalBinary()
+		return append(b, data...), err
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			return append(b, 1), nil
+		}
+		return append(b, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(b, rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.AppendUvarint(b, rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(rv.Float())), nil
+	case reflect.String:
+		return append(b, rv.String()...), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return append(b, rv.Bytes()...), nil
+		}
+	}
+	data, err := json.Marshal(v)
+	return append(b, data...), err
+}
+
+// parseBinary decodes data produced by appendBinary into the value p points to.
+func parseBinary(data []byte, p any) error {
+	if bu, ok := p.(encoding.BinaryUnmarshaler); ok {
+		return bu.UnmarshalBinary(data)
+	}
+	rv := reflect.ValueOf(p).Elem()
+	switch rv.Kind() {
+	case reflect.Bool:
+		if len(data) != 1 {
+			return errors.New("invalid boolean")
+		}
+		rv.SetBool(data[0] != 0)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, n := binary.Varint(data)
+		if n != len(data) || n == 0 || rv.OverflowInt(x) {
+			return errors.New("invalid integer")
+		}
+		rv.SetInt(x)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		x, n := binary.Uvarint(data)
+		if n != len(data) || n == 0 || rv.OverflowUint(x) {
+			return errors.New("invalid integer")
+		}
+		rv.SetUint(x)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if len(data) != 8 {
+			return errors.New("invalid float")
+		}
+		rv.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+		return nil
+	case reflect.String:
+		rv.SetString(string(data))
+		return nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			rv.SetBytes(append([]byte{}, data...))
+			return nil
+		}
+	}
+	return json.Unmarshal(data, p)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (n Null[T]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(n.Valid, n.V)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// Undefined data, as encoded by Optional, decodes to null.
+func (n *Null[T]) UnmarshalBinary(data []byte) error {
+	var v T
+	valid, err := unmarshalBinary(data, &v)
+	if err != nil {
+		return err
+	}
+	*n = Null[T]{V: v, Valid: valid}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// Unlike the text and JSON encodings, it keeps undefined apart from null.
+func (o Optional[T]) MarshalBinary() ([]byte, error) {
+	if !o.Defined {
+		return []byte{binaryUndefined}, nil
+	}
+	return o.Null().MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (o *Optional[T]) UnmarshalBinary(data []byte) error {
+	if len(data) == 1 && data[0] == binaryUndefined {
+		*o = Optional[T]{}
+		return nil
+	}
+	var n Null[T]
+	if err := n.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	*o = OptionalOf(n)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (e Enum[T]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(e.Valid, string(e.V))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It supports null and registered values.
+func (e *Enum[T]) UnmarshalBinary(data []byte) error {
+	var s string
+	valid, err := unmarshalBinary(data, &s)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		*e = Enum[T]{}
+		return nil
+	}
+	v, err := ParseEnum[T](s)
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (d Date) MarshalBinary() ([]byte, error) {
+	return marshalBinary(d.Valid, d.String())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (d *Date) UnmarshalBinary(data []byte) error {
+	var s string
+	valid, err := unmarshalBinary(data, &s)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		*d = Date{}
+		return nil
+	}
+	return d.parse(s)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (d Decimal) MarshalBinary() ([]byte, error) {
+	return marshalBinary(d.Valid, d.Decimal)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (d *Decimal) UnmarshalBinary(data []byte) error {
+	var v decimal.Decimal
+	valid, err := unmarshalBinary(data, &v)
+	if err != nil {
+		return err
+	}
+	*d = Decimal{Decimal: v, Valid: valid}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (u UUID) MarshalBinary() ([]byte, error) {
+	return marshalBinary(u.Valid, u.Bytes[:])
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (u *UUID) UnmarshalBinary(data []byte) error {
+	var b []byte
+	valid, err := unmarshalBinary(data, &b)
+	if err != nil {
+		return err
+	}
+	if valid && len(b) != len(u.Bytes) {
+		return errBinary
+	}
+	*u = UUID{Valid: valid}
+	copy(u.Bytes[:], b)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (u Uinfin) MarshalBinary() ([]byte, error) {
+	return marshalBinary(u.Valid, u.V)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It does not check the decoded value.
+func (u *Uinfin) UnmarshalBinary(data []byte) error {
+	var s string
+	valid, err := unmarshalBinary(data, &s)
+	if err != nil {
+		return err
+	}
+	*u = Uinfin{V: s, Valid: valid}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (j JSON) MarshalBinary() ([]byte, error) {
+	return marshalBinary(j.Valid, []byte(j.RawMessage))
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (j *JSON) UnmarshalBinary(data []byte) error {
+	var raw []byte
+	valid, err := unmarshalBinary(data, &raw)
+	if err != nil {
+		return err
+	}
+	*j = JSON{RawMessage: raw, Valid: valid}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. Present values are stored as JSON.
+func (j JSONOf[T]) MarshalBinary() ([]byte, error) {
+	return marshalJSONBinary(j.Valid, j.V)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (j *JSONOf[T]) UnmarshalBinary(data []byte) error {
+	var v T
+	valid, err := unmarshalJSONBinary(data, &v)
+	if err != nil {
+		return err
+	}
+	*j = JSONOf[T]{V: v, Valid: valid}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. Present values are stored as JSON.
+func (s Slice[T]) MarshalBinary() ([]byte, error) {
+	return marshalJSONBinary(s.Valid, s.V)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *Slice[T]) UnmarshalBinary(data []byte) error {
+	var v []T
+	valid, err := unmarshalJSONBinary(data, &v)
+	if err != nil {
+		return err
+	}
+	*s = Slice[T]{V: v, Valid: valid}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. Present values are stored as JSON.
+func (m MapOf[K, V]) MarshalBinary() ([]byte, error) {
+	return marshalJSONBinary(m.Valid, m.V)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (m *MapOf[K, V]) UnmarshalBinary(data []byte) error {
+	var v map[K]V
+	valid, err := unmarshalJSONBinary(data, &v)
+	if err != nil {
+		return err
+	}
+	*m = MapOf[K, V]{V: v, Valid: valid}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. Present values are stored in their JSON form.
+func (r Range[T]) MarshalBinary() ([]byte, error) {
+	if !r.Valid {
+		return []byte{binaryNull}, nil
+	}
+	data, err := r.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("nullable: couldn't marshal binary: %w", err)
+	}
+	return append([]byte{binaryPresent}, data...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (r *Range[T]) UnmarshalBinary(data []byte) error {
+	var raw []byte
+	valid, err := unmarshalBinary(data, &raw)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		*r = Range[T]{}
+		return nil
+	}
+	return r.UnmarshalJSON(raw)
+}
+
+// marshalJSONBinary is like marshalBinary, always storing v as JSON
+// so that collections of any element type round-trip.
+func marshalJSONBinary(valid bool, v any) ([]byte, error) {
+	if !valid {
+		return []byte{binaryNull}, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("nullable: couldn't marshal binary: %w", err)
+	}
+	return append([]byte{binaryPresent}, data...), nil
+}
+
+// unmarshalJSONBinary decodes data produced by marshalJSONBinary into p.
+func unmarshalJSONBinary(data []byte, p any) (valid bool, err error) {
+	var raw []byte
+	if valid, err = unmarshalBinary(data, &raw); err != nil || !valid {
+		return valid, err
+	}
+	if err := json.Unmarshal(raw, p); err != nil {
+		return false, fmt.Errorf("nullable: couldn't unmarshal binary: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,101 @@
+package nullable
+
+import (
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Bool is a nullable bool, such as a PostgreSQL boolean column, for flags that are
+// genuinely tri-state: true, false, or unknown if null. It embeds Null[bool], so it
+// marshals to true, false or null, and adds SQL-style three-valued logic with And, Or and Not.
+type Bool struct {
+	Null[bool]
+}
+
+// NewBool creates a new Bool.
+func NewBool(b bool, valid bool) Bool {
+	return Bool{New(b, valid)}
+}
+
+// BoolFrom creates a new Bool that will always be valid.
+func BoolFrom(b bool) Bool {
+	return NewBool(b, true)
+}
+
+// BoolFromPg creates a new Bool from b.
+func BoolFromPg(b pgtype.Bool) Bool {
+	return NewBool(b.Bool, b.Valid)
+}
+
+// Pg returns b as a pgtype.Bool.
+func (b Bool) Pg() pgtype.Bool {
+	return pgtype.Bool{Bool: b.V, Valid: b.Valid}
+}
+
+// IsTrue reports whether b is valid and true.
+func (b Bool) IsTrue() bool {
+	return b.Valid && b.V
+}
+
+// IsFalse reports whether b is valid and false.
+func (b Bool) IsFalse() bool {
+	return b.Valid && !b.V
+}
+
+// IsUnknown reports whether b is null.
+func (b Bool) IsUnknown() bool {
+	return !b.Valid
+}
+
+// And returns b AND b2 as SQL evaluates it: false if either is false,
+// otherwise unknown if either is unknown, otherwise true.
+func (b Bool) And(b2 Bool) Bool {
+	if b.IsFalse() || b2.IsFalse() {
+		return BoolFrom(false)
+	}
+	if !b.Valid || !b2.Valid {
+		return Bool{}
+	}
+	return BoolFrom(true)
+}
+
+// Or returns b OR b2 as SQL evaluates it: true if either is true,
+// otherwise unknown if either is unknown, otherwise false.
+func (b Bool) Or(b2 Bool) Bool {
+	if b.IsTrue() || b2.IsTrue() {
+		return BoolFrom(true)
+	}
+	if !b.Valid || !b2.Valid {
+		return Bool{}
+	}
+	return BoolFrom(false)
+}
+
+// Not returns NOT b as SQL evaluates it: unknown stays unknown.
+func (b Bool) Not() Bool {
+	if !b.Valid {
+		return Bool{}
+	}
+	return BoolFrom(!b.V)
+}
+
+// ScanBool implements pgtype.BoolScanner, so pgx decodes boolean columns directly.
+func (b *Bool) ScanBool(v pgtype.Bool) error {
+	*b = BoolFromPg(v)
+	return nil
+}
+
+// BoolValue implements pgtype.BoolValuer, so pgx encodes b as a boolean.
+func (b Bool) BoolValue() (pgtype.Bool, error) {
+	return b.Pg(), nil
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating b, such as nullable.BoolFrom(true), for %#v.
+func (b Bool) GoString() string {
+	if !b.Valid {
+		return "nullable.Bool{}"
+	}
+	return fmt.Sprintf("nullable.BoolFrom(%t)", b.V)
+}
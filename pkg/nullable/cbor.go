@@ -0,0 +1,63 @@
+package nullable
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// cborNull is the CBOR encoding of null, simple value 22.
+var cborNull = []byte{0xf6}
+
+// cborUndefined is the CBOR encoding of undefined, simple value 23.
+var cborUndefined = []byte{0xf7}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It will encode null if this value is null.
+func (n Null[T]) MarshalCBOR() ([]byte, error) {
+	if !n.Valid {
+		return cborNull, nil
+	}
+	return cbor.Marshal(n.V)
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// It supports null, undefined and any input that T itself can be decoded from.
+func (n *Null[T]) UnmarshalCBOR(data []byte) error {
+	if bytes.Equal(data, cborNull) || bytes.Equal(data, cborUndefined) {
+		*n = Null[T]{}
+		return nil
+	}
+	var v T
+	if err := cbor.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal CBOR: %w", err)
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler.
+// It will encode null if this value is null and undefined if this value is undefined;
+// use omitzero to leave undefined values out entirely.
+func (o Optional[T]) MarshalCBOR() ([]byte, error) {
+	if !o.Defined {
+		return cborUndefined, nil
+	}
+	return o.Null().MarshalCBOR()
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler.
+// CBOR undefined leaves o undefined, while null and values mark it as defined.
+func (o *Optional[T]) UnmarshalCBOR(data []byte) error {
+	if bytes.Equal(data, cborUndefined) {
+		*o = Optional[T]{}
+		return nil
+	}
+	var n Null[T]
+	if err := n.UnmarshalCBOR(data); err != nil {
+		return err
+	}
+	*o = OptionalOf(n)
+	return nil
+}
@@ -0,0 +1,63 @@
+package nullable
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// Coalesce returns the first valid value of vals, or null if there is none.
+func Coalesce[T comparable](vals ...Null[T]) Null[T] {
+	for _, v := range vals {
+		if v.Valid {
+			return v
+		}
+	}
+	return Null[T]{}
+}
+
+// OrElse returns the value of n if valid, otherwise fallback.
+func OrElse[T comparable](n Null[T], fallback T) T {
+	return n.ValueOr(fallback)
+}
+
+// CoalesceStruct fills the null or undefined fields of primary with the
+// same-named fields of secondary, leaving fields that already hold a value untouched.
+// It lets user corrections be layered over defaults sourced elsewhere.
+//
+// primary must be a non-nil pointer to a struct, secondary a struct or a pointer to one.
+func CoalesceStruct(primary, secondary any) error {
+	pv := reflect.ValueOf(primary)
+	if pv.Kind() != reflect.Pointer || pv.IsNil() || pv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("nullable: primary must be a non-nil pointer to a struct, got %T", primary)
+	}
+	pv = pv.Elem()
+	sv := reflect.Indirect(reflect.ValueOf(secondary))
+	if sv.Kind() != reflect.Struct {
+		return fmt.Errorf("nullable: secondary must be a struct, got %T", secondary)
+	}
+
+	for _, p := range nullreflect.Pairs(sv.Type(), pv.Type()) {
+		dst := pv.FieldByIndex(p.Dst)
+		_, state, err := nullreflect.Read(dst)
+		if err != nil {
+			return fmt.Errorf("nullable: field %s: %w", p.Name, err)
+		}
+		if state == nullstate.Present {
+			continue
+		}
+		val, state, err := nullreflect.Read(sv.FieldByIndex(p.Src))
+		if err != nil {
+			return fmt.Errorf("nullable: field %s: %w", p.Name, err)
+		}
+		if state != nullstate.Present {
+			continue
+		}
+		if err := nullreflect.Write(dst, val, state); err != nil {
+			return fmt.Errorf("nullable: field %s: %w", p.Name, err)
+		}
+	}
+	return nil
+}
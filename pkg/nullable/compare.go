@@ -0,0 +1,40 @@
+package nullable
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Compare returns -1, 0 or +1 depending on whether a sorts before, with or after b.
+// Valid values are ordered with cmp.Compare; two nulls are equal, and nulls sort
+// after every value if nullsLast is set, before otherwise. PostgreSQL defaults to
+// NULLS LAST for ascending and NULLS FIRST for descending orders.
+func Compare[T cmp.Ordered](a, b Null[T], nullsLast bool) int {
+	switch {
+	case !a.Valid && !b.Valid:
+		return 0
+	case !a.Valid:
+		if nullsLast {
+			return 1
+		}
+		return -1
+	case !b.Valid:
+		if nullsLast {
+			return -1
+		}
+		return 1
+	}
+	return cmp.Compare(a.V, b.V)
+}
+
+// CompareFunc returns Compare with nullsLast fixed, for use with slices.SortFunc and friends.
+func CompareFunc[T cmp.Ordered](nullsLast bool) func(a, b Null[T]) int {
+	return func(a, b Null[T]) int {
+		return Compare(a, b, nullsLast)
+	}
+}
+
+// Sort sorts s in ascending order, placing nulls as Compare does. The sort is stable.
+func Sort[T cmp.Ordered](s []Null[T], nullsLast bool) {
+	slices.SortStableFunc(s, CompareFunc[T](nullsLast))
+}
@@ -0,0 +1,189 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Date is a nullable calendar date, without a time of day or location.
+// It supports SQL, pgx, JSON and text serialization using the "2006-01-02" layout,
+// plus PostgreSQL's "infinity" and "-infinity" dates.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+	// InfinityModifier marks the infinity and -infinity dates, whose other fields are zero.
+	InfinityModifier pgtype.InfinityModifier
+	Valid            bool
+}
+
+// NewDate creates a new valid Date, normalizing out of range values like time.Date does.
+func NewDate(year int, month time.Month, day int) Date {
+	return DateOf(time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+}
+
+// DateOf creates a new valid Date from the calendar date of t in its own location.
+func DateOf(t time.Time) Date {
+	y, m, d := t.Date()
+	return Date{Year: y, Month: m, Day: d, Valid: true}
+}
+
+// DateFromPg creates a new Date from d.
+func DateFromPg(d pgtype.Date) Date {
+	if !d.Valid {
+		return Date{}
+	}
+	if d.InfinityModifier != pgtype.Finite {
+		return Date{InfinityModifier: d.InfinityModifier, Valid: true}
+	}
+	return DateOf(d.Time)
+}
+
+// Pg returns d as a pgtype.Date.
+func (d Date) Pg() pgtype.Date {
+	if !d.Valid {
+		return pgtype.Date{}
+	}
+	if d.InfinityModifier != pgtype.Finite {
+		return pgtype.Date{InfinityModifier: d.InfinityModifier, Valid: true}
+	}
+	return pgtype.Date{Time: d.Time(), Valid: true}
+}
+
+// Time returns midnight UTC of d, or the zero time if d is null or infinite.
+func (d Date) Time() time.Time {
+	if !d.Valid || d.InfinityModifier != pgtype.Finite {
+		return time.Time{}
+	}
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// String returns d in the "2006-01-02" layout, "infinity", "-infinity", or <null> if null.
+func (d Date) String() string {
+	switch {
+	case !d.Valid:
+		return NullPlaceholder
+	case d.InfinityModifier == pgtype.Infinity:
+		return "infinity"
+	case d.InfinityModifier == pgtype.NegativeInfinity:
+		return "-infinity"
+	}
+	return d.Time().Format(time.DateOnly)
+}
+
+// IsZero returns true for null values.
+// It lets encoding/json omit null fields tagged with omitzero.
+func (d Date) IsZero() bool {
+	return !d.Valid
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this value is null.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports null, "2006-01-02", "infinity" and "-infinity".
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		*d = Date{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal JSON: %w", err)
+	}
+	return d.parse(s)
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating d, such as nullable.NewDate(2024, time.January, 2), for %#v.
+func (d Date) GoString() string {
+	switch {
+	case !d.Valid:
+		return "nullable.Date{}"
+	case d.InfinityModifier != pgtype.Finite:
+		return fmt.Sprintf("nullable.Date{InfinityModifier: %d, Valid: true}", d.InfinityModifier)
+	}
+	return fmt.Sprintf("nullable.NewDate(%d, time.%s, %d)", d.Year, d.Month, d.Day)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode NullText if this value is null.
+func (d Date) MarshalText() ([]byte, error) {
+	if !d.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It supports NullText, the empty string, "2006-01-02", "infinity" and "-infinity".
+func (d *Date) UnmarshalText(text []byte) error {
+	if len(text) == 0 || string(text) == NullText {
+		*d = Date{}
+		return nil
+	}
+	return d.parse(string(text))
+}
+
+func (d *Date) parse(s string) error {
+	switch s {
+	case "infinity":
+		*d = Date{InfinityModifier: pgtype.Infinity, Valid: true}
+		return nil
+	case "-infinity":
+		*d = Date{InfinityModifier: pgtype.NegativeInfinity, Valid: true}
+		return nil
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return fmt.Errorf("nullable: couldn't parse date %q: %w", s, err)
+	}
+	*d = DateOf(t)
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It supports times and dates as text or bytes, as returned by lib/pq and MySQL.
+func (d *Date) Scan(value any) error {
+	if b, ok := value.([]byte); ok {
+		value = string(b)
+	}
+	var pd pgtype.Date
+	if err := pd.Scan(value); err != nil {
+		return err
+	}
+	*d = DateFromPg(pd)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (d Date) Value() (driver.Value, error) {
+	return d.Pg().Value()
+}
+
+// ScanDate implements pgtype.DateScanner, so pgx decodes dates without a time of day.
+func (d *Date) ScanDate(v pgtype.Date) error {
+	*d = DateFromPg(v)
+	return nil
+}
+
+// DateValue implements pgtype.DateValuer, so pgx encodes d as a date.
+func (d Date) DateValue() (pgtype.Date, error) {
+	return d.Pg(), nil
+}
+
+// GormDataType reports the column type of Date to GORM.
+func (Date) GormDataType() string {
+	return "date"
+}
@@ -0,0 +1,230 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// DecimalJSONNumber makes Decimal marshal to a JSON number instead of a string.
+// Strings are the default because many JSON decoders parse numbers into float64,
+// losing precision. Unmarshaling accepts both regardless of this setting.
+// It should only be changed during initialization.
+var DecimalJSONNumber = false
+
+// Decimal is a nullable arbitrary precision decimal backed by github.com/shopspring/decimal.
+// It supports SQL, pgx, JSON and text serialization. Arithmetic on Decimal propagates
+// null like SQL does, instead of treating null as zero.
+type Decimal struct {
+	Decimal decimal.Decimal
+	Valid   bool
+}
+
+// DecimalFrom creates a new Decimal that will always be valid.
+func DecimalFrom(d decimal.Decimal) Decimal {
+	return Decimal{Decimal: d, Valid: true}
+}
+
+// ParseDecimal parses s in any of the forms accepted by decimal.NewFromString.
+func ParseDecimal(s string) (Decimal, error) {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("nullable: couldn't parse decimal %q: %w", s, err)
+	}
+	return DecimalFrom(d), nil
+}
+
+// DecimalFromPg creates a new Decimal from n.
+// NaN and infinite numerics cannot be represented and return an error.
+func DecimalFromPg(n pgtype.Numeric) (Decimal, error) {
+	if !n.Valid {
+		return Decimal{}, nil
+	}
+	if n.NaN || n.InfinityModifier != pgtype.Finite {
+		return Decimal{}, errors.New("nullable: couldn't convert NaN or infinite numeric to decimal")
+	}
+	if n.Int == nil {
+		return DecimalFrom(decimal.Zero), nil
+	}
+	return DecimalFrom(decimal.NewFromBigInt(n.Int, n.Exp)), nil
+}
+
+// Pg returns d as a pgtype.Numeric.
+func (d Decimal) Pg() pgtype.Numeric {
+	if !d.Valid {
+		return pgtype.Numeric{}
+	}
+	return pgtype.Numeric{Int: d.Decimal.Coefficient(), Exp: d.Decimal.Exponent(), Valid: true}
+}
+
+// ValueOrZero returns the inner value if valid, otherwise zero.
+func (d Decimal) ValueOrZero() decimal.Decimal {
+	if !d.Valid {
+		return decimal.Zero
+	}
+	return d.Decimal
+}
+
+// String returns d without exponent notation, or <null> if null.
+func (d Decimal) String() string {
+	if !d.Valid {
+		return NullPlaceholder
+	}
+	return d.Decimal.String()
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating d, such as nullable.DecimalFrom(decimal.RequireFromString("1.5")), for %#v.
+func (d Decimal) GoString() string {
+	if !d.Valid {
+		return "nullable.Decimal{}"
+	}
+	return fmt.Sprintf("nullable.DecimalFrom(decimal.RequireFromString(%q))", d.Decimal.String())
+}
+
+// IsZero returns true for null values.
+// It lets encoding/json omit null fields tagged with omitzero.
+func (d Decimal) IsZero() bool {
+	return !d.Valid
+}
+
+// Add returns d + d2, or null if either is null.
+func (d Decimal) Add(d2 Decimal) Decimal {
+	if !d.Valid || !d2.Valid {
+		return Decimal{}
+	}
+	return DecimalFrom(d.Decimal.Add(d2.Decimal))
+}
+
+// Sub returns d - d2, or null if either is null.
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	if !d.Valid || !d2.Valid {
+		return Decimal{}
+	}
+	return DecimalFrom(d.Decimal.Sub(d2.Decimal))
+}
+
+// Mul returns d * d2, or null if either is null.
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	if !d.Valid || !d2.Valid {
+		return Decimal{}
+	}
+	return DecimalFrom(d.Decimal.Mul(d2.Decimal))
+}
+
+// ErrDivisionByZero is returned by Decimal.Div for a zero divisor.
+var ErrDivisionByZero = errors.New("nullable: division by zero")
+
+// Div returns d / d2 rounded to decimal.DivisionPrecision digits, or null if either
+// is null. Like PostgreSQL, it returns ErrDivisionByZero if d2 is zero.
+func (d Decimal) Div(d2 Decimal) (Decimal, error) {
+	if !d.Valid || !d2.Valid {
+		return Decimal{}, nil
+	}
+	if d2.Decimal.IsZero() {
+		return Decimal{}, ErrDivisionByZero
+	}
+	return DecimalFrom(d.Decimal.Div(d2.Decimal)), nil
+}
+
+// Neg returns -d, or null if d is null.
+func (d Decimal) Neg() Decimal {
+	if !d.Valid {
+		return Decimal{}
+	}
+	return DecimalFrom(d.Decimal.Neg())
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this value is null, and a string unless DecimalJSONNumber is set.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return []byte("null"), nil
+	}
+	if DecimalJSONNumber {
+		return []byte(d.Decimal.String()), nil
+	}
+	return json.Marshal(d.Decimal.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports null, numbers and numeric strings.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		*d = Decimal{}
+		return nil
+	}
+	var v decimal.Decimal
+	if err := v.UnmarshalJSON(data); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal JSON: %w", err)
+	}
+	*d = DecimalFrom(v)
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode NullText if this value is null.
+func (d Decimal) MarshalText() ([]byte, error) {
+	if !d.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It supports NullText, the empty string and any of the forms accepted by decimal.NewFromString.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	if len(text) == 0 || string(text) == NullText {
+		*d = Decimal{}
+		return nil
+	}
+	v, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (d *Decimal) Scan(value any) error {
+	var nd decimal.NullDecimal
+	if err := nd.Scan(value); err != nil {
+		return err
+	}
+	d.Decimal, d.Valid = nd.Decimal, nd.Valid
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (d Decimal) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Decimal.String(), nil
+}
+
+// ScanNumeric implements pgtype.NumericScanner, so pgx decodes numeric columns without floats.
+func (d *Decimal) ScanNumeric(v pgtype.Numeric) error {
+	n, err := DecimalFromPg(v)
+	if err != nil {
+		return err
+	}
+	*d = n
+	return nil
+}
+
+// NumericValue implements pgtype.NumericValuer, so pgx encodes d as a numeric.
+func (d Decimal) NumericValue() (pgtype.Numeric, error) {
+	return d.Pg(), nil
+}
+
+// GormDataType reports the column type of Decimal to GORM.
+func (Decimal) GormDataType() string {
+	return "decimal"
+}
@@ -0,0 +1,187 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var enumValues sync.Map // reflect.Type -> []string
+
+// RegisterEnum sets the values accepted by Enum[T]. Until values are registered
+// for T, Enum[T] accepts any string. It should be called during initialization.
+func RegisterEnum[T ~string](values ...T) {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = string(v)
+	}
+	enumValues.Store(reflect.TypeFor[T](), s)
+}
+
+// EnumValues returns the values registered for T, or nil if none were.
+func EnumValues[T ~string]() []T {
+	s, ok := enumValues.Load(reflect.TypeFor[T]())
+	if !ok {
+		return nil
+	}
+	values := make([]T, len(s.([]string)))
+	for i, v := range s.([]string) {
+		values[i] = T(v)
+	}
+	return values
+}
+
+// EnumError is returned when a value is not one of the values registered for an Enum.
+type EnumError struct {
+	Type    string
+	Value   string
+	Allowed []string
+}
+
+func (e *EnumError) Error() string {
+	quoted := make([]string, len(e.Allowed))
+	for i, v := range e.Allowed {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprintf("nullable: invalid %s %q, must be one of %s", e.Type, e.Value, strings.Join(quoted, ", "))
+}
+
+func checkEnum[T ~string](v T) error {
+	t := reflect.TypeFor[T]()
+	s, ok := enumValues.Load(t)
+	if !ok || slices.Contains(s.([]string), string(v)) {
+		return nil
+	}
+	return &EnumError{Type: t.Name(), Value: string(v), Allowed: slices.Clone(s.([]string))}
+}
+
+// Enum is a nullable string enum such as a marital status. Decoding and scanning
+// reject values that are not registered with RegisterEnum, returning an *EnumError.
+type Enum[T ~string] struct {
+	V     T
+	Valid bool
+}
+
+// EnumFrom creates a new Enum that will always be valid. It does not check v.
+func EnumFrom[T ~string](v T) Enum[T] {
+	return Enum[T]{V: v, Valid: true}
+}
+
+// ParseEnum creates a new valid Enum from s, checking it against the registered values.
+func ParseEnum[T ~string](s string) (Enum[T], error) {
+	if err := checkEnum(T(s)); err != nil {
+		return Enum[T]{}, err
+	}
+	return EnumFrom(T(s)), nil
+}
+
+// Check returns an *EnumError if e holds a value that is not registered.
+func (e Enum[T]) Check() error {
+	if !e.Valid {
+		return nil
+	}
+	return checkEnum(e.V)
+}
+
+// ValueOrZero returns the inner value if valid, otherwise zero.
+func (e Enum[T]) ValueOrZero() T {
+	if !e.Valid {
+		return ""
+	}
+	return e.V
+}
+
+// IsZero returns true for null values.
+// It lets encoding/json omit null fields tagged with omitzero.
+func (e Enum[T]) IsZero() bool {
+	return !e.Valid
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this value is null.
+func (e Enum[T]) MarshalJSON() ([]byte, error) {
+	if !e.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(string(e.V))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports null and registered values.
+func (e *Enum[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		*e = Enum[T]{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal JSON: %w", err)
+	}
+	v, err := ParseEnum[T](s)
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode NullText if this value is null.
+func (e Enum[T]) MarshalText() ([]byte, error) {
+	if !e.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(e.V), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It supports NullText, the empty string and registered values.
+func (e *Enum[T]) UnmarshalText(text []byte) error {
+	if len(text) == 0 || string(text) == NullText {
+		*e = Enum[T]{}
+		return nil
+	}
+	v, err := ParseEnum[T](string(text))
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It supports null and registered values.
+func (e *Enum[T]) Scan(value any) error {
+	var s sql.NullString
+	if err := s.Scan(value); err != nil {
+		return err
+	}
+	if !s.Valid {
+		*e = Enum[T]{}
+		return nil
+	}
+	v, err := ParseEnum[T](s.String)
+	if err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// It returns an *EnumError instead of writing a value that is not registered.
+func (e Enum[T]) Value() (driver.Value, error) {
+	if !e.Valid {
+		return nil, nil
+	}
+	if err := e.Check(); err != nil {
+		return nil, err
+	}
+	return string(e.V), nil
+}
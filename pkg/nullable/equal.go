@@ -0,0 +1,20 @@
+package nullable
+
+// Equal reports whether a and b are both null, or both valid with equal values.
+// The value of a null is ignored. Values with an Equal(T) bool method, such as
+// time.Time, are compared with it instead of ==.
+func Equal[T comparable](a, b Null[T]) bool {
+	if !a.Valid || !b.Valid {
+		return a.Valid == b.Valid
+	}
+	if eq, ok := any(a.V).(interface{ Equal(T) bool }); ok {
+		return eq.Equal(b.V)
+	}
+	return a.V == b.V
+}
+
+// EqualOptional reports whether a and b are in the same state and, if present,
+// hold equal values as reported by Equal. Undefined is not equal to null.
+func EqualOptional[T comparable](a, b Optional[T]) bool {
+	return a.Defined == b.Defined && Equal(a.Null(), b.Null())
+}
@@ -0,0 +1,189 @@
+package nullable
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// String implements fmt.Stringer.
+// It returns the inner value formatted with %v, or <null> if null.
+func (n Null[T]) String() string {
+	if !n.Valid {
+		return NullPlaceholder
+	}
+	return fmt.Sprint(n.V)
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating n, such as nullable.From("a"), for %#v.
+func (n Null[T]) GoString() string {
+	if !n.Valid {
+		return fmt.Sprintf("%T{}", n)
+	}
+	return goCall("From", n.V)
+}
+
+// String implements fmt.Stringer.
+// It returns the inner value formatted with %v, <null> if null, or <undefined> if undefined.
+func (o Optional[T]) String() string {
+	if !o.Defined {
+		return UndefinedPlaceholder
+	}
+	return o.Null().String()
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating o, such as nullable.OptionalFrom("a"), for %#v.
+func (o Optional[T]) GoString() string {
+	switch {
+	case !o.Defined:
+		return fmt.Sprintf("%T{}", o)
+	case !o.Valid:
+		return fmt.Sprintf("nullable.OptionalNull[%s]()", reflect.TypeFor[T]())
+	}
+	return goCall("OptionalFrom", o.V)
+}
+
+// goCall returns a call of the nullable constructor name with v as its argument.
+// The type argument is spelled out unless the constructor would infer it from the literal.
+func goCall[T any](name string, v T) string {
+	t := reflect.TypeFor[T]()
+	lit := fmt.Sprintf("%#v", v)
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Int, reflect.Float64:
+		if t.PkgPath() == "" {
+			return fmt.Sprintf("nullable.%s(%s)", name, lit)
+		}
+	case reflect.Struct, reflect.Slice, reflect.Map, reflect.Array:
+		// Composite literals, and calls like time.Date, carry their type.
+		return fmt.Sprintf("nullable.%s(%s)", name, lit)
+	}
+	return fmt.Sprintf("nullable.%s[%s](%s)", name, t, lit)
+}
+
+// String implements fmt.Stringer.
+// It returns the inner value, or <null> if null.
+func (e Enum[T]) String() string {
+	if !e.Valid {
+		return NullPlaceholder
+	}
+	return string(e.V)
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating e, such as nullable.EnumFrom(Status("active")), for %#v.
+func (e Enum[T]) GoString() string {
+	if !e.Valid {
+		return fmt.Sprintf("%T{}", e)
+	}
+	return fmt.Sprintf("nullable.EnumFrom(%s(%q))", reflect.TypeFor[T](), string(e.V))
+}
+
+// String implements fmt.Stringer.
+// It returns the time formatted by time.Time.String, or <null> if null.
+func (t Time) String() string {
+	return t.Null.String()
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating t, such as nullable.TimeFrom(time.Date(…)), for %#v.
+func (t Time) GoString() string {
+	if !t.Valid {
+		return "nullable.Time{}"
+	}
+	return fmt.Sprintf("nullable.TimeFrom(%#v)", t.V)
+}
+
+// String implements fmt.Stringer.
+// It returns the JSON document, or <null> if null.
+func (j JSON) String() string {
+	if !j.Valid {
+		return NullPlaceholder
+	}
+	return string(j.RawMessage)
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating j, such as nullable.JSONFrom(json.RawMessage(`{}`)), for %#v.
+func (j JSON) GoString() string {
+	if !j.Valid {
+		return "nullable.JSON{}"
+	}
+	return fmt.Sprintf("nullable.JSONFrom(json.RawMessage(%q))", j.RawMessage)
+}
+
+// String implements fmt.Stringer.
+// It returns the inner value formatted with %v, or <null> if null.
+func (j JSONOf[T]) String() string {
+	if !j.Valid {
+		return NullPlaceholder
+	}
+	return fmt.Sprint(j.V)
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating j, such as nullable.JSONOfFrom(Settings{…}), for %#v.
+func (j JSONOf[T]) GoString() string {
+	if !j.Valid {
+		return fmt.Sprintf("%T{}", j)
+	}
+	return goCall("JSONOfFrom", j.V)
+}
+
+// String implements fmt.Stringer.
+// It returns the elements formatted with %v, or <null> if null.
+func (s Slice[T]) String() string {
+	if !s.Valid {
+		return NullPlaceholder
+	}
+	return fmt.Sprint(s.V)
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating s, such as nullable.SliceFrom([]string{"a"}), for %#v.
+func (s Slice[T]) GoString() string {
+	if !s.Valid {
+		return fmt.Sprintf("%T{}", s)
+	}
+	return goCall("SliceFrom", s.V)
+}
+
+// String implements fmt.Stringer.
+// It returns the map formatted with %v, or <null> if null.
+func (m MapOf[K, V]) String() string {
+	if !m.Valid {
+		return NullPlaceholder
+	}
+	return fmt.Sprint(m.V)
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating m, such as nullable.MapOfFrom(map[string]int{"a": 1}), for %#v.
+func (m MapOf[K, V]) GoString() string {
+	if !m.Valid {
+		return fmt.Sprintf("%T{}", m)
+	}
+	return goCall("MapOfFrom", m.V)
+}
+
+// String implements fmt.Stringer.
+// It returns the range literal, such as [1,5), or <null> if null.
+func (r Range[T]) String() string {
+	if !r.Valid {
+		return NullPlaceholder
+	}
+	v, err := r.Value()
+	if err != nil {
+		return fmt.Sprintf("%v", r.Range)
+	}
+	return v.(string)
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating r for %#v.
+func (r Range[T]) GoString() string {
+	if !r.Valid {
+		return fmt.Sprintf("%T{}", r)
+	}
+	return fmt.Sprintf("%T{Range: %#v}", r, r.Range)
+}
@@ -0,0 +1,37 @@
+package nullable
+
+// Map applies f to the value of n if valid, otherwise returns null.
+func Map[A, B comparable](n Null[A], f func(A) B) Null[B] {
+	if !n.Valid {
+		return Null[B]{}
+	}
+	return From(f(n.V))
+}
+
+// FlatMap applies f to the value of n if valid, otherwise returns null.
+// Unlike Map, f itself may produce a null.
+func FlatMap[A, B comparable](n Null[A], f func(A) Null[B]) Null[B] {
+	if !n.Valid {
+		return Null[B]{}
+	}
+	return f(n.V)
+}
+
+// Filter returns n if it is valid and its value satisfies pred, otherwise null.
+func Filter[T comparable](n Null[T], pred func(T) bool) Null[T] {
+	if !n.Valid || !pred(n.V) {
+		return Null[T]{}
+	}
+	return n
+}
+
+// MapOptional applies f to the value of o if present, keeping undefined and null as they are.
+func MapOptional[A, B comparable](o Optional[A], f func(A) B) Optional[B] {
+	switch o.State() {
+	case StateUndefined:
+		return Optional[B]{}
+	case StateNull:
+		return OptionalNull[B]()
+	}
+	return OptionalFrom(f(o.V))
+}
@@ -0,0 +1,162 @@
+package nullable
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Int16 is a nullable int16, such as a PostgreSQL smallint column. It embeds Null[int16], so it
+// marshals to a plain number or null, and adds conversions to and from pgtype.Int2.
+type Int16 struct {
+	Null[int16]
+}
+
+// NewInt16 creates a new Int16.
+func NewInt16(i int16, valid bool) Int16 {
+	return Int16{New(i, valid)}
+}
+
+// Int16From creates a new Int16 that will always be valid.
+func Int16From(i int16) Int16 {
+	return NewInt16(i, true)
+}
+
+// Int16FromPg creates a new Int16 from i.
+func Int16FromPg(i pgtype.Int2) Int16 {
+	return NewInt16(i.Int16, i.Valid)
+}
+
+// Pg returns i as a pgtype.Int2.
+func (i Int16) Pg() pgtype.Int2 {
+	return pgtype.Int2{Int16: i.V, Valid: i.Valid}
+}
+
+// ScanInt64 implements pgtype.Int64Scanner, so pgx decodes integer columns directly.
+// Values out of the range of int16 return an error.
+func (i *Int16) ScanInt64(v pgtype.Int8) error {
+	if !v.Valid {
+		*i = Int16{}
+		return nil
+	}
+	if v.Int64 < math.MinInt16 || v.Int64 > math.MaxInt16 {
+		return fmt.Errorf("nullable: %d is out of range for Int16", v.Int64)
+	}
+	*i = Int16From(int16(v.Int64))
+	return nil
+}
+
+// Int64Value implements pgtype.Int64Valuer, so pgx encodes i as an integer.
+func (i Int16) Int64Value() (pgtype.Int8, error) {
+	return pgtype.Int8{Int64: int64(i.V), Valid: i.Valid}, nil
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating i, such as nullable.Int16From(5), for %#v.
+func (i Int16) GoString() string {
+	if !i.Valid {
+		return "nullable.Int16{}"
+	}
+	return fmt.Sprintf("nullable.Int16From(%d)", i.V)
+}
+
+// Int32 is a nullable int32, such as a PostgreSQL integer column. It embeds Null[int32], so it
+// marshals to a plain number or null, and adds conversions to and from pgtype.Int4.
+type Int32 struct {
+	Null[int32]
+}
+
+// NewInt32 creates a new Int32.
+func NewInt32(i int32, valid bool) Int32 {
+	return Int32{New(i, valid)}
+}
+
+// Int32From creates a new Int32 that will always be valid.
+func Int32From(i int32) Int32 {
+	return NewInt32(i, true)
+}
+
+// Int32FromPg creates a new Int32 from i.
+func Int32FromPg(i pgtype.Int4) Int32 {
+	return NewInt32(i.Int32, i.Valid)
+}
+
+// Pg returns i as a pgtype.Int4.
+func (i Int32) Pg() pgtype.Int4 {
+	return pgtype.Int4{Int32: i.V, Valid: i.Valid}
+}
+
+// ScanInt64 implements pgtype.Int64Scanner, so pgx decodes integer columns directly.
+// Values out of the range of int32 return an error.
+func (i *Int32) ScanInt64(v pgtype.Int8) error {
+	if !v.Valid {
+		*i = Int32{}
+		return nil
+	}
+	if v.Int64 < math.MinInt32 || v.Int64 > math.MaxInt32 {
+		return fmt.Errorf("nullable: %d is out of range for Int32", v.Int64)
+	}
+	*i = Int32From(int32(v.Int64))
+	return nil
+}
+
+// Int64Value implements pgtype.Int64Valuer, so pgx encodes i as an integer.
+func (i Int32) Int64Value() (pgtype.Int8, error) {
+	return pgtype.Int8{Int64: int64(i.V), Valid: i.Valid}, nil
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating i, such as nullable.Int32From(5), for %#v.
+func (i Int32) GoString() string {
+	if !i.Valid {
+		return "nullable.Int32{}"
+	}
+	return fmt.Sprintf("nullable.Int32From(%d)", i.V)
+}
+
+// Int64 is a nullable int64, such as a PostgreSQL bigint column. It embeds Null[int64], so it
+// marshals to a plain number or null, and adds conversions to and from pgtype.Int8.
+type Int64 struct {
+	Null[int64]
+}
+
+// NewInt64 creates a new Int64.
+func NewInt64(i int64, valid bool) Int64 {
+	return Int64{New(i, valid)}
+}
+
+// Int64From creates a new Int64 that will always be valid.
+func Int64From(i int64) Int64 {
+	return NewInt64(i, true)
+}
+
+// Int64FromPg creates a new Int64 from i.
+func Int64FromPg(i pgtype.Int8) Int64 {
+	return NewInt64(i.Int64, i.Valid)
+}
+
+// Pg returns i as a pgtype.Int8.
+func (i Int64) Pg() pgtype.Int8 {
+	return pgtype.Int8{Int64: i.V, Valid: i.Valid}
+}
+
+// ScanInt64 implements pgtype.Int64Scanner, so pgx decodes integer columns directly.
+func (i *Int64) ScanInt64(v pgtype.Int8) error {
+	*i = Int64FromPg(v)
+	return nil
+}
+
+// Int64Value implements pgtype.Int64Valuer, so pgx encodes i as an integer.
+func (i Int64) Int64Value() (pgtype.Int8, error) {
+	return i.Pg(), nil
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating i, such as nullable.Int64From(5), for %#v.
+func (i Int64) GoString() string {
+	if !i.Valid {
+		return "nullable.Int64{}"
+	}
+	return fmt.Sprintf("nullable.Int64From(%d)", i.V)
+}
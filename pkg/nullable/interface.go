@@ -0,0 +1,118 @@
+package nullable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// Nullable is the common interface of nullable values, letting generic code such as struct
+// mappers, validators and SQL builders handle them uniformly. Pointers to Null and Optional
+// implement it, Adapt wraps guregu/null, pgtype and other Scanner and Valuer types, and
+// third-party types implementing it are read and written through Get and Set by the
+// packages of this module.
+type Nullable interface {
+	// IsZero reports whether the value is the zero value of its type, which is null,
+	// or undefined for types telling the two apart.
+	IsZero() bool
+	// IsNull reports whether the value is null.
+	IsNull() bool
+	// Get returns the value and true, or nil and false if it is null or undefined.
+	Get() (any, bool)
+	// Set stores v, or null if v is nil.
+	Set(v any) error
+}
+
+var (
+	_ Nullable = (*Null[int])(nil)
+	_ Nullable = (*Optional[int])(nil)
+)
+
+// IsNull returns true for null values.
+func (n Null[T]) IsNull() bool {
+	return !n.Valid
+}
+
+// Get returns the inner value and true, or nil and false if n is null.
+func (n Null[T]) Get() (any, bool) {
+	if !n.Valid {
+		return nil, false
+	}
+	return n.V, true
+}
+
+// Set stores v, or null if v is nil. Values other than a T are converted like Scan does.
+func (n *Null[T]) Set(v any) error {
+	if t, ok := v.(T); ok {
+		n.SetValid(t)
+		return nil
+	}
+	return n.Scan(v)
+}
+
+// Get returns the inner value and true, or nil and false if o is undefined or null.
+func (o Optional[T]) Get() (any, bool) {
+	if !o.IsPresent() {
+		return nil, false
+	}
+	return o.V, true
+}
+
+// Set stores v, or an explicit null if v is nil, marking o as defined either way.
+// Values other than a T are converted like Scan does.
+func (o *Optional[T]) Set(v any) error {
+	if t, ok := v.(T); ok {
+		o.SetValid(t)
+		return nil
+	}
+	return o.Scan(v)
+}
+
+// ScanValuer is implemented by pointers to types such as null.String and pgtype.Text.
+type ScanValuer interface {
+	sql.Scanner
+	driver.Valuer
+}
+
+// Adapt returns a Nullable backed by p, so types supporting database/sql, such as
+// guregu/null and pgtype types, work with code written against Nullable. Get returns
+// the driver value of p, such as int64 for pgtype.Int4, and Set scans into p.
+func Adapt(p ScanValuer) Nullable {
+	return scanValuer{p}
+}
+
+// As returns p if it implements Nullable, or else p adapted with Adapt if it implements
+// ScanValuer. It returns false for other values.
+func As(p any) (Nullable, bool) {
+	switch p := p.(type) {
+	case Nullable:
+		return p, true
+	case ScanValuer:
+		return Adapt(p), true
+	}
+	return nil, false
+}
+
+type scanValuer struct {
+	p ScanValuer
+}
+
+func (a scanValuer) IsZero() bool {
+	return a.IsNull()
+}
+
+func (a scanValuer) IsNull() bool {
+	_, ok := a.Get()
+	return !ok
+}
+
+func (a scanValuer) Get() (any, bool) {
+	v, err := a.p.Value()
+	if err != nil || v == nil {
+		return nil, false
+	}
+	return v, true
+}
+
+func (a scanValuer) Set(v any) error {
+	return a.p.Scan(v)
+}
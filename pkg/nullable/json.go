@@ -0,0 +1,159 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSON is a nullable JSON document, such as a json or jsonb column.
+// The document is kept verbatim and marshaled through unchanged.
+// A JSON null document cannot be told apart from SQL NULL and is treated as null.
+type JSON struct {
+	RawMessage json.RawMessage
+	Valid      bool
+}
+
+// JSONFrom creates a new JSON holding raw, which will be null if raw is nil or the null literal.
+func JSONFrom(raw json.RawMessage) JSON {
+	if raw == nil || bytes.Equal(raw, nullLiteral) {
+		return JSON{}
+	}
+	return JSON{RawMessage: raw, Valid: true}
+}
+
+// Decode unmarshals the document into v, leaving v untouched if j is null.
+func (j JSON) Decode(v any) error {
+	if !j.Valid {
+		return nil
+	}
+	if err := json.Unmarshal(j.RawMessage, v); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal JSON: %w", err)
+	}
+	return nil
+}
+
+// IsZero returns true for null values.
+// It lets encoding/json omit null fields tagged with omitzero.
+func (j JSON) IsZero() bool {
+	return !j.Valid
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this value is null, and the document verbatim otherwise.
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if !j.Valid {
+		return []byte("null"), nil
+	}
+	return j.RawMessage, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It keeps a copy of any document other than null.
+func (j *JSON) UnmarshalJSON(data []byte) error {
+	*j = JSONFrom(bytes.Clone(data))
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It supports json and jsonb columns scanned as text or bytes.
+func (j *JSON) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*j = JSON{}
+	case []byte:
+		*j = JSONFrom(bytes.Clone(v))
+	case string:
+		*j = JSONFrom(json.RawMessage(v))
+	default:
+		return fmt.Errorf("nullable: cannot scan %T into JSON", value)
+	}
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (j JSON) Value() (driver.Value, error) {
+	if !j.Valid {
+		return nil, nil
+	}
+	return string(j.RawMessage), nil
+}
+
+// JSONOf is a nullable JSON document decoded into T, such as a json or jsonb column
+// holding structured metadata. Unlike Null, T does not need to be comparable.
+type JSONOf[T any] struct {
+	V     T
+	Valid bool
+}
+
+// JSONOfFrom creates a new JSONOf that will always be valid.
+func JSONOfFrom[T any](v T) JSONOf[T] {
+	return JSONOf[T]{V: v, Valid: true}
+}
+
+// IsZero returns true for null values.
+// It lets encoding/json omit null fields tagged with omitzero.
+func (j JSONOf[T]) IsZero() bool {
+	return !j.Valid
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this value is null.
+func (j JSONOf[T]) MarshalJSON() ([]byte, error) {
+	if !j.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(j.V)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports null and any input that T itself can be decoded from.
+func (j *JSONOf[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		*j = JSONOf[T]{}
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal JSON: %w", err)
+	}
+	j.V, j.Valid = v, true
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It supports json and jsonb columns scanned as text or bytes.
+func (j *JSONOf[T]) Scan(value any) error {
+	var raw JSON
+	if err := raw.Scan(value); err != nil {
+		return err
+	}
+	if !raw.Valid {
+		*j = JSONOf[T]{}
+		return nil
+	}
+	return j.UnmarshalJSON(raw.RawMessage)
+}
+
+// Value implements the driver.Valuer interface.
+func (j JSONOf[T]) Value() (driver.Value, error) {
+	if !j.Valid {
+		return nil, nil
+	}
+	b, err := json.Marshal(j.V)
+	if err != nil {
+		return nil, fmt.Errorf("nullable: couldn't marshal JSON: %w", err)
+	}
+	return string(b), nil
+}
+
+// GormDataType reports the column type of JSON to GORM.
+func (JSON) GormDataType() string {
+	return "json"
+}
+
+// GormDataType reports the column type of JSONOf to GORM.
+func (JSONOf[T]) GormDataType() string {
+	return "json"
+}
@@ -0,0 +1,78 @@
+package nullable
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// AppendJSON appends the JSON encoding of n to b, as MarshalJSON would return it.
+// Strings, booleans, numbers and times are encoded without going through encoding/json,
+// which keeps null-heavy responses from paying for a full json.Marshal per field.
+func (n Null[T]) AppendJSON(b []byte) ([]byte, error) {
+	if !n.Valid {
+		return append(b, "null"...), nil
+	}
+	return appendJSON(b, n.V)
+}
+
+// AppendJSON appends the JSON encoding of o to b, as MarshalJSON would return it.
+func (o Optional[T]) AppendJSON(b []byte) ([]byte, error) {
+	if !o.IsPresent() {
+		return append(b, "null"...), nil
+	}
+	return appendJSON(b, o.V)
+}
+
+// appendJSON encodes v exactly like encoding/json does, falling back to it for
+// types without a fast path and for values it rejects, so errors stay the same.
+func appendJSON[T any](b []byte, v T) ([]byte, error) {
+	switch x := any(v).(type) {
+	case string:
+		return nullreflect.AppendJSONString(b, x), nil
+	case bool:
+		return strconv.AppendBool(b, x), nil
+	case int:
+		return strconv.AppendInt(b, int64(x), 10), nil
+	case int8:
+		return strconv.AppendInt(b, int64(x), 10), nil
+	case int16:
+		return strconv.AppendInt(b, int64(x), 10), nil
+	case int32:
+		return strconv.AppendInt(b, int64(x), 10), nil
+	case int64:
+		return strconv.AppendInt(b, x, 10), nil
+	case uint:
+		return strconv.AppendUint(b, uint64(x), 10), nil
+	case uint8:
+		return strconv.AppendUint(b, uint64(x), 10), nil
+	case uint16:
+		return strconv.AppendUint(b, uint64(x), 10), nil
+	case uint32:
+		return strconv.AppendUint(b, uint64(x), 10), nil
+	case uint64:
+		return strconv.AppendUint(b, x, 10), nil
+	case float32:
+		if !math.IsNaN(float64(x)) && !math.IsInf(float64(x), 0) {
+			return nullreflect.AppendJSONFloat(b, float64(x), 32), nil
+		}
+	case float64:
+		if !math.IsNaN(x) && !math.IsInf(x, 0) {
+			return nullreflect.AppendJSONFloat(b, x, 64), nil
+		}
+	case time.Time:
+		if y := x.Year(); y >= 0 && y <= 9999 {
+			b = append(b, '"')
+			b = x.AppendFormat(b, time.RFC3339Nano)
+			return append(b, '"'), nil
+		}
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return b, err
+	}
+	return append(b, data...), nil
+}
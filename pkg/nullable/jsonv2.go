@@ -0,0 +1,73 @@
+//go:build go1.27
+
+package nullable
+
+import (
+	"encoding/json/jsontext"
+	json "encoding/json/v2"
+)
+
+// MarshalJSONTo implements json.MarshalerTo from encoding/json/v2.
+// It will encode null if this value is null.
+func (n Null[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !n.Valid {
+		return enc.WriteToken(jsontext.Null)
+	}
+	return json.MarshalEncode(enc, n.V)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom from encoding/json/v2.
+// It supports null and any input that T itself can be decoded from.
+func (n *Null[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	if dec.PeekKind() == 'n' {
+		if _, err := dec.ReadToken(); err != nil {
+			return err
+		}
+		*n = Null[T]{}
+		return nil
+	}
+	var v T
+	if err := json.UnmarshalDecode(dec, &v); err != nil {
+		return err
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// MarshalJSONTo implements json.MarshalerTo from encoding/json/v2.
+// It will encode null if this value is undefined or null;
+// use omitzero to leave undefined values out entirely.
+func (o Optional[T]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	return o.Null().MarshalJSONTo(enc)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom from encoding/json/v2.
+// It is only called for keys that are present, so any input marks o as defined.
+func (o *Optional[T]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	var n Null[T]
+	if err := n.UnmarshalJSONFrom(dec); err != nil {
+		return err
+	}
+	*o = OptionalOf(n)
+	return nil
+}
+
+// MarshalJSONTo implements json.MarshalerTo from encoding/json/v2.
+// It shadows the method promoted from Null so TimeOutputLayout applies.
+func (t Time) MarshalJSONTo(enc *jsontext.Encoder) error {
+	b, err := t.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return enc.WriteValue(b)
+}
+
+// UnmarshalJSONFrom implements json.UnmarshalerFrom from encoding/json/v2.
+// It shadows the method promoted from Null so TimeLayouts apply.
+func (t *Time) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	b, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+	return t.UnmarshalJSON(b)
+}
@@ -0,0 +1,90 @@
+package nullable
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+var errLazyPanic = errors.New("nullable: lazy resolver panicked")
+
+// Lazy is a Null[T] resolved on demand, for optional enrichment fields such as
+// transliterated names that are expensive to fetch and not always needed.
+// The resolver runs at most once, on the first call to Get, and its result or
+// error is kept for every later call. It is safe for concurrent use.
+// A Lazy must not be copied after first use.
+type Lazy[T comparable] struct {
+	resolve func(context.Context) (T, error)
+	once    sync.Once
+	res     atomic.Pointer[lazyResult[T]]
+}
+
+type lazyResult[T comparable] struct {
+	n   Null[T]
+	err error
+}
+
+// NewLazy creates a new Lazy resolved by resolve.
+func NewLazy[T comparable](resolve func(context.Context) (T, error)) *Lazy[T] {
+	return &Lazy[T]{resolve: resolve}
+}
+
+// Get resolves l if it has not been resolved yet and returns its value, which is null
+// if the resolver failed. The context of the first call is the one passed to the resolver,
+// so an error from its cancellation is kept like any other.
+func (l *Lazy[T]) Get(ctx context.Context) (Null[T], error) {
+	l.once.Do(func() {
+		defer func() {
+			if l.res.Load() == nil {
+				l.res.Store(&lazyResult[T]{err: errLazyPanic})
+			}
+		}()
+		if l.resolve == nil {
+			l.res.Store(&lazyResult[T]{})
+			return
+		}
+		v, err := l.resolve(ctx)
+		if err != nil {
+			l.res.Store(&lazyResult[T]{err: err})
+			return
+		}
+		l.res.Store(&lazyResult[T]{n: From(v)})
+	})
+	r := l.res.Load()
+	return r.n, r.err
+}
+
+// Peek returns the value of l without resolving it, which is null until Get succeeded.
+func (l *Lazy[T]) Peek() Null[T] {
+	if r := l.res.Load(); r != nil {
+		return r.n
+	}
+	return Null[T]{}
+}
+
+// Resolved reports whether the resolver of l has run.
+func (l *Lazy[T]) Resolved() bool {
+	return l.res.Load() != nil
+}
+
+// Err returns the error of the resolver, or nil if it succeeded or has not run.
+func (l *Lazy[T]) Err() error {
+	if r := l.res.Load(); r != nil {
+		return r.err
+	}
+	return nil
+}
+
+// IsZero returns true while l holds null.
+// It lets encoding/json omit unresolved fields tagged with omitzero.
+func (l *Lazy[T]) IsZero() bool {
+	return !l.Peek().Valid
+}
+
+// MarshalJSON implements json.Marshaler.
+// It encodes the value of l as returned by Peek, without resolving it,
+// so call Get before encoding to include it.
+func (l *Lazy[T]) MarshalJSON() ([]byte, error) {
+	return l.Peek().MarshalJSON()
+}
@@ -0,0 +1,166 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// MapOf is a nullable map[K]V that tells a NULL column apart from an empty map,
+// such as free-form attributes on a form. It marshals to a JSON object and scans
+// from jsonb or hstore columns. It is not called Map, which applies a function to a Null.
+type MapOf[K comparable, V any] struct {
+	V     map[K]V
+	Valid bool
+}
+
+// MapOfFrom creates a new MapOf that will always be valid, even if m is nil.
+func MapOfFrom[K comparable, V any](m map[K]V) MapOf[K, V] {
+	return MapOf[K, V]{V: m, Valid: true}
+}
+
+// ValueOrZero returns the inner map if valid, otherwise nil.
+func (m MapOf[K, V]) ValueOrZero() map[K]V {
+	if !m.Valid {
+		return nil
+	}
+	return m.V
+}
+
+// IsZero returns true for null values.
+// It lets encoding/json omit null fields tagged with omitzero.
+func (m MapOf[K, V]) IsZero() bool {
+	return !m.Valid
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this value is null, and an object otherwise, even if V is nil.
+func (m MapOf[K, V]) MarshalJSON() ([]byte, error) {
+	if !m.Valid {
+		return []byte("null"), nil
+	}
+	if m.V == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(m.V)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports null and objects that map[K]V can be decoded from.
+func (m *MapOf[K, V]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		*m = MapOf[K, V]{}
+		return nil
+	}
+	var v map[K]V
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal JSON: %w", err)
+	}
+	if v == nil {
+		v = map[K]V{}
+	}
+	m.V, m.Valid = v, true
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It supports json and jsonb objects as well as the hstore text format.
+func (m *MapOf[K, V]) Scan(value any) error {
+	var src []byte
+	switch v := value.(type) {
+	case nil:
+		*m = MapOf[K, V]{}
+		return nil
+	case string:
+		src = []byte(v)
+	case []byte:
+		src = v
+	default:
+		return fmt.Errorf("nullable: cannot scan %T into MapOf", value)
+	}
+	if trimmed := bytes.TrimSpace(src); len(trimmed) > 0 && trimmed[0] == '{' {
+		return m.UnmarshalJSON(trimmed)
+	}
+	var h pgtype.Hstore
+	if err := h.Scan(string(src)); err != nil {
+		return err
+	}
+	return m.ScanHstore(h)
+}
+
+// Value implements the driver.Valuer interface.
+// It encodes a JSON object; pgx encodes hstore columns through HstoreValue instead.
+func (m MapOf[K, V]) Value() (driver.Value, error) {
+	if !m.Valid {
+		return nil, nil
+	}
+	b, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// ScanHstore implements pgtype.HstoreScanner. Keys and values are converted from
+// strings like form input is; NULL values become nil if V is a pointer, otherwise zero.
+func (m *MapOf[K, V]) ScanHstore(h pgtype.Hstore) error {
+	if h == nil {
+		*m = MapOf[K, V]{}
+		return nil
+	}
+	v := make(map[K]V, len(h))
+	for hk, hv := range h {
+		var key K
+		if err := nullreflect.WriteString(reflect.ValueOf(&key).Elem(), hk); err != nil {
+			return fmt.Errorf("nullable: couldn't scan hstore key %q: %w", hk, err)
+		}
+		var val V
+		if hv != nil {
+			if err := nullreflect.WriteString(reflect.ValueOf(&val).Elem(), *hv); err != nil {
+				return fmt.Errorf("nullable: couldn't scan hstore value of %q: %w", hk, err)
+			}
+		}
+		v[key] = val
+	}
+	m.V, m.Valid = v, true
+	return nil
+}
+
+// HstoreValue implements pgtype.HstoreValuer. Keys and values are formatted as
+// strings, numbers, booleans and times are, or with MarshalText; nil pointers become NULL.
+func (m MapOf[K, V]) HstoreValue() (pgtype.Hstore, error) {
+	if !m.Valid {
+		return nil, nil
+	}
+	h := make(pgtype.Hstore, len(m.V))
+	for k, v := range m.V {
+		key, err := formatText(k)
+		if err != nil {
+			return nil, fmt.Errorf("nullable: couldn't format hstore key: %w", err)
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				h[key] = nil
+				continue
+			}
+			rv = rv.Elem()
+		}
+		if !rv.IsValid() {
+			h[key] = nil
+			continue
+		}
+		val, err := formatText(rv.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("nullable: couldn't format hstore value of %q: %w", key, err)
+		}
+		h[key] = &val
+	}
+	return h, nil
+}
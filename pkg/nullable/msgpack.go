@@ -0,0 +1,90 @@
+package nullable
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+// EncodeMsgpack implements msgpack.CustomEncoder.
+// It will encode nil if this value is null.
+func (n Null[T]) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if !n.Valid {
+		return enc.EncodeNil()
+	}
+	return enc.Encode(n.V)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+// It supports nil and any input that T itself can be decoded from.
+func (n *Null[T]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	c, err := dec.PeekCode()
+	if err != nil {
+		return err
+	}
+	if c == msgpcode.Nil {
+		*n = Null[T]{}
+		return dec.DecodeNil()
+	}
+	var v T
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal msgpack: %w", err)
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// EncodeMsgpack implements msgpack.CustomEncoder.
+// It will encode nil if this value is undefined or null;
+// use omitempty to leave undefined values out entirely.
+func (o Optional[T]) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return o.Null().EncodeMsgpack(enc)
+}
+
+// DecodeMsgpack implements msgpack.CustomDecoder.
+// It is only called for keys that are present, so any input marks o as defined.
+// msgpack zeroes custom decoders on nil input without calling them, which leaves
+// an explicit nil undefined; RegisterMsgpackOptional lifts that restriction for T,
+// and is already applied to the builtin scalar types and time.Time.
+func (o *Optional[T]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	var n Null[T]
+	if err := n.DecodeMsgpack(dec); err != nil {
+		return err
+	}
+	*o = OptionalOf(n)
+	return nil
+}
+
+// RegisterMsgpackOptional registers a decoder for Optional[T] that also handles nil input,
+// so an explicit nil decodes to an explicit null instead of undefined.
+// It should be called once per T during initialization, before any decoding.
+// Builtin scalar types and time.Time are registered by this package.
+func RegisterMsgpackOptional[T comparable]() {
+	msgpack.Register(Optional[T]{}, nil, func(dec *msgpack.Decoder, v reflect.Value) error {
+		if !v.CanAddr() {
+			return fmt.Errorf("nullable: couldn't unmarshal msgpack: nonaddressable %s", v.Type())
+		}
+		return v.Addr().Interface().(*Optional[T]).DecodeMsgpack(dec)
+	})
+}
+
+func init() {
+	RegisterMsgpackOptional[string]()
+	RegisterMsgpackOptional[bool]()
+	RegisterMsgpackOptional[int]()
+	RegisterMsgpackOptional[int8]()
+	RegisterMsgpackOptional[int16]()
+	RegisterMsgpackOptional[int32]()
+	RegisterMsgpackOptional[int64]()
+	RegisterMsgpackOptional[uint]()
+	RegisterMsgpackOptional[uint8]()
+	RegisterMsgpackOptional[uint16]()
+	RegisterMsgpackOptional[uint32]()
+	RegisterMsgpackOptional[uint64]()
+	RegisterMsgpackOptional[float32]()
+	RegisterMsgpackOptional[float64]()
+	RegisterMsgpackOptional[time.Time]()
+}
@@ -0,0 +1,132 @@
+// Package nullable provides generic nullable types that support SQL and JSON serialization.
+// Types in this package will always encode to their null value if null.
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// Null is a nullable T. It supports SQL and JSON serialization.
+// It does not consider zero values to be null.
+// It will marshal to null if null.
+type Null[T comparable] struct {
+	V     T
+	Valid bool
+}
+
+// New creates a new Null.
+func New[T comparable](v T, valid bool) Null[T] {
+	return Null[T]{V: v, Valid: valid}
+}
+
+// From creates a new Null that will always be valid.
+func From[T comparable](v T) Null[T] {
+	return New(v, true)
+}
+
+// ValueOrZero returns the inner value if valid, otherwise zero.
+func (n Null[T]) ValueOrZero() T {
+	if !n.Valid {
+		var zero T
+		return zero
+	}
+	return n.V
+}
+
+// ValueOr returns the inner value if valid, otherwise v.
+func (n Null[T]) ValueOr(v T) T {
+	if !n.Valid {
+		return v
+	}
+	return n.V
+}
+
+// SetValid changes this Null's value and sets it to be non-null.
+func (n *Null[T]) SetValid(v T) {
+	n.V = v
+	n.Valid = true
+}
+
+// IsZero returns true for null values.
+// It lets encoding/json omit null fields tagged with omitzero.
+func (n Null[T]) IsZero() bool {
+	return !n.Valid
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this value is null.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	return n.AppendJSON(make([]byte, 0, 32))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports null and any input that T itself can be decoded from.
+func (n *Null[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		*n = Null[T]{}
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal JSON: %w", err)
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+// Besides what database/sql converts by itself, it parses textual times,
+// as returned by MySQL without parseTime and by SQLite.
+func (n *Null[T]) Scan(value any) error {
+	var sn sql.Null[T]
+	if err := sn.Scan(value); err != nil {
+		if scanTimeText(&n.V, value) {
+			n.Valid = true
+			return nil
+		}
+		return err
+	}
+	n.V, n.Valid = sn.V, sn.Valid
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// Non-driver types such as int32 are converted with driver.DefaultParameterConverter.
+func (n Null[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(n.V)
+}
+
+// scanTimeText parses value into dst if dst is a *time.Time and value is textual.
+func scanTimeText(dst any, value any) bool {
+	t, ok := dst.(*time.Time)
+	if !ok {
+		return false
+	}
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return false
+	}
+	parsed, err := nullreflect.ParseTime(s)
+	if err != nil {
+		return false
+	}
+	*t = parsed
+	return true
+}
+
+var nullLiteral = []byte("null")
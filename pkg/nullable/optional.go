@@ -0,0 +1,153 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// State describes whether an Optional was left undefined, explicitly set to null, or holds a value.
+type State = nullstate.State
+
+const (
+	// StateUndefined means the value was never set, e.g. the JSON key was absent.
+	StateUndefined = nullstate.Undefined
+	// StateNull means the value was explicitly set to null.
+	StateNull = nullstate.Null
+	// StatePresent means the value holds a non-null value.
+	StatePresent = nullstate.Present
+)
+
+// Optional is a tri-state nullable T, suited for PATCH-like partial updates.
+// Its zero value is undefined. Decoding a JSON object leaves absent keys undefined,
+// while null input produces an explicit null.
+type Optional[T comparable] struct {
+	V       T
+	Valid   bool
+	Defined bool
+}
+
+// OptionalFrom creates a new Optional that holds v.
+func OptionalFrom[T comparable](v T) Optional[T] {
+	return Optional[T]{V: v, Valid: true, Defined: true}
+}
+
+// OptionalNull creates a new Optional that is explicitly null.
+func OptionalNull[T comparable]() Optional[T] {
+	return Optional[T]{Defined: true}
+}
+
+// OptionalOf creates a new defined Optional from n.
+func OptionalOf[T comparable](n Null[T]) Optional[T] {
+	return Optional[T]{V: n.V, Valid: n.Valid, Defined: true}
+}
+
+// State reports whether o is undefined, null, or present.
+func (o Optional[T]) State() State {
+	switch {
+	case !o.Defined:
+		return StateUndefined
+	case !o.Valid:
+		return StateNull
+	}
+	return StatePresent
+}
+
+// IsDefined returns true if o was explicitly set, either to null or to a value.
+func (o Optional[T]) IsDefined() bool {
+	return o.Defined
+}
+
+// IsNull returns true if o was explicitly set to null.
+func (o Optional[T]) IsNull() bool {
+	return o.Defined && !o.Valid
+}
+
+// IsPresent returns true if o holds a value.
+func (o Optional[T]) IsPresent() bool {
+	return o.Defined && o.Valid
+}
+
+// ValueOrZero returns the inner value if present, otherwise zero.
+func (o Optional[T]) ValueOrZero() T {
+	if !o.IsPresent() {
+		var zero T
+		return zero
+	}
+	return o.V
+}
+
+// ValueOr returns the inner value if present, otherwise v.
+func (o Optional[T]) ValueOr(v T) T {
+	if !o.IsPresent() {
+		return v
+	}
+	return o.V
+}
+
+// Null returns o as a Null, collapsing undefined into null.
+func (o Optional[T]) Null() Null[T] {
+	return New(o.V, o.IsPresent())
+}
+
+// SetValid changes this Optional's value and marks it present.
+func (o *Optional[T]) SetValid(v T) {
+	*o = OptionalFrom(v)
+}
+
+// SetNull marks this Optional as explicitly null.
+func (o *Optional[T]) SetNull() {
+	*o = OptionalNull[T]()
+}
+
+// Unset marks this Optional as undefined.
+func (o *Optional[T]) Unset() {
+	*o = Optional[T]{}
+}
+
+// IsZero returns true for undefined values.
+// It lets encoding/json omit undefined fields tagged with omitzero while keeping explicit nulls.
+func (o Optional[T]) IsZero() bool {
+	return !o.Defined
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this value is undefined or null.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	return o.AppendJSON(make([]byte, 0, 32))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It is only called for keys that are present, so any input marks o as defined.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		o.SetNull()
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal JSON: %w", err)
+	}
+	o.SetValid(v)
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+// A scanned value is always defined.
+func (o *Optional[T]) Scan(value any) error {
+	var n Null[T]
+	if err := n.Scan(value); err != nil {
+		return err
+	}
+	*o = OptionalOf(n)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// Undefined values are encoded as NULL; callers that need to skip them should check IsDefined.
+func (o Optional[T]) Value() (driver.Value, error) {
+	return o.Null().Value()
+}
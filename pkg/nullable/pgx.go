@@ -0,0 +1,181 @@
+package nullable
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// pgxValuer is implemented by nullable types so pgx can encode the inner value with its own codecs.
+type pgxValuer interface {
+	pgxValue() (v any, valid bool)
+}
+
+// pgxScanner is implemented by nullable types so pgx can decode straight into the inner value.
+type pgxScanner interface {
+	pgxTarget() any
+	pgxSetValid(valid bool)
+}
+
+func (n Null[T]) pgxValue() (any, bool) {
+	return n.V, n.Valid
+}
+
+func (n *Null[T]) pgxTarget() any {
+	return &n.V
+}
+
+func (n *Null[T]) pgxSetValid(valid bool) {
+	if !valid {
+		*n = Null[T]{}
+		return
+	}
+	n.Valid = true
+}
+
+func (o Optional[T]) pgxValue() (any, bool) {
+	return o.V, o.IsPresent()
+}
+
+func (o *Optional[T]) pgxTarget() any {
+	return &o.V
+}
+
+func (o *Optional[T]) pgxSetValid(valid bool) {
+	if !valid {
+		o.SetNull()
+		return
+	}
+	o.Valid, o.Defined = true, true
+}
+
+// pgxScalarOIDs lists the types whose codecs get wrapped by RegisterPgxTypeMap.
+var pgxScalarOIDs = []uint32{
+	pgtype.BoolOID,
+	pgtype.ByteaOID,
+	pgtype.Int2OID,
+	pgtype.Int4OID,
+	pgtype.Int8OID,
+	pgtype.Float4OID,
+	pgtype.Float8OID,
+	pgtype.NumericOID,
+	pgtype.TextOID,
+	pgtype.VarcharOID,
+	pgtype.BPCharOID,
+	pgtype.NameOID,
+	pgtype.DateOID,
+	pgtype.TimeOID,
+	pgtype.TimestampOID,
+	pgtype.TimestamptzOID,
+	pgtype.IntervalOID,
+	pgtype.UUIDOID,
+	pgtype.JSONOID,
+	pgtype.JSONBOID,
+}
+
+// RegisterPgxTypeMap teaches m to encode and scan Null and Optional values using
+// the codec of their inner type, instead of going through driver.Valuer and sql.Scanner.
+// It is safe to call more than once on the same map.
+func RegisterPgxTypeMap(m *pgtype.Map) {
+	for _, oid := range pgxScalarOIDs {
+		t, ok := m.TypeForOID(oid)
+		if !ok {
+			continue
+		}
+		if _, wrapped := t.Codec.(pgxCodec); wrapped {
+			continue
+		}
+		m.RegisterType(&pgtype.Type{Name: t.Name, OID: t.OID, Codec: pgxCodec{t.Codec}})
+	}
+	for _, f := range m.TryWrapEncodePlanFuncs {
+		if isPgxEncodeWrapper(f) {
+			return
+		}
+	}
+	m.TryWrapEncodePlanFuncs = append([]pgtype.TryWrapEncodePlanFunc{tryWrapPgxEncodePlan}, m.TryWrapEncodePlanFuncs...)
+}
+
+// RegisterPgxTypes registers Null and Optional support on the type map of conn.
+func RegisterPgxTypes(conn *pgx.Conn) {
+	RegisterPgxTypeMap(conn.TypeMap())
+}
+
+// RegisterPgxPoolTypes makes every connection opened by a pool built from cfg
+// register Null and Optional support, keeping any AfterConnect hook already set.
+func RegisterPgxPoolTypes(cfg *pgxpool.Config) {
+	prev := cfg.AfterConnect
+	cfg.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		RegisterPgxTypes(conn)
+		if prev != nil {
+			return prev(ctx, conn)
+		}
+		return nil
+	}
+}
+
+// pgxCodec wraps a registered codec so scanning into Null and Optional targets
+// is planned against the inner value. pgx consults codecs before falling back
+// to sql.Scanner, which is what makes the direct path win.
+type pgxCodec struct {
+	pgtype.Codec
+}
+
+func (c pgxCodec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	if t, ok := target.(pgxScanner); ok {
+		return &pgxScanPlan{next: m.PlanScan(oid, format, t.pgxTarget())}
+	}
+	return c.Codec.PlanScan(m, oid, format, target)
+}
+
+type pgxScanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (p *pgxScanPlan) Scan(src []byte, target any) error {
+	t := target.(pgxScanner)
+	if src == nil {
+		t.pgxSetValid(false)
+		return nil
+	}
+	if err := p.next.Scan(src, t.pgxTarget()); err != nil {
+		return err
+	}
+	t.pgxSetValid(true)
+	return nil
+}
+
+func tryWrapPgxEncodePlan(value any) (pgtype.WrappedEncodePlanNextSetter, any, bool) {
+	if v, ok := value.(pgxValuer); ok {
+		next, _ := v.pgxValue()
+		return &pgxEncodePlan{}, next, true
+	}
+	return nil, nil, false
+}
+
+// isPgxEncodeWrapper reports whether f is tryWrapPgxEncodePlan by probing it with a Null value.
+func isPgxEncodeWrapper(f pgtype.TryWrapEncodePlanFunc) bool {
+	plan, _, ok := f(Null[string]{})
+	if !ok {
+		return false
+	}
+	_, is := plan.(*pgxEncodePlan)
+	return is
+}
+
+type pgxEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (p *pgxEncodePlan) SetNext(next pgtype.EncodePlan) {
+	p.next = next
+}
+
+func (p *pgxEncodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	v, valid := value.(pgxValuer).pgxValue()
+	if !valid {
+		return nil, nil
+	}
+	return p.next.Encode(v, buf)
+}
@@ -0,0 +1,33 @@
+package nullable
+
+// FromPtr creates a new Null that will be null if p is nil.
+func FromPtr[T comparable](p *T) Null[T] {
+	if p == nil {
+		return Null[T]{}
+	}
+	return From(*p)
+}
+
+// ToPtr returns a pointer to a copy of the value of n, or nil if n is null.
+func ToPtr[T comparable](n Null[T]) *T {
+	return n.Ptr()
+}
+
+// Ptr returns a pointer to a copy of this Null's value, or nil if null.
+func (n Null[T]) Ptr() *T {
+	if !n.Valid {
+		return nil
+	}
+	v := n.V
+	return &v
+}
+
+// OptionalFromPtr creates a new defined Optional that will be null if p is nil.
+func OptionalFromPtr[T comparable](p *T) Optional[T] {
+	return OptionalOf(FromPtr(p))
+}
+
+// Ptr returns a pointer to a copy of this Optional's value, or nil if undefined or null.
+func (o Optional[T]) Ptr() *T {
+	return o.Null().Ptr()
+}
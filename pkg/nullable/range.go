@@ -0,0 +1,285 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// Range is a nullable PostgreSQL range of T, such as an optional validity period.
+// It embeds pgtype.Range, so pgx can scan and encode it for any range column whose
+// elements T can hold, and reads and writes range literals for database/sql. It marshals to JSON as {"from": lower, "to": upper} or null,
+// where a null or missing bound is unbounded.
+//
+// Bounds default to an inclusive lower and exclusive upper bound, PostgreSQL's
+// canonical form; other bounds add a "bounds" key such as "[]", and empty ranges
+// marshal to {"empty": true}.
+type Range[T any] struct {
+	pgtype.Range[T]
+}
+
+// RangeFrom creates a new valid Range including lower and excluding upper.
+func RangeFrom[T any](lower, upper T) Range[T] {
+	return Range[T]{pgtype.Range[T]{
+		Lower:     lower,
+		Upper:     upper,
+		LowerType: pgtype.Inclusive,
+		UpperType: pgtype.Exclusive,
+		Valid:     true,
+	}}
+}
+
+// IsZero returns true for null values.
+// It lets encoding/json omit null fields tagged with omitzero.
+func (r Range[T]) IsZero() bool {
+	return !r.Valid
+}
+
+type rangeJSON struct {
+	From   json.RawMessage `json:"from"`
+	To     json.RawMessage `json:"to"`
+	Bounds string          `json:"bounds,omitempty"`
+	Empty  bool            `json:"empty,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this value is null.
+func (r Range[T]) MarshalJSON() ([]byte, error) {
+	if !r.Valid {
+		return []byte("null"), nil
+	}
+	if r.LowerType == pgtype.Empty {
+		return []byte(`{"empty":true}`), nil
+	}
+	var out rangeJSON
+	var err error
+	if out.From, err = rangeBoundJSON(r.Lower, r.LowerType); err != nil {
+		return nil, err
+	}
+	if out.To, err = rangeBoundJSON(r.Upper, r.UpperType); err != nil {
+		return nil, err
+	}
+	lower, upper := byte('['), byte(')')
+	if r.LowerType == pgtype.Exclusive {
+		lower = '('
+	}
+	if r.UpperType == pgtype.Inclusive {
+		upper = ']'
+	}
+	if lower != '[' || upper != ')' {
+		out.Bounds = string([]byte{lower, upper})
+	}
+	return json.Marshal(out)
+}
+
+func rangeBoundJSON(v any, bt pgtype.BoundType) (json.RawMessage, error) {
+	if bt == pgtype.Unbounded {
+		return nullLiteral, nil
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports null and objects with optional "from", "to", "bounds" and "empty" keys.
+func (r *Range[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		*r = Range[T]{}
+		return nil
+	}
+	var in rangeJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal JSON: %w", err)
+	}
+	if in.Empty {
+		*r = Range[T]{pgtype.Range[T]{LowerType: pgtype.Empty, UpperType: pgtype.Empty, Valid: true}}
+		return nil
+	}
+	bounds := in.Bounds
+	if bounds == "" {
+		bounds = "[)"
+	}
+	if len(bounds) != 2 || (bounds[0] != '[' && bounds[0] != '(') || (bounds[1] != ']' && bounds[1] != ')') {
+		return fmt.Errorf("nullable: couldn't unmarshal JSON: invalid range bounds %q", in.Bounds)
+	}
+	var v pgtype.Range[T]
+	var err error
+	if v.LowerType, err = unmarshalRangeBound(in.From, &v.Lower, bounds[0] == '['); err != nil {
+		return err
+	}
+	if v.UpperType, err = unmarshalRangeBound(in.To, &v.Upper, bounds[1] == ']'); err != nil {
+		return err
+	}
+	v.Valid = true
+	r.Range = v
+	return nil
+}
+
+func unmarshalRangeBound[T any](data json.RawMessage, dst *T, inclusive bool) (pgtype.BoundType, error) {
+	if data == nil || bytes.Equal(data, nullLiteral) {
+		return pgtype.Unbounded, nil
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return 0, fmt.Errorf("nullable: couldn't unmarshal JSON: %w", err)
+	}
+	if inclusive {
+		return pgtype.Inclusive, nil
+	}
+	return pgtype.Exclusive, nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It parses range literals such as "[1,5)" or "empty", as returned by lib/pq.
+func (r *Range[T]) Scan(value any) error {
+	var src string
+	switch v := value.(type) {
+	case nil:
+		*r = Range[T]{}
+		return nil
+	case string:
+		src = v
+	case []byte:
+		src = string(v)
+	default:
+		return fmt.Errorf("nullable: cannot scan %T into Range", value)
+	}
+	v, err := parseRange[T](src)
+	if err != nil {
+		return fmt.Errorf("nullable: couldn't parse range %q: %w", src, err)
+	}
+	r.Range = v
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// It encodes a range literal with quoted bounds.
+func (r Range[T]) Value() (driver.Value, error) {
+	if !r.Valid {
+		return nil, nil
+	}
+	if r.LowerType == pgtype.Empty {
+		return "empty", nil
+	}
+	var sb strings.Builder
+	if r.LowerType == pgtype.Inclusive {
+		sb.WriteByte('[')
+	} else {
+		sb.WriteByte('(')
+	}
+	if err := writeRangeBound(&sb, r.Lower, r.LowerType); err != nil {
+		return nil, err
+	}
+	sb.WriteByte(',')
+	if err := writeRangeBound(&sb, r.Upper, r.UpperType); err != nil {
+		return nil, err
+	}
+	if r.UpperType == pgtype.Inclusive {
+		sb.WriteByte(']')
+	} else {
+		sb.WriteByte(')')
+	}
+	return sb.String(), nil
+}
+
+func writeRangeBound(sb *strings.Builder, v any, bt pgtype.BoundType) error {
+	if bt == pgtype.Unbounded {
+		return nil
+	}
+	s, err := formatText(v)
+	if err != nil {
+		return fmt.Errorf("nullable: couldn't format range bound: %w", err)
+	}
+	sb.WriteByte('"')
+	for _, c := range []byte(s) {
+		if c == '"' || c == '\\' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(c)
+	}
+	sb.WriteByte('"')
+	return nil
+}
+
+func parseRange[T any](src string) (pgtype.Range[T], error) {
+	s := strings.TrimSpace(src)
+	if strings.EqualFold(s, "empty") {
+		return pgtype.Range[T]{LowerType: pgtype.Empty, UpperType: pgtype.Empty, Valid: true}, nil
+	}
+	if len(s) < 3 || (s[0] != '[' && s[0] != '(') || (s[len(s)-1] != ']' && s[len(s)-1] != ')') {
+		return pgtype.Range[T]{}, errors.New("missing bounds")
+	}
+	lower, rest, err := parseRangeElement(s[1 : len(s)-1])
+	if err != nil {
+		return pgtype.Range[T]{}, err
+	}
+	if len(rest) == 0 || rest[0] != ',' {
+		return pgtype.Range[T]{}, errors.New("missing comma")
+	}
+	upper, rest, err := parseRangeElement(rest[1:])
+	if err != nil {
+		return pgtype.Range[T]{}, err
+	}
+	if rest != "" {
+		return pgtype.Range[T]{}, errors.New("unexpected trailing text")
+	}
+	v := pgtype.Range[T]{Valid: true}
+	if v.LowerType, err = scanRangeBound(lower, &v.Lower, s[0] == '['); err != nil {
+		return pgtype.Range[T]{}, err
+	}
+	if v.UpperType, err = scanRangeBound(upper, &v.Upper, s[len(s)-1] == ']'); err != nil {
+		return pgtype.Range[T]{}, err
+	}
+	return v, nil
+}
+
+// parseRangeElement reads one bound of a range literal, returning nil for an unbounded side.
+func parseRangeElement(s string) (*string, string, error) {
+	if s == "" || s[0] == ',' {
+		return nil, s, nil
+	}
+	var sb strings.Builder
+	if s[0] != '"' {
+		end := strings.IndexByte(s, ',')
+		if end < 0 {
+			end = len(s)
+		}
+		v := s[:end]
+		return &v, s[end:], nil
+	}
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' && i+1 < len(s):
+			i++
+			sb.WriteByte(s[i])
+		case c == '"' && i+1 < len(s) && s[i+1] == '"':
+			i++
+			sb.WriteByte('"')
+		case c == '"':
+			v := sb.String()
+			return &v, s[i+1:], nil
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return nil, "", errors.New("unterminated quoted bound")
+}
+
+func scanRangeBound[T any](text *string, dst *T, inclusive bool) (pgtype.BoundType, error) {
+	if text == nil {
+		return pgtype.Unbounded, nil
+	}
+	if err := nullreflect.WriteString(reflect.ValueOf(dst).Elem(), *text); err != nil {
+		return 0, err
+	}
+	if inclusive {
+		return pgtype.Inclusive, nil
+	}
+	return pgtype.Exclusive, nil
+}
@@ -0,0 +1,392 @@
+package nullable
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+)
+
+// scanTest scans in, a value as returned by lib/pq or MySQL, into a zero T.
+type scanTest[T any] struct {
+	name    string
+	in      any
+	want    T
+	wantErr bool
+}
+
+func testScan[T any, P interface {
+	*T
+	sql.Scanner
+}](t *testing.T, tests []scanTest[T], equal func(a, b T) bool) {
+	t.Helper()
+	if equal == nil {
+		equal = func(a, b T) bool { return reflect.DeepEqual(a, b) }
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got T
+			err := P(&got).Scan(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Scan(%#v) = %#v, want an error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%#v): %v", tt.in, err)
+			}
+			if !equal(got, tt.want) {
+				t.Errorf("Scan(%#v) = %#v, want %#v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// valueTest encodes in as a SQL argument.
+type valueTest struct {
+	name    string
+	in      driver.Valuer
+	want    driver.Value
+	wantErr bool
+}
+
+func testValue(t *testing.T, tests []valueTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.in.Value()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("%#v.Value() = %#v, want an error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%#v.Value(): %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%#v.Value() = %#v (%T), want %#v (%T)", tt.in, got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+var (
+	testInstant = time.Date(2024, time.January, 2, 3, 4, 5, 0, time.UTC)
+	testUUID    = uuid.MustParse("0190a1b2-c3d4-7e5f-8a9b-0c1d2e3f4a5b")
+)
+
+func TestNullScan(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		testScan(t, []scanTest[Null[string]]{
+			{name: "nil", in: nil, want: Null[string]{}},
+			{name: "string", in: "tan", want: From("tan")},
+			{name: "bytes", in: []byte("tan"), want: From("tan")},
+			{name: "empty", in: "", want: From("")},
+			{name: "int64", in: int64(42), want: From("42")},
+		}, nil)
+	})
+	t.Run("int64", func(t *testing.T) {
+		testScan(t, []scanTest[Null[int64]]{
+			{name: "nil", in: nil, want: Null[int64]{}},
+			{name: "int64", in: int64(42), want: From[int64](42)},
+			{name: "bytes", in: []byte("-7"), want: From[int64](-7)},
+			{name: "string", in: "9", want: From[int64](9)},
+			{name: "not a number", in: []byte("abc"), wantErr: true},
+		}, nil)
+	})
+	t.Run("int32", func(t *testing.T) {
+		testScan(t, []scanTest[Null[int32]]{
+			{name: "int64", in: int64(42), want: From[int32](42)},
+			{name: "bytes", in: []byte("42"), want: From[int32](42)},
+			{name: "overflow", in: int64(1 << 40), wantErr: true},
+		}, nil)
+	})
+	t.Run("float64", func(t *testing.T) {
+		testScan(t, []scanTest[Null[float64]]{
+			{name: "float64", in: 1.5, want: From(1.5)},
+			{name: "bytes", in: []byte("2.25"), want: From(2.25)},
+			{name: "int64", in: int64(3), want: From(3.0)},
+		}, nil)
+	})
+	t.Run("bool", func(t *testing.T) {
+		testScan(t, []scanTest[Null[bool]]{
+			{name: "nil", in: nil, want: Null[bool]{}},
+			{name: "bool", in: true, want: From(true)},
+			{name: "tinyint", in: int64(0), want: From(false)},
+			{name: "bytes", in: []byte("1"), want: From(true)},
+			{name: "not a bool", in: "maybe", wantErr: true},
+		}, nil)
+	})
+	t.Run("time", func(t *testing.T) {
+		testScan(t, []scanTest[Null[time.Time]]{
+			{name: "nil", in: nil, want: Null[time.Time]{}},
+			{name: "time", in: testInstant, want: From(testInstant)},
+			{name: "mysql bytes", in: []byte("2024-01-02 03:04:05"), want: From(testInstant)},
+			{name: "sqlite string", in: "2024-01-02T03:04:05Z", want: From(testInstant)},
+			{name: "pg offset", in: "2024-01-02 11:04:05+08", want: From(testInstant)},
+			{name: "not a time", in: []byte("yesterday"), wantErr: true},
+		}, func(a, b Null[time.Time]) bool { return a.Valid == b.Valid && a.V.Equal(b.V) })
+	})
+}
+
+func TestOptionalScan(t *testing.T) {
+	testScan(t, []scanTest[Optional[string]]{
+		{name: "nil", in: nil, want: OptionalNull[string]()},
+		{name: "string", in: "tan", want: OptionalFrom("tan")},
+		{name: "bytes", in: []byte("tan"), want: OptionalFrom("tan")},
+	}, nil)
+}
+
+func TestWrapperScan(t *testing.T) {
+	t.Run("Bool", func(t *testing.T) {
+		testScan(t, []scanTest[Bool]{
+			{name: "nil", in: nil, want: Bool{}},
+			{name: "bool", in: false, want: BoolFrom(false)},
+			{name: "tinyint", in: int64(1), want: BoolFrom(true)},
+		}, nil)
+	})
+	t.Run("Int16", func(t *testing.T) {
+		testScan(t, []scanTest[Int16]{
+			{name: "nil", in: nil, want: Int16{}},
+			{name: "int64", in: int64(-3), want: Int16From(-3)},
+			{name: "bytes", in: []byte("300"), want: Int16From(300)},
+			{name: "overflow", in: int64(1 << 20), wantErr: true},
+		}, nil)
+	})
+	t.Run("Int32", func(t *testing.T) {
+		testScan(t, []scanTest[Int32]{
+			{name: "int64", in: int64(42), want: Int32From(42)},
+			{name: "string", in: "42", want: Int32From(42)},
+		}, nil)
+	})
+	t.Run("Int64", func(t *testing.T) {
+		testScan(t, []scanTest[Int64]{
+			{name: "int64", in: int64(1 << 40), want: Int64From(1 << 40)},
+			{name: "bytes", in: []byte("5"), want: Int64From(5)},
+		}, nil)
+	})
+	t.Run("Time", func(t *testing.T) {
+		testScan(t, []scanTest[Time]{
+			{name: "nil", in: nil, want: Time{}},
+			{name: "time", in: testInstant, want: TimeFrom(testInstant)},
+			{name: "mysql bytes", in: []byte("2024-01-02 03:04:05"), want: TimeFrom(testInstant)},
+		}, func(a, b Time) bool { return a.Valid == b.Valid && a.V.Equal(b.V) })
+	})
+}
+
+func TestDateScan(t *testing.T) {
+	testScan(t, []scanTest[Date]{
+		{name: "nil", in: nil, want: Date{}},
+		{name: "lib/pq time", in: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), want: NewDate(2024, time.February, 29)},
+		{name: "mysql bytes", in: []byte("2024-02-29"), want: NewDate(2024, time.February, 29)},
+		{name: "string", in: "1999-12-31", want: NewDate(1999, time.December, 31)},
+		{name: "infinity", in: []byte("infinity"), want: Date{InfinityModifier: pgtype.Infinity, Valid: true}},
+		{name: "-infinity", in: "-infinity", want: Date{InfinityModifier: pgtype.NegativeInfinity, Valid: true}},
+		{name: "not a date", in: "02/29/2024", wantErr: true},
+	}, nil)
+}
+
+func TestDecimalScan(t *testing.T) {
+	testScan(t, []scanTest[Decimal]{
+		{name: "nil", in: nil, want: Decimal{}},
+		{name: "lib/pq bytes", in: []byte("12.50"), want: DecimalFrom(decimal.RequireFromString("12.5"))},
+		{name: "string", in: "-0.001", want: DecimalFrom(decimal.RequireFromString("-0.001"))},
+		{name: "float64", in: 1.25, want: DecimalFrom(decimal.NewFromFloat(1.25))},
+		{name: "int64", in: int64(3), want: DecimalFrom(decimal.NewFromInt(3))},
+		{name: "not a number", in: []byte("twelve"), wantErr: true},
+	}, func(a, b Decimal) bool { return a.Valid == b.Valid && a.Decimal.Equal(b.Decimal) })
+}
+
+func TestUUIDScan(t *testing.T) {
+	testScan(t, []scanTest[UUID]{
+		{name: "nil", in: nil, want: UUID{}},
+		{name: "string", in: testUUID.String(), want: UUIDFrom(testUUID)},
+		{name: "bytes", in: []byte(testUUID.String()), want: UUIDFrom(testUUID)},
+		{name: "raw bytes", in: testUUID[:], want: UUIDFrom(testUUID)},
+		{name: "not a uuid", in: "0190a1b2", wantErr: true},
+	}, nil)
+}
+
+func TestUinfinScan(t *testing.T) {
+	testScan(t, []scanTest[Uinfin]{
+		{name: "nil", in: nil, want: Uinfin{}},
+		{name: "string", in: "S1234567D", want: UinfinFrom("S1234567D")},
+		{name: "lower case bytes", in: []byte(" s1234567d "), want: UinfinFrom("S1234567D")},
+		{name: "bad checksum", in: "S1234567A", wantErr: true},
+		{name: "bad prefix", in: "X1234567D", wantErr: true},
+	}, nil)
+}
+
+type testStatus string
+
+func init() {
+	RegisterEnum[testStatus]("draft", "submitted")
+}
+
+func TestEnumScan(t *testing.T) {
+	testScan(t, []scanTest[Enum[testStatus]]{
+		{name: "nil", in: nil, want: Enum[testStatus]{}},
+		{name: "string", in: "draft", want: EnumFrom[testStatus]("draft")},
+		{name: "bytes", in: []byte("submitted"), want: EnumFrom[testStatus]("submitted")},
+		{name: "unregistered", in: "deleted", wantErr: true},
+	}, nil)
+}
+
+func TestJSONScan(t *testing.T) {
+	t.Run("JSON", func(t *testing.T) {
+		testScan(t, []scanTest[JSON]{
+			{name: "nil", in: nil, want: JSON{}},
+			{name: "bytes", in: []byte(`{"a":1}`), want: JSONFrom([]byte(`{"a":1}`))},
+			{name: "string", in: `[1,2]`, want: JSONFrom([]byte(`[1,2]`))},
+			{name: "int64", in: int64(1), wantErr: true},
+		}, nil)
+	})
+	t.Run("JSONOf", func(t *testing.T) {
+		testScan(t, []scanTest[JSONOf[map[string]int]]{
+			{name: "nil", in: nil, want: JSONOf[map[string]int]{}},
+			{name: "bytes", in: []byte(`{"a":1}`), want: JSONOfFrom(map[string]int{"a": 1})},
+			{name: "string", in: `{"b":2}`, want: JSONOfFrom(map[string]int{"b": 2})},
+			{name: "wrong shape", in: `[1]`, wantErr: true},
+		}, nil)
+	})
+}
+
+func TestSliceScan(t *testing.T) {
+	t.Run("int64", func(t *testing.T) {
+		testScan(t, []scanTest[Slice[int64]]{
+			{name: "nil", in: nil, want: Slice[int64]{}},
+			{name: "bytes", in: []byte("{1,2,3}"), want: SliceFrom([]int64{1, 2, 3})},
+			{name: "empty", in: "{}", want: SliceFrom([]int64{})},
+			{name: "int64", in: int64(1), wantErr: true},
+		}, nil)
+	})
+	t.Run("string", func(t *testing.T) {
+		testScan(t, []scanTest[Slice[string]]{
+			{name: "quoted", in: `{tan,"lee, ah kow"}`, want: SliceFrom([]string{"tan", "lee, ah kow"})},
+		}, nil)
+	})
+}
+
+func TestMapOfScan(t *testing.T) {
+	testScan(t, []scanTest[MapOf[string, string]]{
+		{name: "nil", in: nil, want: MapOf[string, string]{}},
+		{name: "json bytes", in: []byte(`{"a":"b"}`), want: MapOfFrom(map[string]string{"a": "b"})},
+		{name: "hstore string", in: `"a"=>"b", "c"=>"d"`, want: MapOfFrom(map[string]string{"a": "b", "c": "d"})},
+		{name: "int64", in: int64(1), wantErr: true},
+	}, nil)
+}
+
+func TestRangeScan(t *testing.T) {
+	testScan(t, []scanTest[Range[int32]]{
+		{name: "nil", in: nil, want: Range[int32]{}},
+		{name: "bytes", in: []byte("[1,5)"), want: RangeFrom[int32](1, 5)},
+		{name: "string", in: "[1,5)", want: RangeFrom[int32](1, 5)},
+		{name: "empty", in: "empty", want: Range[int32]{pgtype.Range[int32]{LowerType: pgtype.Empty, UpperType: pgtype.Empty, Valid: true}}},
+		{name: "not a range", in: "1..5", wantErr: true},
+	}, nil)
+}
+
+func TestValue(t *testing.T) {
+	testValue(t, []valueTest{
+		{name: "null string", in: Null[string]{}, want: nil},
+		{name: "string", in: From("tan"), want: "tan"},
+		{name: "int32", in: From[int32](7), want: int64(7)},
+		{name: "uint16", in: From[uint16](7), want: int64(7)},
+		{name: "time", in: From(testInstant), want: testInstant},
+		{name: "undefined optional", in: Optional[string]{}, want: nil},
+		{name: "optional", in: OptionalFrom(1.5), want: 1.5},
+		{name: "Bool", in: BoolFrom(true), want: true},
+		{name: "Int16", in: Int16From(-3), want: int64(-3)},
+		{name: "Int32", in: Int32From(42), want: int64(42)},
+		{name: "Int64", in: Int64From(1 << 40), want: int64(1 << 40)},
+		{name: "Time", in: TimeFrom(testInstant), want: testInstant},
+		{name: "null Date", in: Date{}, want: nil},
+		{name: "Date", in: NewDate(2024, time.February, 29), want: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "infinite Date", in: Date{InfinityModifier: pgtype.Infinity, Valid: true}, want: "infinity"},
+		{name: "Decimal", in: DecimalFrom(decimal.RequireFromString("12.50")), want: "12.5"},
+		{name: "UUID", in: UUIDFrom(testUUID), want: testUUID.String()},
+		{name: "Uinfin", in: UinfinFrom("S1234567D"), want: "S1234567D"},
+		{name: "invalid Uinfin", in: UinfinFrom("S1234567A"), wantErr: true},
+		{name: "Enum", in: EnumFrom[testStatus]("draft"), want: "draft"},
+		{name: "unregistered Enum", in: EnumFrom[testStatus]("deleted"), wantErr: true},
+		{name: "JSON", in: JSONFrom([]byte(`{"a":1}`)), want: `{"a":1}`},
+		{name: "JSONOf", in: JSONOfFrom(map[string]int{"a": 1}), want: `{"a":1}`},
+		{name: "Slice", in: SliceFrom([]int64{1, 2}), want: "{1,2}"},
+		{name: "null Slice", in: Slice[int64]{}, want: nil},
+		{name: "MapOf", in: MapOfFrom(map[string]string{"a": "b"}), want: `{"a":"b"}`},
+		{name: "Range", in: RangeFrom[int32](1, 5), want: `["1","5")`},
+	})
+}
+
+func TestValueScanRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		in   driver.Valuer
+		dst  sql.Scanner
+	}{
+		{name: "Date", in: NewDate(2024, time.February, 29), dst: new(Date)},
+		{name: "Decimal", in: DecimalFrom(decimal.RequireFromString("-1.5")), dst: new(Decimal)},
+		{name: "UUID", in: UUIDFrom(testUUID), dst: new(UUID)},
+		{name: "Uinfin", in: UinfinFrom("T0000000G"), dst: new(Uinfin)},
+		{name: "Slice", in: SliceFrom([]string{"a b", `"q"`}), dst: new(Slice[string])},
+		{name: "MapOf", in: MapOfFrom(map[string]string{"k": "v"}), dst: new(MapOf[string, string])},
+		{name: "Range", in: RangeFrom[int32](1, 5), dst: new(Range[int32])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.in.Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.dst.Scan(v); err != nil {
+				t.Fatalf("Scan(%#v): %v", v, err)
+			}
+			got := reflect.ValueOf(tt.dst).Elem().Interface()
+			if !equalScanned(got, tt.in) {
+				t.Errorf("round trip of %#v = %#v", tt.in, got)
+			}
+		})
+	}
+}
+
+func equalScanned(a, b any) bool {
+	if x, ok := a.(Decimal); ok {
+		y := b.(Decimal)
+		return x.Valid == y.Valid && x.Decimal.Equal(y.Decimal)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func TestScanErrorsWrapUinfin(t *testing.T) {
+	var u Uinfin
+	if err := u.Scan("S1234567A"); !errors.Is(err, ErrInvalidUinfin) {
+		t.Errorf("Scan = %v, want ErrInvalidUinfin", err)
+	}
+}
+
+func TestScanTimeLocation(t *testing.T) {
+	defer func(loc *time.Location) { TimeLocation = loc }(TimeLocation)
+	TimeLocation = time.FixedZone("SGT", 8*60*60)
+	want := time.Date(2024, time.January, 2, 3, 4, 5, 0, TimeLocation)
+	var n Null[time.Time]
+	if err := n.Scan([]byte("2024-01-02 03:04:05")); err != nil {
+		t.Fatal(err)
+	}
+	var tm Time
+	if err := tm.Scan("2024-01-02 03:04:05"); err != nil {
+		t.Fatal(err)
+	}
+	for name, got := range map[string]time.Time{"Null[time.Time]": n.V, "Time": tm.V} {
+		if !got.Equal(want) {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Slice is a nullable []T that tells a NULL array apart from an empty one,
+// such as an optional list of previous names. It supports JSON and PostgreSQL arrays,
+// through pgx directly or through database/sql as array literals.
+type Slice[T any] struct {
+	V     []T
+	Valid bool
+}
+
+// SliceFrom creates a new Slice that will always be valid, even if v is nil.
+func SliceFrom[T any](v []T) Slice[T] {
+	return Slice[T]{V: v, Valid: true}
+}
+
+// ValueOrZero returns the inner slice if valid, otherwise nil.
+func (s Slice[T]) ValueOrZero() []T {
+	if !s.Valid {
+		return nil
+	}
+	return s.V
+}
+
+// IsZero returns true for null values.
+// It lets encoding/json omit null fields tagged with omitzero.
+func (s Slice[T]) IsZero() bool {
+	return !s.Valid
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this value is null, and an array otherwise, even if V is nil.
+func (s Slice[T]) MarshalJSON() ([]byte, error) {
+	if !s.Valid {
+		return []byte("null"), nil
+	}
+	if s.V == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s.V)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports null and arrays of anything T can be decoded from.
+func (s *Slice[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		*s = Slice[T]{}
+		return nil
+	}
+	var v []T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal JSON: %w", err)
+	}
+	if v == nil {
+		v = []T{}
+	}
+	s.V, s.Valid = v, true
+	return nil
+}
+
+// Dimensions implements pgtype.ArrayGetter.
+func (s Slice[T]) Dimensions() []pgtype.ArrayDimension {
+	if !s.Valid {
+		return nil
+	}
+	return []pgtype.ArrayDimension{{Length: int32(len(s.V)), LowerBound: 1}}
+}
+
+// Index implements pgtype.ArrayGetter.
+func (s Slice[T]) Index(i int) any {
+	return s.V[i]
+}
+
+// IndexType implements pgtype.ArrayGetter.
+func (s Slice[T]) IndexType() any {
+	var el T
+	return el
+}
+
+// SetDimensions implements pgtype.ArraySetter, flattening multidimensional arrays.
+func (s *Slice[T]) SetDimensions(dimensions []pgtype.ArrayDimension) error {
+	if dimensions == nil {
+		*s = Slice[T]{}
+		return nil
+	}
+	n := 0
+	if len(dimensions) > 0 {
+		n = 1
+		for _, d := range dimensions {
+			n *= int(d.Length)
+		}
+	}
+	s.V, s.Valid = make([]T, n), true
+	return nil
+}
+
+// ScanIndex implements pgtype.ArraySetter.
+func (s *Slice[T]) ScanIndex(i int) any {
+	return &s.V[i]
+}
+
+// ScanIndexType implements pgtype.ArraySetter.
+func (s *Slice[T]) ScanIndexType() any {
+	return new(T)
+}
+
+// pgMaps holds pgtype maps used to convert arrays for database/sql, as a Map is not safe for concurrent use.
+var pgMaps = sync.Pool{New: func() any { return pgtype.NewMap() }}
+
+func arrayType[T any](m *pgtype.Map) (*pgtype.Type, error) {
+	dt, ok := m.TypeForValue([]T(nil))
+	if !ok {
+		return nil, fmt.Errorf("nullable: no PostgreSQL array type for []%T", *new(T))
+	}
+	return dt, nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It parses PostgreSQL array literals of any element type known to pgx.
+func (s *Slice[T]) Scan(value any) error {
+	var src []byte
+	switch v := value.(type) {
+	case nil:
+		*s = Slice[T]{}
+		return nil
+	case string:
+		src = []byte(v)
+	case []byte:
+		src = v
+	default:
+		return fmt.Errorf("nullable: cannot scan %T into Slice", value)
+	}
+	m := pgMaps.Get().(*pgtype.Map)
+	defer pgMaps.Put(m)
+	dt, err := arrayType[T](m)
+	if err != nil {
+		return err
+	}
+	return m.Scan(dt.OID, pgtype.TextFormatCode, src, s)
+}
+
+// Value implements the driver.Valuer interface.
+// It encodes a PostgreSQL array literal of any element type known to pgx.
+func (s Slice[T]) Value() (driver.Value, error) {
+	if !s.Valid {
+		return nil, nil
+	}
+	m := pgMaps.Get().(*pgtype.Map)
+	defer pgMaps.Put(m)
+	dt, err := arrayType[T](m)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := m.Encode(dt.OID, pgtype.TextFormatCode, s, nil)
+	if err != nil {
+		return nil, err
+	}
+	return string(buf), nil
+}
@@ -0,0 +1,27 @@
+package nullable
+
+import "log/slog"
+
+// Placeholders used when logging values that hold nothing.
+const (
+	NullPlaceholder      = "<null>"
+	UndefinedPlaceholder = "<undefined>"
+)
+
+// LogValue implements slog.LogValuer.
+// It renders null as <null> instead of the struct internals.
+func (n Null[T]) LogValue() slog.Value {
+	if !n.Valid {
+		return slog.StringValue(NullPlaceholder)
+	}
+	return slog.AnyValue(n.V)
+}
+
+// LogValue implements slog.LogValuer.
+// It renders undefined as <undefined> and null as <null> instead of the struct internals.
+func (o Optional[T]) LogValue() slog.Value {
+	if !o.Defined {
+		return slog.StringValue(UndefinedPlaceholder)
+	}
+	return o.Null().LogValue()
+}
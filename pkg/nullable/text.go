@@ -0,0 +1,85 @@
+package nullable
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// NullText is the text that null values marshal to and that is unmarshaled as null,
+// as in PostgreSQL COPY files. It is not empty, so empty strings marshal and unmarshal
+// as values. The empty string is still unmarshaled as null into types that cannot hold
+// it, such as numbers and times.
+const NullText = `\N`
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode NullText if this value is null.
+func (n Null[T]) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte(NullText), nil
+	}
+	s, err := formatText(n.V)
+	if err != nil {
+		return nil, fmt.Errorf("nullable: couldn't marshal text: %w", err)
+	}
+	return []byte(s), nil
+}
+
+// formatText renders v with MarshalText if it implements encoding.TextMarshaler,
+// and otherwise as strings, numbers, booleans and times are rendered in form input.
+func formatText(v any) (string, error) {
+	if tm, ok := v.(encoding.TextMarshaler); ok {
+		b, err := tm.MarshalText()
+		return string(b), err
+	}
+	var s string
+	if err := nullreflect.Assign(reflect.ValueOf(&s).Elem(), v); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It supports NullText, any input that T itself can be parsed from and the empty
+// string, which is null if T cannot be parsed from it.
+func (n *Null[T]) UnmarshalText(text []byte) error {
+	if string(text) == NullText {
+		*n = Null[T]{}
+		return nil
+	}
+	var v T
+	var err error
+	if tu, ok := any(&v).(encoding.TextUnmarshaler); ok {
+		err = tu.UnmarshalText(text)
+	} else {
+		err = nullreflect.Assign(reflect.ValueOf(&v).Elem(), string(text))
+	}
+	if err != nil && len(text) == 0 {
+		*n = Null[T]{}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal text: %w", err)
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode NullText if this value is undefined or null.
+func (o Optional[T]) MarshalText() ([]byte, error) {
+	return o.Null().MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// Any input, including NullText, marks o as defined.
+func (o *Optional[T]) UnmarshalText(text []byte) error {
+	var n Null[T]
+	if err := n.UnmarshalText(text); err != nil {
+		return err
+	}
+	*o = OptionalOf(n)
+	return nil
+}
@@ -0,0 +1,141 @@
+package nullable
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// LayoutUnixMilli is a pseudo layout for TimeLayouts and TimeOutputLayout that
+// stands for milliseconds since the Unix epoch, written as a JSON number.
+const LayoutUnixMilli = nullreflect.LayoutUnixMilli
+
+// TimeLayouts returns the layouts tried in order when parsing Time from JSON or text,
+// which every package of this module parses times with: RFC 3339, PostgreSQL's and
+// MySQL's textual timestamps, dates and LayoutUnixMilli.
+func TimeLayouts() []string {
+	return slices.Clone(nullreflect.TimeLayouts)
+}
+
+// SetTimeLayouts replaces the layouts returned by TimeLayouts.
+func SetTimeLayouts(layouts ...string) {
+	nullreflect.TimeLayouts = slices.Clone(layouts)
+}
+
+// TimeOutputLayout is the layout Time is marshaled with.
+var TimeOutputLayout = time.RFC3339Nano
+
+// TimeLocation is the location layouts without a zone offset are parsed in, by Time,
+// ParseTime and every other package of this module, and that times are converted to
+// before being marshaled. It must not be nil.
+//
+// SetTimeLayouts should only be called, and TimeOutputLayout and TimeLocation only be
+// changed, during initialization.
+var TimeLocation = time.UTC
+
+func init() {
+	nullreflect.TimeLocation = func() *time.Location { return TimeLocation }
+}
+
+// Time is a nullable time.Time that accepts several input layouts but always
+// marshals with TimeOutputLayout. It supports SQL, JSON and text serialization.
+type Time struct {
+	Null[time.Time]
+}
+
+// NewTime creates a new Time.
+func NewTime(t time.Time, valid bool) Time {
+	return Time{New(t, valid)}
+}
+
+// TimeFrom creates a new Time that will always be valid.
+func TimeFrom(t time.Time) Time {
+	return NewTime(t, true)
+}
+
+// ParseTime parses s with the first of TimeLayouts that accepts it.
+func ParseTime(s string) (time.Time, error) {
+	t, err := nullreflect.ParseTime(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("nullable: %w", err)
+	}
+	return t, nil
+}
+
+// formatTime renders t with TimeOutputLayout.
+func formatTime(t time.Time) string {
+	return string(appendTime(nil, t))
+}
+
+// appendTime appends t rendered with TimeOutputLayout to b.
+func appendTime(b []byte, t time.Time) []byte {
+	if TimeOutputLayout == LayoutUnixMilli {
+		return strconv.AppendInt(b, t.UnixMilli(), 10)
+	}
+	return t.In(TimeLocation).AppendFormat(b, TimeOutputLayout)
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this value is null, a number for LayoutUnixMilli,
+// and a string for any other output layout.
+func (t Time) MarshalJSON() ([]byte, error) {
+	return t.AppendJSON(make([]byte, 0, 40))
+}
+
+// AppendJSON appends the JSON encoding of t to b, as MarshalJSON would return it.
+// It shadows the method promoted from Null so TimeOutputLayout and TimeLocation apply.
+func (t Time) AppendJSON(b []byte) ([]byte, error) {
+	if !t.Valid {
+		return append(b, "null"...), nil
+	}
+	if TimeOutputLayout == LayoutUnixMilli {
+		return appendTime(b, t.V), nil
+	}
+	return nullreflect.AppendJSONString(b, formatTime(t.V)), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports null, empty strings as null, strings in any of TimeLayouts and,
+// if LayoutUnixMilli is accepted, numbers.
+func (t *Time) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		*t = Time{}
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("nullable: couldn't unmarshal JSON: %w", err)
+		}
+	}
+	return t.UnmarshalText([]byte(s))
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode NullText if this value is null.
+func (t Time) MarshalText() ([]byte, error) {
+	if !t.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(formatTime(t.V)), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It supports NullText, the empty string and any of TimeLayouts.
+func (t *Time) UnmarshalText(text []byte) error {
+	if s := string(text); s == NullText || s == "" {
+		*t = Time{}
+		return nil
+	}
+	v, err := ParseTime(string(text))
+	if err != nil {
+		return err
+	}
+	t.SetValid(v)
+	return nil
+}
@@ -0,0 +1,215 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// ErrInvalidUinfin is returned when a value is not a well-formed NRIC or FIN.
+// The offending value is left out of the message, as it is personal data.
+var ErrInvalidUinfin = errors.New("nullable: invalid NRIC/FIN")
+
+// Uinfin is a nullable Singapore NRIC or FIN, such as S1234567D. Decoding and
+// scanning normalize the case and reject values whose checksum letter does not
+// match, returning ErrInvalidUinfin. Mask renders it for display.
+type Uinfin struct {
+	V     string
+	Valid bool
+}
+
+// UinfinFrom creates a new Uinfin that will always be valid. It does not check s.
+func UinfinFrom(s string) Uinfin {
+	return Uinfin{V: s, Valid: true}
+}
+
+// ParseUinfin creates a new valid Uinfin from s, trimming white space,
+// upper-casing it and checking its prefix and checksum.
+func ParseUinfin(s string) (Uinfin, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if !validUinfin(s) {
+		return Uinfin{}, ErrInvalidUinfin
+	}
+	return UinfinFrom(s), nil
+}
+
+var uinfinWeights = [7]int{2, 7, 6, 5, 4, 3, 2}
+
+// validUinfin reports whether s is a prefix letter, seven digits and the matching checksum letter.
+func validUinfin(s string) bool {
+	if len(s) != 9 {
+		return false
+	}
+	sum := 0
+	for i, w := range uinfinWeights {
+		d := s[i+1]
+		if d < '0' || d > '9' {
+			return false
+		}
+		sum += int(d-'0') * w
+	}
+	var table string
+	switch s[0] {
+	case 'S':
+		table = "JZIHGFEDCBA"
+	case 'T':
+		table, sum = "JZIHGFEDCBA", sum+4
+	case 'F':
+		table = "XWUTRQPNMLK"
+	case 'G':
+		table, sum = "XWUTRQPNMLK", sum+4
+	case 'M':
+		table, sum = "XWUTRQPNJLK", sum+3
+	default:
+		return false
+	}
+	return s[8] == table[sum%11]
+}
+
+// Check returns ErrInvalidUinfin if u holds a value that is not a valid NRIC or FIN.
+func (u Uinfin) Check() error {
+	if u.Valid && !validUinfin(u.V) {
+		return ErrInvalidUinfin
+	}
+	return nil
+}
+
+// ValueOrZero returns the inner value if valid, otherwise "".
+func (u Uinfin) ValueOrZero() string {
+	if !u.Valid {
+		return ""
+	}
+	return u.V
+}
+
+// String returns the full NRIC or FIN, or <null> if null. Use Mask for display.
+func (u Uinfin) String() string {
+	if !u.Valid {
+		return NullPlaceholder
+	}
+	return u.V
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating u, such as nullable.UinfinFrom("S1234567D"), for %#v.
+func (u Uinfin) GoString() string {
+	if !u.Valid {
+		return "nullable.Uinfin{}"
+	}
+	return fmt.Sprintf("nullable.UinfinFrom(%q)", u.V)
+}
+
+// Mask returns u with all but its prefix and last four characters hidden,
+// e.g. S****567D, or <null> if null.
+func (u Uinfin) Mask() string {
+	if !u.Valid {
+		return NullPlaceholder
+	}
+	if len(u.V) < 5 {
+		return strings.Repeat("*", len(u.V))
+	}
+	return u.V[:1] + strings.Repeat("*", len(u.V)-5) + u.V[len(u.V)-4:]
+}
+
+// IsZero returns true for null values.
+// It lets encoding/json omit null fields tagged with omitzero.
+func (u Uinfin) IsZero() bool {
+	return !u.Valid
+}
+
+// LogValue implements slog.LogValuer.
+// It logs the masked value, and null as <null>.
+func (u Uinfin) LogValue() slog.Value {
+	if !u.Valid {
+		return slog.StringValue(NullPlaceholder)
+	}
+	return slog.StringValue(u.Mask())
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this value is null.
+func (u Uinfin) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(u.V)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports null and valid NRICs and FINs in any case.
+func (u *Uinfin) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		*u = Uinfin{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal JSON: %w", err)
+	}
+	v, err := ParseUinfin(s)
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode NullText if this value is null.
+func (u Uinfin) MarshalText() ([]byte, error) {
+	if !u.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(u.V), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It supports NullText, the empty string and valid NRICs and FINs in any case.
+func (u *Uinfin) UnmarshalText(text []byte) error {
+	if len(text) == 0 || string(text) == NullText {
+		*u = Uinfin{}
+		return nil
+	}
+	v, err := ParseUinfin(string(text))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It supports null and valid NRICs and FINs in any case.
+func (u *Uinfin) Scan(value any) error {
+	var s sql.NullString
+	if err := s.Scan(value); err != nil {
+		return err
+	}
+	if !s.Valid {
+		*u = Uinfin{}
+		return nil
+	}
+	v, err := ParseUinfin(s.String)
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+// It returns ErrInvalidUinfin instead of writing an invalid value.
+func (u Uinfin) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	if err := u.Check(); err != nil {
+		return nil, err
+	}
+	return u.V, nil
+}
@@ -0,0 +1,181 @@
+package nullable
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// UUID is a nullable UUID. It supports SQL, pgx, JSON and text serialization
+// using the canonical hyphenated form, and converts to and from github.com/google/uuid.
+type UUID struct {
+	Bytes [16]byte
+	Valid bool
+}
+
+// UUIDFrom creates a new UUID that will always be valid.
+func UUIDFrom(u uuid.UUID) UUID {
+	return UUID{Bytes: u, Valid: true}
+}
+
+// UUIDFromPtr creates a new UUID that will be null if u is nil.
+func UUIDFromPtr(u *uuid.UUID) UUID {
+	if u == nil {
+		return UUID{}
+	}
+	return UUIDFrom(*u)
+}
+
+// UUIDFromPg creates a new UUID from u.
+func UUIDFromPg(u pgtype.UUID) UUID {
+	return UUID{Bytes: u.Bytes, Valid: u.Valid}
+}
+
+// ParseUUID parses s in any of the forms accepted by uuid.Parse.
+func ParseUUID(s string) (UUID, error) {
+	u, err := uuid.Parse(s)
+	if err != nil {
+		return UUID{}, fmt.Errorf("nullable: couldn't parse UUID %q: %w", s, err)
+	}
+	return UUIDFrom(u), nil
+}
+
+// ValueOrZero returns the inner value if valid, otherwise uuid.Nil.
+func (u UUID) ValueOrZero() uuid.UUID {
+	if !u.Valid {
+		return uuid.Nil
+	}
+	return u.Bytes
+}
+
+// Ptr returns a pointer to a copy of this UUID's value, or nil if null.
+func (u UUID) Ptr() *uuid.UUID {
+	if !u.Valid {
+		return nil
+	}
+	v := uuid.UUID(u.Bytes)
+	return &v
+}
+
+// Pg returns u as a pgtype.UUID.
+func (u UUID) Pg() pgtype.UUID {
+	return pgtype.UUID{Bytes: u.Bytes, Valid: u.Valid}
+}
+
+// String returns u in the canonical hyphenated form, or <null> if null.
+func (u UUID) String() string {
+	if !u.Valid {
+		return NullPlaceholder
+	}
+	return uuid.UUID(u.Bytes).String()
+}
+
+// GoString implements fmt.GoStringer.
+// It returns a Go expression creating u, such as nullable.UUIDFrom(uuid.MustParse("…")), for %#v.
+func (u UUID) GoString() string {
+	if !u.Valid {
+		return "nullable.UUID{}"
+	}
+	return fmt.Sprintf("nullable.UUIDFrom(uuid.MustParse(%q))", u.String())
+}
+
+// IsZero returns true for null values.
+// It lets encoding/json omit null fields tagged with omitzero.
+func (u UUID) IsZero() bool {
+	return !u.Valid
+}
+
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this value is null.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	if !u.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(u.String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+// It supports null and strings in any of the forms accepted by uuid.Parse.
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullLiteral) {
+		*u = UUID{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal JSON: %w", err)
+	}
+	v, err := ParseUUID(s)
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It will encode NullText if this value is null.
+func (u UUID) MarshalText() ([]byte, error) {
+	if !u.Valid {
+		return []byte(NullText), nil
+	}
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It supports NullText, the empty string and any of the forms accepted by uuid.Parse.
+func (u *UUID) UnmarshalText(text []byte) error {
+	if len(text) == 0 || string(text) == NullText {
+		*u = UUID{}
+		return nil
+	}
+	v, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// Scan implements the sql.Scanner interface.
+// It supports textual UUIDs as well as 16 raw bytes.
+func (u *UUID) Scan(value any) error {
+	if value == nil {
+		*u = UUID{}
+		return nil
+	}
+	var v uuid.UUID
+	if err := v.Scan(value); err != nil {
+		return err
+	}
+	*u = UUIDFrom(v)
+	return nil
+}
+
+// Value implements the driver.Valuer interface.
+func (u UUID) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.String(), nil
+}
+
+// ScanUUID implements pgtype.UUIDScanner, so pgx decodes uuid columns directly.
+func (u *UUID) ScanUUID(v pgtype.UUID) error {
+	*u = UUIDFromPg(v)
+	return nil
+}
+
+// UUIDValue implements pgtype.UUIDValuer, so pgx encodes u as a uuid.
+func (u UUID) UUIDValue() (pgtype.UUID, error) {
+	return u.Pg(), nil
+}
+
+// GormDataType reports the column type of UUID to GORM.
+func (UUID) GormDataType() string {
+	return "uuid"
+}
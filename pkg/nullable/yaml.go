@@ -0,0 +1,48 @@
+package nullable
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this value is null.
+func (n Null[T]) MarshalYAML() (any, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.V, nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It supports any input that T itself can be decoded from.
+// yaml.v3 resets null nodes to the zero value without calling it, which is null.
+func (n *Null[T]) UnmarshalYAML(value *yaml.Node) error {
+	var v T
+	if err := value.Decode(&v); err != nil {
+		return fmt.Errorf("nullable: couldn't unmarshal YAML: %w", err)
+	}
+	n.V, n.Valid = v, true
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+// It will encode null if this value is undefined or null;
+// use omitempty to leave undefined values out entirely.
+func (o Optional[T]) MarshalYAML() (any, error) {
+	return o.Null().MarshalYAML()
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+// It is only called for keys that are present, so any input marks o as defined.
+// yaml.v3 resets null nodes to the zero value without calling it,
+// so a key explicitly set to null leaves o undefined.
+func (o *Optional[T]) UnmarshalYAML(value *yaml.Node) error {
+	var n Null[T]
+	if err := n.UnmarshalYAML(value); err != nil {
+		return err
+	}
+	*o = OptionalOf(n)
+	return nil
+}
@@ -0,0 +1,57 @@
+package nullable
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// NonZero creates a new Null that will be null if v is the zero value of T.
+func NonZero[T comparable](v T) Null[T] {
+	var zero T
+	return New(v, v != zero)
+}
+
+var scannerType = reflect.TypeFor[sql.Scanner]()
+
+// ZeroToNull sets every nullable field of dst holding a zero value, such as an empty
+// string, to null. Nullable fields are pointers and types implementing sql.Scanner;
+// plain fields and undefined values are left as they are.
+// It is meant for ingesting legacy structs that used zero values to mean "no value".
+//
+// dst must be a non-nil pointer to a struct.
+func ZeroToNull(dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("nullable: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	v = v.Elem()
+
+	for _, f := range nullreflect.Fields(v.Type()) {
+		if f.Type.Kind() != reflect.Pointer && !reflect.PointerTo(f.Type).Implements(scannerType) {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		val, state, err := nullreflect.Read(fv)
+		if err != nil {
+			return fmt.Errorf("nullable: field %s: %w", f.Name, err)
+		}
+		if state != nullstate.Present || !isZeroValue(val) {
+			continue
+		}
+		if err := nullreflect.Write(fv, nil, nullstate.Null); err != nil {
+			return fmt.Errorf("nullable: field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func isZeroValue(v any) bool {
+	if b, ok := v.([]byte); ok {
+		return len(b) == 0
+	}
+	return reflect.ValueOf(v).IsZero()
+}
@@ -0,0 +1,180 @@
+package nulljson
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+func TestEncodeAppendReusesBuffer(t *testing.T) {
+	buf := []byte("[")
+	buf, err := EncodeAppend(buf, names{Name: nullable.From("a")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf = append(buf, ',')
+	buf, err = EncodeAppend(buf, struct{ X int }{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf = append(buf, ']')
+	want := `[{"name":"a","alias":"","age":0,"tags":[],"consent":null,"nickname":null},{"X":1}]`
+	if string(buf) != want {
+		t.Errorf("EncodeAppend = %s, want %s", buf, want)
+	}
+}
+
+type inner struct {
+	Street nullable.Null[string] `json:"street,omitempty"`
+	Unit   string                `json:"unit"`
+}
+
+type embedded struct {
+	inner
+	Name string
+}
+
+type marshaler struct{ V int }
+
+func (m marshaler) MarshalJSON() ([]byte, error) { return []byte(`{"custom":true}`), nil }
+
+type ptrMarshaler struct{ V int }
+
+func (m *ptrMarshaler) MarshalText() ([]byte, error) { return []byte("ptr"), nil }
+
+type textKey string
+
+func (k textKey) MarshalText() ([]byte, error) { return []byte("k:" + string(k)), nil }
+
+type untagged struct {
+	String  string
+	Escaped string `json:"<html>&amp;"`
+	Unicode string `json:"名前"`
+	Int     int64
+	Uint    uint8
+	Float   float64
+	Small   float32
+	Bool    bool
+	Bytes   []byte
+	Ptr     *int
+	Iface   any
+	Map     map[textKey]int
+	Time    time.Time
+	Null    nullable.Null[int32]
+	NTime   nullable.Time
+	Date    nullable.Date
+	Decimal nullable.Decimal
+	UUID    nullable.UUID
+	JSON    nullable.JSON
+	Slice   nullable.Slice[int64]
+	Inner   inner
+	PInner  *inner
+	Omit    string               `json:",omitempty"`
+	OmitN   nullable.Null[int32] `json:",omitzero"`
+	AsStr   int                  `json:",string"`
+	Skip    string               `json:"-"`
+	Dash    string               `json:"-,"`
+	private string
+}
+
+func TestEncodeAppendMatchesJSONMarshal(t *testing.T) {
+	one := 1
+	id := uuid.MustParse("0190a1b2-c3d4-7e5f-8a9b-0c1d2e3f4a5b")
+	full := untagged{
+		String:  "line\n\"quoted\" <b>& ",
+		Escaped: "x",
+		Unicode: "陈",
+		Int:     -1 << 40,
+		Uint:    255,
+		Float:   1e21,
+		Small:   0.1,
+		Bool:    true,
+		Bytes:   []byte{0, 1, 2},
+		Ptr:     &one,
+		Iface:   map[string]any{"a": []any{1.5, nil}},
+		Map:     map[textKey]int{"b": 2, "a": 1},
+		Time:    time.Date(2024, 1, 2, 3, 4, 5, 6, time.FixedZone("SGT", 8*3600)),
+		Null:    nullable.From[int32](7),
+		NTime:   nullable.TimeFrom(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		Date:    nullable.NewDate(2024, 2, 29),
+		Decimal: nullable.DecimalFrom(decimal.RequireFromString("-12.50")),
+		UUID:    nullable.UUIDFrom(id),
+		JSON:    nullable.JSONFrom([]byte(`{"k":[1,2]}`)),
+		Slice:   nullable.SliceFrom([]int64{1, 2}),
+		Inner:   inner{Street: nullable.From("Orchard Rd"), Unit: "#01-01"},
+		PInner:  &inner{},
+		Omit:    "o",
+		OmitN:   nullable.From[int32](0),
+		AsStr:   42,
+		Skip:    "skipped",
+		Dash:    "dash",
+		private: "p",
+	}
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{name: "zero", v: untagged{}},
+		{name: "full", v: full},
+		{name: "pointer", v: &full},
+		{name: "nil pointer", v: (*untagged)(nil)},
+		{name: "small floats", v: struct{ A, B, C float64 }{1e-7, 123456789, -0.0}},
+		{name: "embedded", v: embedded{inner: inner{Unit: "u"}, Name: "n"}},
+		{name: "marshaler", v: marshaler{V: 1}},
+		{name: "pointer-only text marshaler", v: &ptrMarshaler{V: 1}},
+		{name: "struct holding a marshaler", v: struct{ M marshaler }{}},
+		{name: "invalid tag name", v: struct {
+			A string `json:"a b\\c"`
+		}{"a"}},
+		{name: "slice", v: []untagged{{}, full}},
+		{name: "nil", v: nil},
+		{name: "string", v: "s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := json.Marshal(tt.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := EncodeAppend(nil, tt.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("EncodeAppend =\n%s\njson.Marshal =\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestEncodeAppendErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{name: "NaN", v: struct{ F float64 }{math.NaN()}},
+		{name: "infinity", v: struct{ F float32 }{float32(math.Inf(1))}},
+		{name: "channel", v: struct{ C chan int }{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := json.Marshal(tt.v); err == nil {
+				t.Fatal("json.Marshal: want an error")
+			}
+			dst := []byte("kept")
+			got, err := EncodeAppend(dst, tt.v)
+			if err == nil {
+				t.Fatalf("EncodeAppend = %s, want an error", got)
+			}
+			if string(got) != "kept" {
+				t.Errorf("EncodeAppend returned %q on error, want dst unchanged", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Package nulljson decodes JSON request bodies into DTOs while keeping track of
+// which keys the client actually sent, so handlers can tell missing fields apart
+// from fields explicitly set to null without resorting to pointers.
+// Marshal encodes DTOs choosing the representation of null per field.
+package nulljson
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// FieldSet is the set of top-level JSON keys present in a decoded object.
+type FieldSet struct {
+	keys   map[string]struct{}
+	fields map[string]struct{}
+}
+
+// Has reports whether the JSON key was present.
+func (fs FieldSet) Has(key string) bool {
+	_, ok := fs.keys[key]
+	return ok
+}
+
+// HasField reports whether the Go struct field with the given name received a JSON key.
+func (fs FieldSet) HasField(name string) bool {
+	_, ok := fs.fields[name]
+	return ok
+}
+
+// Keys returns the present JSON keys in sorted order.
+func (fs FieldSet) Keys() []string {
+	keys := make([]string, 0, len(fs.keys))
+	for k := range fs.keys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Len returns the number of present JSON keys.
+func (fs FieldSet) Len() int {
+	return len(fs.keys)
+}
+
+// Decode reads a JSON object from r into v and returns the set of keys it contained.
+// v is decoded with encoding/json, so its usual rules for tags and
+// case-insensitive key matching apply.
+func Decode(r io.Reader, v any) (FieldSet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return FieldSet{}, fmt.Errorf("nulljson: reading body: %w", err)
+	}
+	return Unmarshal(data, v)
+}
+
+// Unmarshal is like Decode but reads from data.
+func Unmarshal(data []byte, v any) (FieldSet, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return FieldSet{}, fmt.Errorf("nulljson: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return FieldSet{}, fmt.Errorf("nulljson: %w", err)
+	}
+
+	fs := FieldSet{keys: make(map[string]struct{}, len(raw)), fields: make(map[string]struct{})}
+	for k := range raw {
+		fs.keys[k] = struct{}{}
+	}
+	if t := structType(v); t != nil {
+		for _, f := range nullreflect.Fields(t) {
+			if name, ok := f.JSONName(); ok && hasKey(raw, name) {
+				fs.fields[f.Name] = struct{}{}
+			}
+		}
+	}
+	return fs, nil
+}
+
+func structType(v any) reflect.Type {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+// hasKey matches name against raw the way encoding/json does,
+// preferring an exact match and falling back to case-insensitive matching.
+func hasKey(raw map[string]json.RawMessage, name string) bool {
+	if _, ok := raw[name]; ok {
+		return true
+	}
+	for k := range raw {
+		if strings.EqualFold(k, name) {
+			return true
+		}
+	}
+	return false
+}
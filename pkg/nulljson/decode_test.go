@@ -0,0 +1,92 @@
+package nulljson
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type request struct {
+	Name     nullable.Optional[string] `json:"name"`
+	Married  nullable.Optional[string] `json:"married_name"`
+	Age      nullable.Null[int32]
+	Internal string `json:"-"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		want       request
+		wantKeys   []string
+		wantFields []string
+	}{
+		{
+			name:       "empty object",
+			data:       `{}`,
+			wantKeys:   []string{},
+			wantFields: nil,
+		},
+		{
+			name:       "present and null",
+			data:       `{"name":"Tan","married_name":null}`,
+			want:       request{Name: nullable.OptionalFrom("Tan"), Married: nullable.OptionalNull[string]()},
+			wantKeys:   []string{"married_name", "name"},
+			wantFields: []string{"Married", "Name"},
+		},
+		{
+			name:       "case-insensitive and unknown keys",
+			data:       `{"AGE":3,"extra":1,"Internal":"x"}`,
+			want:       request{Age: nullable.From[int32](3)},
+			wantKeys:   []string{"AGE", "Internal", "extra"},
+			wantFields: []string{"Age"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got request
+			fs, err := Unmarshal([]byte(tt.data), &got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal = %#v, want %#v", got, tt.want)
+			}
+			if keys := fs.Keys(); !reflect.DeepEqual(keys, tt.wantKeys) || fs.Len() != len(tt.wantKeys) {
+				t.Errorf("Keys() = %q, Len() = %d, want %q", keys, fs.Len(), tt.wantKeys)
+			}
+			for _, k := range tt.wantKeys {
+				if !fs.Has(k) {
+					t.Errorf("Has(%q) = false", k)
+				}
+			}
+			for _, f := range []string{"Name", "Married", "Age", "Internal"} {
+				want := false
+				for _, w := range tt.wantFields {
+					want = want || w == f
+				}
+				if fs.HasField(f) != want {
+					t.Errorf("HasField(%q) = %v, want %v", f, !want, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	var got request
+	fs, err := Decode(strings.NewReader(`{"name":null}`), &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fs.HasField("Name") || got.Name != nullable.OptionalNull[string]() {
+		t.Errorf("Decode = %#v, %v, want Name explicitly null", got, fs.Keys())
+	}
+	for _, data := range []string{`[1]`, `{"age":"x"}`, `{`} {
+		if _, err := Decode(strings.NewReader(data), &got); err == nil || !strings.HasPrefix(err.Error(), "nulljson: ") {
+			t.Errorf("Decode(%s) = %v, want a nulljson error", data, err)
+		}
+	}
+}
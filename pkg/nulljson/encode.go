@@ -0,0 +1,35 @@
+package nulljson
+
+import (
+	"fmt"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// Marshal returns the JSON encoding of v, letting each field of a struct v choose how
+// it encodes null with a `nulljson` tag instead of a custom MarshalJSON on the DTO:
+//
+//	type Names struct {
+//		Name        nullable.Null[string]
+//		MarriedName nullable.Null[string] `nulljson:"omit"`  // left out when null
+//		Aliasnme    nullable.Null[string] `nulljson:"empty"` // "" when null
+//	}
+//
+// "emit" keeps "field": null, which is what fields without the tag get too, "omit"
+// drops the member and "empty" encodes the empty value of the field's type, such as
+// "", 0, false or []; types without one, such as nullable.Date, return an error.
+// Undefined fields count as null. v is otherwise marshaled with
+// encoding/json; httpnull.Marshal honours the same tag.
+func Marshal(v any) ([]byte, error) {
+	return EncodeAppend(nil, v)
+}
+
+// EncodeAppend appends the encoding of v, as Marshal returns it, to dst and returns the
+// extended buffer, like httpnull.EncodeAppend.
+func EncodeAppend(dst []byte, v any) ([]byte, error) {
+	out, err := nullreflect.AppendJSON(dst, v, nullreflect.JSONPolicy{})
+	if err != nil {
+		return dst, fmt.Errorf("nulljson: %w", err)
+	}
+	return out, nil
+}
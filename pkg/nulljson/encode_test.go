@@ -0,0 +1,75 @@
+package nulljson
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type names struct {
+	Name        nullable.Null[string]     `json:"name"`
+	MarriedName nullable.Null[string]     `json:"married_name" nulljson:"omit"`
+	Alias       nullable.Null[string]     `json:"alias" nulljson:"empty"`
+	Age         nullable.Optional[int32]  `json:"age" nulljson:"empty"`
+	Tags        nullable.Slice[string]    `json:"tags" nulljson:"empty"`
+	Consent     nullable.Optional[bool]   `json:"consent" nulljson:"emit"`
+	Nickname    nullable.Optional[string] `json:"nickname"`
+}
+
+func TestMarshalTags(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+		want string
+	}{
+		{
+			name: "nulls",
+			v:    names{},
+			want: `{"name":null,"alias":"","age":0,"tags":[],"consent":null,"nickname":null}`,
+		},
+		{
+			name: "values",
+			v: names{
+				Name:        nullable.From("Tan"),
+				MarriedName: nullable.From("Lee"),
+				Alias:       nullable.From("Ah Kow"),
+				Age:         nullable.OptionalFrom[int32](30),
+				Tags:        nullable.SliceFrom([]string{"a"}),
+				Consent:     nullable.OptionalFrom(true),
+				Nickname:    nullable.OptionalNull[string](),
+			},
+			want: `{"name":"Tan","married_name":"Lee","alias":"Ah Kow","age":30,"tags":["a"],"consent":true,"nickname":null}`,
+		},
+		{
+			name: "pointer",
+			v:    &names{Name: nullable.From("Tan")},
+			want: `{"name":"Tan","alias":"","age":0,"tags":[],"consent":null,"nickname":null}`,
+		},
+		{
+			name: "not a struct",
+			v:    []nullable.Null[int64]{nullable.From[int64](1), {}},
+			want: `[1,null]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalEmptyWithoutEmptyValue(t *testing.T) {
+	v := struct {
+		Birthday nullable.Date `nulljson:"empty"`
+	}{}
+	if _, err := Marshal(v); err == nil || !strings.HasPrefix(err.Error(), "nulljson: ") {
+		t.Errorf("Marshal = %v, want a nulljson error", err)
+	}
+}
@@ -0,0 +1,174 @@
+package nulltest
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// AssertDefined fails t unless v, a nullable value, pointer or plain value, is null or present.
+func AssertDefined(t testing.TB, v any) bool {
+	t.Helper()
+	_, state, err := read(v)
+	if err != nil {
+		t.Errorf("nulltest: %v", err)
+		return false
+	}
+	if state == nullable.StateUndefined {
+		t.Errorf("got undefined %T, want defined", v)
+		return false
+	}
+	return true
+}
+
+// AssertNull fails t unless v is explicitly null.
+func AssertNull(t testing.TB, v any) bool {
+	t.Helper()
+	val, state, err := read(v)
+	if err != nil {
+		t.Errorf("nulltest: %v", err)
+		return false
+	}
+	if state != nullable.StateNull {
+		t.Errorf("got %s, want null", describe(val, state))
+		return false
+	}
+	return true
+}
+
+// AssertValueEqual fails t unless got holds a value equal to want. Both are compared
+// through their driver values, so want may be a plain value or any nullable type.
+func AssertValueEqual(t testing.TB, got, want any) bool {
+	t.Helper()
+	gv, gs, err := read(got)
+	if err != nil {
+		t.Errorf("nulltest: got: %v", err)
+		return false
+	}
+	wv, ws, err := read(want)
+	if err != nil {
+		t.Errorf("nulltest: want: %v", err)
+		return false
+	}
+	if gs != nullable.StatePresent || gs != ws || !equalValues(gv, wv) {
+		t.Errorf("got %s, want %s", describe(gv, gs), describe(wv, ws))
+		return false
+	}
+	return true
+}
+
+// AssertFormEqual fails t unless got and want, structs or pointers to them, have the
+// same state and value in every same-named field, as forms.Equal does. The failure
+// lists every differing field along with both states.
+func AssertFormEqual(t testing.TB, got, want any) bool {
+	t.Helper()
+	gv := reflect.Indirect(reflect.ValueOf(got))
+	wv := reflect.Indirect(reflect.ValueOf(want))
+	if gv.Kind() != reflect.Struct || wv.Kind() != reflect.Struct {
+		t.Errorf("nulltest: AssertFormEqual needs structs, got %T and %T", got, want)
+		return false
+	}
+
+	diffs, err := formDiffs(gv, wv, false)
+	if err != nil {
+		t.Errorf("nulltest: %v", err)
+		return false
+	}
+	if len(diffs) > 0 {
+		t.Errorf("%T differs from %T:\n%s", got, want, strings.Join(diffs, "\n"))
+		return false
+	}
+	return true
+}
+
+// formDiffs lists the same-named fields of got and want differing in state or value,
+// treating undefined fields of want as null if undefinedAsNull is set.
+func formDiffs(got, want reflect.Value, undefinedAsNull bool) ([]string, error) {
+	fields, names, err := sides(got, want)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []string
+	for _, name := range names {
+		g, w := fields[name][0], fields[name][1]
+		if undefinedAsNull && w.state == nullable.StateUndefined {
+			w.state = nullable.StateNull
+		}
+		if g.state != w.state || !equalValues(g.val, w.val) {
+			diffs = append(diffs, fmt.Sprintf("\t%s: got %s, want %s", name, describe(g.val, g.state), describe(w.val, w.state)))
+		}
+	}
+	return diffs, nil
+}
+
+// side is the value and state of a field.
+type side struct {
+	val   driver.Value
+	state nullable.State
+}
+
+// sides reads the fields of structs a and b by name, returning the names in the order
+// first seen.
+func sides(a, b reflect.Value) (map[string][2]side, []string, error) {
+	fields := make(map[string][2]side)
+	var names []string
+	for i, v := range []reflect.Value{a, b} {
+		for _, f := range nullreflect.Fields(v.Type()) {
+			val, state, err := nullreflect.Read(v.FieldByIndex(f.Index))
+			if err != nil {
+				return nil, nil, fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			pair, seen := fields[f.Name]
+			if !seen {
+				names = append(names, f.Name)
+			}
+			pair[i] = side{val, state}
+			fields[f.Name] = pair
+		}
+	}
+	return fields, names, nil
+}
+
+func read(v any) (driver.Value, nullable.State, error) {
+	if v == nil {
+		return nil, nullable.StateNull, nil
+	}
+	return nullreflect.Read(reflect.ValueOf(v))
+}
+
+// equalValues compares two driver values, treating two nulls as equal.
+func equalValues(a, b driver.Value) bool {
+	switch av := a.(type) {
+	case time.Time:
+		bv, ok := b.(time.Time)
+		return ok && av.Equal(bv)
+	case []byte:
+		bv, ok := b.([]byte)
+		return ok && bytes.Equal(av, bv)
+	}
+	return a == b
+}
+
+// describe renders a value and its state for failure messages.
+func describe(v driver.Value, state nullable.State) string {
+	if state != nullable.StatePresent {
+		return state.String()
+	}
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case []byte:
+		return strconv.Quote(string(v))
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,269 @@
+// Package nulltest helps testing code built on nullable DTOs: it generates DTOs
+// with random combinations of undefined, null and present fields for property-based
+// tests, checks that they survive the JSON and PostgreSQL pipeline unchanged, and
+// asserts on field states with readable failure messages.
+package nulltest
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+	"pgregory.net/rapid"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type options struct {
+	undefinedRate float64
+	nullRate      float64
+	seed          int64
+	seeded        bool
+	runs          int
+	generators    map[reflect.Type]func(*rand.Rand) reflect.Value
+}
+
+// Option configures generation.
+type Option func(*options)
+
+// UndefinedRate sets the probability of leaving a field undefined, 1/3 by default.
+// It only applies to fields that can be undefined, such as nullable.Optional.
+func UndefinedRate(p float64) Option {
+	return func(o *options) {
+		o.undefinedRate = p
+	}
+}
+
+// NullRate sets the probability of a defined field being null, 1/2 by default.
+// It only applies to fields that can be null.
+func NullRate(p float64) Option {
+	return func(o *options) {
+		o.nullRate = p
+	}
+}
+
+// Seed makes Fill deterministic. Without it Fill picks a seed and logs it.
+func Seed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+		o.seeded = true
+	}
+}
+
+// For generates the values of type T with gen, such as enum members or well-formed
+// identifiers, instead of arbitrary ones. It applies to T fields as well as to the
+// values held by nullable fields of T.
+func For[T any](gen func(r *rand.Rand) T) Option {
+	return func(o *options) {
+		o.generators[reflect.TypeFor[T]()] = func(r *rand.Rand) reflect.Value {
+			return reflect.ValueOf(gen(r))
+		}
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		undefinedRate: 1.0 / 3,
+		nullRate:      0.5,
+		runs:          100,
+		generators: map[reflect.Type]func(*rand.Rand) reflect.Value{
+			reflect.TypeFor[time.Time](): func(r *rand.Rand) reflect.Value {
+				// Whole microseconds between 1970 and 2100 survive every database round trip.
+				return reflect.ValueOf(time.UnixMicro(r.Int63n(4102444800_000000)).UTC())
+			},
+			reflect.TypeFor[pgtype.InfinityModifier](): func(*rand.Rand) reflect.Value {
+				return reflect.ValueOf(pgtype.Finite)
+			},
+			reflect.TypeFor[decimal.Decimal](): func(r *rand.Rand) reflect.Value {
+				return reflect.ValueOf(decimal.New(r.Int63n(2_000_000)-1_000_000, -int32(r.Intn(5))))
+			},
+		},
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Fill overwrites every exported field of dst, a non-nil pointer to a struct, picking
+// for each field a state and, if present, an arbitrary value. Failures are reported
+// through t, along with the seed to replay the run with Seed.
+func Fill(t testing.TB, dst any, opts ...Option) {
+	t.Helper()
+	o := newOptions(opts)
+	if !o.seeded {
+		o.seed = time.Now().UnixNano()
+		t.Logf("nulltest: Fill seed %d", o.seed)
+	}
+	if err := Generate(rand.New(rand.NewSource(o.seed)), dst, opts...); err != nil {
+		t.Fatalf("nulltest: seed %d: %v", o.seed, err)
+	}
+}
+
+// Generate is Fill drawing from r, for use outside of tests. Seed is ignored.
+func Generate(r *rand.Rand, dst any, opts ...Option) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("nulltest: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	o := newOptions(opts)
+	return o.fill(r, v.Elem())
+}
+
+func (o *options) fill(r *rand.Rand, v reflect.Value) error {
+	for _, f := range nullreflect.Fields(v.Type()) {
+		if err := o.field(r, v.FieldByIndex(f.Index)); err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func (o *options) field(r *rand.Rand, fv reflect.Value) error {
+	t := fv.Type()
+	if gen, ok := o.generators[t]; ok {
+		fv.Set(gen(r))
+		return nil
+	}
+	if nullreflect.CanBeUndefined(t) && r.Float64() < o.undefinedRate {
+		return nullreflect.Write(fv, nil, nullable.StateUndefined)
+	}
+
+	inner, isNullable := nullreflect.Inner(t)
+	canBeNull := isNullable || t.Kind() == reflect.Pointer || hasValid(t)
+	if canBeNull && r.Float64() < o.nullRate {
+		// Writing null through Scan keeps Optional defined.
+		return nullreflect.Write(fv, nil, nullable.StateNull)
+	}
+
+	switch {
+	case isNullable:
+		val, err := o.value(r, inner)
+		if err != nil {
+			return err
+		}
+		fv.SetZero()
+		fv.FieldByName("V").Set(val)
+		fv.FieldByName("Valid").SetBool(true)
+		if d := fv.FieldByName("Defined"); d.IsValid() {
+			d.SetBool(true)
+		}
+		return nil
+	case hasValid(t):
+		fv.SetZero()
+		return o.valid(r, fv)
+	case t.Kind() == reflect.Pointer:
+		p := reflect.New(t.Elem())
+		if err := o.field(r, p.Elem()); err != nil {
+			return err
+		}
+		fv.Set(p)
+		return nil
+	}
+	val, err := o.value(r, t)
+	if err != nil {
+		return err
+	}
+	fv.Set(val)
+	return nil
+}
+
+// hasValid reports whether t is a struct with a Valid flag, like null.XxX, pgtype.XxX
+// and the nullable types that aren't built on Null.
+func hasValid(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	f, ok := t.FieldByName("Valid")
+	return ok && f.Type.Kind() == reflect.Bool
+}
+
+// valid sets the Valid flag of v and generates its other exported fields,
+// including those of embedded structs such as sql.NullString in null.String.
+func (o *options) valid(r *rand.Rand, v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		switch {
+		case sf.Name == "Valid":
+			v.Field(i).SetBool(true)
+		case sf.Anonymous && sf.Type.Kind() == reflect.Struct:
+			if err := o.valid(r, v.Field(i)); err != nil {
+				return err
+			}
+		case sf.IsExported():
+			val, err := o.value(r, sf.Type)
+			if err != nil {
+				return err
+			}
+			v.Field(i).Set(val)
+		}
+	}
+	return nil
+}
+
+// value generates an arbitrary t, using testing/quick for types without a generator.
+func (o *options) value(r *rand.Rand, t reflect.Type) (v reflect.Value, err error) {
+	if gen, ok := o.generators[t]; ok {
+		return gen(r), nil
+	}
+	defer func() {
+		// quick panics on structs with unexported fields.
+		if recover() != nil {
+			err = fmt.Errorf("cannot generate %s, register one with For", t)
+		}
+	}()
+	v, ok := quick.Value(t, r)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("cannot generate %s, register one with For", t)
+	}
+	return v, nil
+}
+
+// QuickValues returns a quick.Config.Values function generating the arguments of f,
+// the function under test, which must only take structs or pointers to them.
+func QuickValues(f any, opts ...Option) func([]reflect.Value, *rand.Rand) {
+	ft := reflect.TypeOf(f)
+	if ft == nil || ft.Kind() != reflect.Func {
+		panic(fmt.Sprintf("nulltest: QuickValues needs a function, got %T", f))
+	}
+	o := newOptions(opts)
+	return func(args []reflect.Value, r *rand.Rand) {
+		for i := range args {
+			t := ft.In(i)
+			elem := t
+			if t.Kind() == reflect.Pointer {
+				elem = t.Elem()
+			}
+			p := reflect.New(elem)
+			if err := o.fill(r, p.Elem()); err != nil {
+				panic("nulltest: " + err.Error())
+			}
+			if t.Kind() == reflect.Pointer {
+				args[i] = p
+			} else {
+				args[i] = p.Elem()
+			}
+		}
+	}
+}
+
+// Rapid returns a rapid generator of T, a struct type, filled like Fill does.
+// Values are derived from a drawn seed, so rapid can replay but not shrink them.
+func Rapid[T any](opts ...Option) *rapid.Generator[T] {
+	o := newOptions(opts)
+	return rapid.Custom(func(t *rapid.T) T {
+		r := rand.New(rand.NewSource(rapid.Int64().Draw(t, "seed")))
+		var dto T
+		if err := o.fill(r, reflect.ValueOf(&dto).Elem()); err != nil {
+			t.Fatalf("nulltest: %v", err)
+		}
+		return dto
+	})
+}
@@ -0,0 +1,189 @@
+package nulltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/convert"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/httpnull"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/pgargs"
+)
+
+// Runs sets the number of DTOs RoundTrip generates, 100 by default.
+func Runs(n int) Option {
+	return func(o *options) {
+		o.runs = n
+	}
+}
+
+// RoundTrip fills DTOs of the type of dto, a struct or pointer to one, like Fill does and
+// passes each through the whole pipeline, failing t at the first stage that loses a
+// value or a field state:
+//
+//   - json: encoded like httpnull.Marshal and decoded with encoding/json, keeping every state
+//   - pgtype: converted with convert.Struct into a struct of the pgtype.XxX matching each field,
+//     keeping values and nulls, undefined fields becoming null
+//   - sql: turned into arguments with pgargs.FromStruct, encoded in the binary format of the
+//     PostgreSQL type of their pgtype counterpart and scanned back into a new DTO, as if
+//     written with INSERT and read with SELECT, keeping every state since undefined
+//     fields are left out of the arguments
+//
+// No database is involved, so it runs in unit tests. Failures name the stage, the
+// differing fields and the seed replaying the runs with Seed.
+//
+//	func TestUinfinNamesRoundTrip(t *testing.T) {
+//		nulltest.RoundTrip(t, dtos.UinfinNamesForm{}, nulltest.For(validUinfin))
+//	}
+func RoundTrip(t testing.TB, dto any, opts ...Option) {
+	t.Helper()
+	dt := reflect.TypeOf(dto)
+	if dt != nil && dt.Kind() == reflect.Pointer {
+		dt = dt.Elem()
+	}
+	if dt == nil || dt.Kind() != reflect.Struct {
+		t.Fatalf("nulltest: RoundTrip needs a struct, got %T", dto)
+	}
+	o := newOptions(opts)
+	if !o.seeded {
+		o.seed = time.Now().UnixNano()
+		t.Logf("nulltest: RoundTrip seed %d", o.seed)
+	}
+	r := rand.New(rand.NewSource(o.seed))
+	schema, err := o.schema(r, dt)
+	if err != nil {
+		t.Fatalf("nulltest: seed %d: %v", o.seed, err)
+	}
+	for run := 1; run <= o.runs; run++ {
+		orig := reflect.New(dt)
+		if err := o.fill(r, orig.Elem()); err != nil {
+			t.Fatalf("nulltest: seed %d: %v", o.seed, err)
+		}
+		stage, diffs, err := schema.roundTrip(orig.Elem())
+		if err != nil || len(diffs) > 0 {
+			msg := strings.Join(diffs, "\n")
+			if err != nil {
+				msg = "\t" + err.Error()
+			}
+			t.Errorf("nulltest: seed %d, run %d: %s stage of %s:\n%s", o.seed, run, stage, dt, msg)
+			return
+		}
+	}
+}
+
+// column is the PostgreSQL representation of a field.
+type column struct {
+	field nullreflect.Field
+	name  string
+	oid   uint32
+}
+
+// schema maps the fields of a DTO type to their pgtype counterparts.
+type schema struct {
+	dto    reflect.Type
+	mirror reflect.Type
+	cols   []column
+	// types encodes and scans columns. A Map is not safe for concurrent use,
+	// so each RoundTrip has its own.
+	types *pgtype.Map
+}
+
+// schema picks the pgtype of each field of t after the driver value of a present value,
+// generated from r as no field type says which driver value it holds.
+func (o *options) schema(r *rand.Rand, t reflect.Type) (*schema, error) {
+	present := *o
+	present.undefinedRate, present.nullRate = 0, 0
+	s := &schema{dto: t, types: pgtype.NewMap()}
+	var fields []reflect.StructField
+	for _, f := range nullreflect.Fields(t) {
+		name, ok := f.Column()
+		if !ok {
+			continue
+		}
+		fv := reflect.New(f.Type).Elem()
+		if err := present.field(r, fv); err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		val, _, err := nullreflect.Read(fv)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		var pg any
+		switch val.(type) {
+		case int64:
+			pg = pgtype.Int8{}
+		case float64:
+			pg = pgtype.Float8{}
+		case bool:
+			pg = pgtype.Bool{}
+		case string:
+			pg = pgtype.Text{}
+		case []byte:
+			pg = []byte(nil)
+		case time.Time:
+			pg = pgtype.Timestamptz{}
+		default:
+			return nil, fmt.Errorf("field %s: no PostgreSQL type for %T", f.Name, val)
+		}
+		typ, ok := s.types.TypeForValue(pg)
+		if !ok {
+			return nil, fmt.Errorf("field %s: no PostgreSQL type for %T", f.Name, pg)
+		}
+		s.cols = append(s.cols, column{field: f, name: name, oid: typ.OID})
+		fields = append(fields, reflect.StructField{Name: f.Name, Type: reflect.TypeOf(pg)})
+	}
+	s.mirror = reflect.StructOf(fields)
+	return s, nil
+}
+
+// roundTrip passes orig through every stage, returning the first one that fails along
+// with the differences it caused.
+func (s *schema) roundTrip(orig reflect.Value) (stage string, diffs []string, err error) {
+	decoded := reflect.New(s.dto)
+	data, err := httpnull.Marshal(orig.Interface(), httpnull.EncodePolicy{})
+	if err == nil {
+		err = json.Unmarshal(data, decoded.Interface())
+	}
+	if err != nil {
+		return "json", nil, err
+	}
+	if diffs, err := formDiffs(decoded.Elem(), orig, false); err != nil || len(diffs) > 0 {
+		return "json", diffs, err
+	}
+
+	mirror := reflect.New(s.mirror)
+	if err := convert.Struct(decoded.Interface(), mirror.Interface()); err != nil {
+		return "pgtype", nil, err
+	}
+	if diffs, err := formDiffs(mirror.Elem(), decoded.Elem(), true); err != nil || len(diffs) > 0 {
+		return "pgtype", diffs, err
+	}
+
+	args := pgargs.FromStruct(decoded.Interface())
+	scanned := reflect.New(s.dto).Elem()
+	for _, c := range s.cols {
+		arg, ok := args[c.name]
+		if !ok {
+			continue
+		}
+		// Encoding into a non-nil buffer, as pgx does, tells empty values apart from NULL,
+		// which encodes as nil.
+		buf, err := s.types.Encode(c.oid, pgtype.BinaryFormatCode, arg, []byte{})
+		if err != nil {
+			return "sql", nil, fmt.Errorf("field %s: encode: %w", c.field.Name, err)
+		}
+		dst := scanned.FieldByIndex(c.field.Index).Addr().Interface()
+		if err := s.types.Scan(c.oid, pgtype.BinaryFormatCode, buf, dst); err != nil {
+			return "sql", nil, fmt.Errorf("field %s: scan: %w", c.field.Name, err)
+		}
+	}
+	diffs, err = formDiffs(scanned, orig, false)
+	return "sql", diffs, err
+}
@@ -0,0 +1,113 @@
+package nullvalidate
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Bounds checks the numeric fields of dto, a struct or pointer to one, against the
+// inclusive range in their `bounds` tag, written as "min,max" with either side left
+// empty for no limit:
+//
+//	Age    nullable.Int32   `bounds:"0,150"`
+//	Amount nullable.Decimal `bounds:"0.01,"`
+//
+// Only fields holding a value are checked; null and undefined ones always pass.
+// Integers, floats and decimals are compared exactly, and NaN and infinite floats are
+// never in range. Out of range fields are reported together as forms.FieldErrors keyed
+// by field name, such as "must be between 0 and 150".
+func Bounds(dto any) error {
+	v := reflect.Indirect(reflect.ValueOf(dto))
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("nullvalidate: dto must be a struct, got %T", dto)
+	}
+	var fe forms.FieldErrors
+	for _, f := range nullreflect.Fields(v.Type()) {
+		tag, ok := f.Tag.Lookup("bounds")
+		if !ok {
+			continue
+		}
+		lo, hi, err := parseBounds(tag)
+		if err != nil {
+			return fmt.Errorf("nullvalidate: field %s: %w", f.Name, err)
+		}
+		val, state, err := nullreflect.Read(v.FieldByIndex(f.Index))
+		if err != nil {
+			return fmt.Errorf("nullvalidate: field %s: %w", f.Name, err)
+		}
+		if state != nullable.StatePresent {
+			continue
+		}
+		if x, ok := val.(float64); ok && (math.IsNaN(x) || math.IsInf(x, 0)) {
+			fe.Add(f.Name, boundsMessage(lo, hi))
+			continue
+		}
+		d, err := toDecimal(val)
+		if err != nil {
+			return fmt.Errorf("nullvalidate: field %s: %w", f.Name, err)
+		}
+		if (lo != nil && d.LessThan(*lo)) || (hi != nil && d.GreaterThan(*hi)) {
+			fe.Add(f.Name, boundsMessage(lo, hi))
+		}
+	}
+	return fe.Err()
+}
+
+func parseBounds(tag string) (lo, hi *decimal.Decimal, err error) {
+	loText, hiText, ok := strings.Cut(tag, ",")
+	if !ok {
+		return nil, nil, fmt.Errorf("bounds %q must be written as min,max", tag)
+	}
+	parse := func(s string) (*decimal.Decimal, error) {
+		if s = strings.TrimSpace(s); s == "" {
+			return nil, nil
+		}
+		d, err := decimal.NewFromString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bound %q", s)
+		}
+		return &d, nil
+	}
+	if lo, err = parse(loText); err != nil {
+		return nil, nil, err
+	}
+	if hi, err = parse(hiText); err != nil {
+		return nil, nil, err
+	}
+	return lo, hi, nil
+}
+
+// toDecimal converts a numeric driver value; decimals arrive as strings.
+func toDecimal(val any) (decimal.Decimal, error) {
+	switch v := val.(type) {
+	case int64:
+		return decimal.NewFromInt(v), nil
+	case float64:
+		return decimal.NewFromFloat(v), nil
+	case string:
+		if d, err := decimal.NewFromString(v); err == nil {
+			return d, nil
+		}
+	}
+	return decimal.Decimal{}, fmt.Errorf("cannot check bounds of %T", val)
+}
+
+func boundsMessage(lo, hi *decimal.Decimal) string {
+	switch {
+	case lo == nil && hi == nil:
+		return "must be a finite number"
+	case lo == nil:
+		return "must be at most " + hi.String()
+	case hi == nil:
+		return "must be at least " + lo.String()
+	}
+	return "must be between " + lo.String() + " and " + hi.String()
+}
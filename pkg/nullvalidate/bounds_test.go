@@ -0,0 +1,120 @@
+package nullvalidate
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type boundedForm struct {
+	Age    nullable.Int32           `bounds:"0,150"`
+	Ratio  nullable.Null[float64]   `bounds:"0,1"`
+	Amount nullable.Decimal         `bounds:"0.01,"`
+	Score  nullable.Optional[int64] `bounds:",100"`
+	Weight null.Float               `bounds:" 0.5 , 500 "`
+	Any    nullable.Null[float64]   `bounds:","`
+	Free   nullable.Null[int64]
+}
+
+func TestBounds(t *testing.T) {
+	tests := []struct {
+		name string
+		dto  any
+		want forms.FieldErrors
+	}{
+		{name: "zero", dto: boundedForm{}},
+		{
+			name: "inclusive limits",
+			dto: boundedForm{
+				Age:    nullable.Int32From(150),
+				Ratio:  nullable.From(0.0),
+				Amount: nullable.DecimalFrom(decimal.RequireFromString("0.01")),
+				Score:  nullable.OptionalFrom[int64](100),
+				Weight: null.FloatFrom(0.5),
+				Any:    nullable.From(-1e300),
+				Free:   nullable.From[int64](-1),
+			},
+		},
+		{name: "null and undefined pass", dto: &boundedForm{Score: nullable.OptionalNull[int64]()}},
+		{
+			name: "out of range",
+			dto: boundedForm{
+				Age:    nullable.Int32From(-1),
+				Ratio:  nullable.From(1.0000001),
+				Amount: nullable.DecimalFrom(decimal.RequireFromString("0.009")),
+				Score:  nullable.OptionalFrom[int64](101),
+				Weight: null.FloatFrom(500.01),
+			},
+			want: forms.FieldErrors{
+				"Age":    {"must be between 0 and 150"},
+				"Ratio":  {"must be between 0 and 1"},
+				"Amount": {"must be at least 0.01"},
+				"Score":  {"must be at most 100"},
+				"Weight": {"must be between 0.5 and 500"},
+			},
+		},
+		{
+			name: "exact beyond float precision",
+			dto:  boundedForm{Amount: nullable.DecimalFrom(decimal.RequireFromString("0.0099999999999999999999"))},
+			want: forms.FieldErrors{"Amount": {"must be at least 0.01"}},
+		},
+		{name: "positive infinity", dto: boundedForm{Ratio: nullable.From(math.Inf(1))}, want: forms.FieldErrors{"Ratio": {"must be between 0 and 1"}}},
+		{name: "negative infinity", dto: boundedForm{Weight: null.FloatFrom(math.Inf(-1))}, want: forms.FieldErrors{"Weight": {"must be between 0.5 and 500"}}},
+		{name: "NaN", dto: boundedForm{Ratio: nullable.From(math.NaN())}, want: forms.FieldErrors{"Ratio": {"must be between 0 and 1"}}},
+		{name: "NaN without limits", dto: boundedForm{Any: nullable.From(math.NaN())}, want: forms.FieldErrors{"Any": {"must be a finite number"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Bounds(tt.dto)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Bounds = %v, want nil", err)
+				}
+				return
+			}
+			var fe forms.FieldErrors
+			if !errors.As(err, &fe) {
+				t.Fatalf("Bounds = %v, want forms.FieldErrors", err)
+			}
+			if !reflect.DeepEqual(fe, tt.want) {
+				t.Errorf("Bounds = %v, want %v", fe, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoundsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		dto     any
+		wantErr string
+	}{
+		{name: "not a struct", dto: 1, wantErr: "dto must be a struct"},
+		{name: "missing comma", dto: struct {
+			Age nullable.Int32 `bounds:"150"`
+		}{nullable.Int32From(1)}, wantErr: "must be written as min,max"},
+		{name: "invalid bound", dto: struct {
+			Age nullable.Int32 `bounds:"zero,"`
+		}{}, wantErr: `invalid bound "zero"`},
+		{name: "not numeric", dto: struct {
+			Name nullable.Null[string] `bounds:"0,1"`
+		}{nullable.From("x")}, wantErr: "cannot check bounds of string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Bounds(tt.dto)
+			var fe forms.FieldErrors
+			if err == nil || errors.As(err, &fe) || !strings.HasPrefix(err.Error(), "nullvalidate: ") || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Bounds = %v, want a nullvalidate error mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
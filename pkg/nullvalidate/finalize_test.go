@@ -0,0 +1,84 @@
+package nullvalidate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/guregu/null/v6"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type finalForm struct {
+	Name    nullable.Optional[string] `final:"required"`
+	Age     nullable.Null[int32]      `final:"required"`
+	Email   null.String               `final:"required"`
+	Consent nullable.Bool             `final:"required"`
+	Married nullable.Optional[string]
+	Note    nullable.Optional[string] `final:"optional"`
+}
+
+func TestFinalize(t *testing.T) {
+	tests := []struct {
+		name string
+		dto  any
+		want forms.FieldErrors
+	}{
+		{
+			name: "complete",
+			dto: finalForm{
+				Name:    nullable.OptionalFrom(""),
+				Age:     nullable.From[int32](0),
+				Email:   null.StringFrom("tan@example.com"),
+				Consent: nullable.BoolFrom(false),
+			},
+		},
+		{
+			name: "undefined and null are missing",
+			dto:  &finalForm{Name: nullable.OptionalNull[string](), Age: nullable.From[int32](30)},
+			want: forms.FieldErrors{"Name": {"required"}, "Email": {"required"}, "Consent": {"required"}},
+		},
+		{
+			name: "untagged fields are not checked",
+			dto: finalForm{
+				Name:    nullable.OptionalFrom("Tan"),
+				Age:     nullable.From[int32](30),
+				Email:   null.StringFrom("tan@example.com"),
+				Consent: nullable.BoolFrom(true),
+				Married: nullable.OptionalNull[string](),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Finalize(tt.dto)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("Finalize = %v, want nil", err)
+				}
+				return
+			}
+			var fe forms.FieldErrors
+			if !errors.As(err, &fe) {
+				t.Fatalf("Finalize = %v, want forms.FieldErrors", err)
+			}
+			if !reflect.DeepEqual(fe, tt.want) {
+				t.Errorf("Finalize = %v, want %v", fe, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinalizeErrors(t *testing.T) {
+	if err := Finalize("form"); err == nil {
+		t.Error("Finalize of a string: want an error")
+	}
+	bad := struct {
+		Data func() `final:"required"`
+	}{}
+	if err := Finalize(bad); err == nil {
+		t.Error("Finalize of an unreadable field: want an error")
+	}
+}
@@ -0,0 +1,198 @@
+// Package nullvalidate teaches github.com/go-playground/validator/v10 about nullable types,
+// so standard tags apply to the value they hold instead of to their struct internals.
+//
+//	type Form struct {
+//		Name nullable.Optional[string] `validate:"required_if_defined,omitnull,min=2"`
+//	}
+//
+// Null and undefined values are presented to validator as nil pointers:
+// omitnull (or omitnil) skips the remaining tags for them, required rejects them,
+// and required_if_defined only rejects explicit nulls.
+//
+// Fields that multi-step forms fill in over several steps can be tagged `final:"required"`
+// instead, and checked with Finalize when the form is submitted for good.
+package nullvalidate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/observe"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// New creates a validator with every nullable type and tag of this package registered.
+func New(opts ...validator.Option) *validator.Validate {
+	v := validator.New(opts...)
+	Register(v)
+	return v
+}
+
+// Register wires the nullable types and tags into an existing validator,
+// such as the engine behind gin's binding.Validator. Besides the types holding their
+// value in V, nullable.Date is presented as a time.Time at midnight UTC, the zero time for
+// infinite dates, nullable.Decimal as a float64 and nullable.UUID as its canonical string,
+// so tags such as gte or uuid4 apply. Enums are registered with RegisterEnum.
+func Register(v *validator.Validate) {
+	RegisterType[string](v)
+	RegisterType[int](v)
+	RegisterType[int16](v)
+	RegisterType[int32](v)
+	RegisterType[int64](v)
+	RegisterType[float32](v)
+	RegisterType[float64](v)
+	RegisterType[bool](v)
+	RegisterType[time.Time](v)
+
+	registerUnwrap(v, func(n null.String) (string, bool) { return n.String, n.Valid })
+	registerUnwrap(v, func(n null.Int) (int64, bool) { return n.Int64, n.Valid })
+	registerUnwrap(v, func(n null.Int32) (int32, bool) { return n.Int32, n.Valid })
+	registerUnwrap(v, func(n null.Int16) (int16, bool) { return n.Int16, n.Valid })
+	registerUnwrap(v, func(n null.Byte) (byte, bool) { return n.Byte, n.Valid })
+	registerUnwrap(v, func(n null.Float) (float64, bool) { return n.Float64, n.Valid })
+	registerUnwrap(v, func(n null.Bool) (bool, bool) { return n.Bool, n.Valid })
+	registerUnwrap(v, func(n null.Time) (time.Time, bool) { return n.Time, n.Valid })
+
+	registerUnwrap(v, func(n nullable.Int16) (int16, bool) { return n.V, n.Valid })
+	registerUnwrap(v, func(n nullable.Int32) (int32, bool) { return n.V, n.Valid })
+	registerUnwrap(v, func(n nullable.Int64) (int64, bool) { return n.V, n.Valid })
+	registerUnwrap(v, func(n nullable.Bool) (bool, bool) { return n.V, n.Valid })
+	registerUnwrap(v, func(n nullable.Time) (time.Time, bool) { return n.V, n.Valid })
+	registerUnwrap(v, func(n nullable.Date) (time.Time, bool) { return n.Time(), n.Valid })
+	registerUnwrap(v, func(n nullable.Decimal) (float64, bool) { return n.Decimal.InexactFloat64(), n.Valid })
+	registerUnwrap(v, func(n nullable.UUID) (string, bool) { return uuid.UUID(n.Bytes).String(), n.Valid })
+	registerUnwrap(v, func(n nullable.Uinfin) (string, bool) { return n.V, n.Valid })
+
+	v.RegisterAlias("omitnull", "omitnil")
+	// Registration only fails for empty or restricted tag names.
+	_ = v.RegisterValidation("required_if_defined", requiredIfDefined, true)
+}
+
+// RegisterType registers nullable.Null[T] and nullable.Optional[T] with v,
+// for element types beyond the ones Register covers.
+func RegisterType[T comparable](v *validator.Validate) {
+	v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		n := field.Interface().(nullable.Null[T])
+		if !n.Valid {
+			return (*T)(nil)
+		}
+		return n.V
+	}, nullable.Null[T]{})
+	v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		o := field.Interface().(nullable.Optional[T])
+		if !o.IsPresent() {
+			return (*T)(nil)
+		}
+		return o.V
+	}, nullable.Optional[T]{})
+}
+
+// RegisterEnum registers nullable.Enum[T] with v, presenting its value as a string so
+// tags such as oneof apply.
+func RegisterEnum[T ~string](v *validator.Validate) {
+	registerUnwrap(v, func(n nullable.Enum[T]) (string, bool) { return string(n.V), n.Valid })
+}
+
+func registerUnwrap[N any, T any](v *validator.Validate, get func(N) (T, bool)) {
+	var sample N
+	v.RegisterCustomTypeFunc(func(field reflect.Value) any {
+		val, ok := get(field.Interface().(N))
+		if !ok {
+			return (*T)(nil)
+		}
+		return val
+	}, sample)
+}
+
+// requiredIfDefined passes for undefined fields and values, failing only for explicit nulls.
+func requiredIfDefined(fl validator.FieldLevel) bool {
+	if parent := reflect.Indirect(fl.Parent()); parent.Kind() == reflect.Struct {
+		if orig := parent.FieldByName(fl.StructFieldName()); orig.IsValid() && nullreflect.IsUndefined(orig) {
+			return true
+		}
+	}
+	field := fl.Field()
+	switch field.Kind() {
+	case reflect.Invalid:
+		return false
+	case reflect.Pointer, reflect.Interface:
+		return !field.IsNil()
+	}
+	return true
+}
+
+// Struct validates dto with v.Struct, counting each failing field in package metrics,
+// and returns the error of v.Struct unchanged.
+func Struct(v *validator.Validate, dto any) error {
+	return record(v.Struct(dto))
+}
+
+// StructPartial is Struct for the given fields only, as v.StructPartial validates them.
+func StructPartial(v *validator.Validate, dto any, fields ...string) error {
+	return record(v.StructPartial(dto, fields...))
+}
+
+// record reports the validator.ValidationErrors wrapped by err to the observer and
+// returns err.
+func record(err error) error {
+	var ves validator.ValidationErrors
+	if errors.As(err, &ves) {
+		for _, e := range ves {
+			dto, field, _ := strings.Cut(e.StructNamespace(), ".")
+			observe.Invalid(dto, field, e.Tag())
+		}
+	}
+	return err
+}
+
+// FieldErrors converts the validator.ValidationErrors wrapped by err into forms.FieldErrors,
+// keyed by field name with the failing tag, such as required, as message. Field names
+// follow validator, so register a tag name func to report JSON names instead.
+// It returns nil if err holds no validation errors.
+func FieldErrors(err error) forms.FieldErrors {
+	var ves validator.ValidationErrors
+	if !errors.As(err, &ves) {
+		return nil
+	}
+	var fe forms.FieldErrors
+	for _, e := range ves {
+		fe.Add(e.Field(), e.Tag())
+	}
+	return fe
+}
+
+// Finalize checks the fields of dto, a struct or pointer to one, tagged `final:"required"`,
+// for the last submission of a multi-step form. Such fields may stay undefined while
+// intermediate steps are validated, but must hold a value, neither null nor undefined,
+// once the form is finalized. Missing fields are reported together as forms.FieldErrors
+// keyed by field name, with "required" as message.
+func Finalize(dto any) error {
+	v := reflect.Indirect(reflect.ValueOf(dto))
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("nullvalidate: dto must be a struct, got %T", dto)
+	}
+	var fe forms.FieldErrors
+	for _, f := range nullreflect.Fields(v.Type()) {
+		if f.Tag.Get("final") != "required" {
+			continue
+		}
+		_, state, err := nullreflect.Read(v.FieldByIndex(f.Index))
+		if err != nil {
+			return fmt.Errorf("nullvalidate: field %s: %w", f.Name, err)
+		}
+		if state != nullable.StatePresent {
+			fe.Add(f.Name, "required")
+			observe.Invalid(v.Type().Name(), f.Name, "required")
+		}
+	}
+	return fe.Err()
+}
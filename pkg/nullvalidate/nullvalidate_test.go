@@ -0,0 +1,166 @@
+package nullvalidate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/guregu/null/v6"
+	"github.com/shopspring/decimal"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type testStatus string
+
+func init() {
+	nullable.RegisterEnum[testStatus]("draft", "submitted")
+}
+
+type applicant struct {
+	Name     nullable.Optional[string] `validate:"required_if_defined,omitnull,min=2"`
+	Married  nullable.Optional[string] `validate:"omitnull,min=2"`
+	Age      nullable.Null[int32]      `validate:"omitnull,gte=18"`
+	Email    null.String               `validate:"required,email"`
+	Phone    nullable.Null[string]     `validate:"omitnil,numeric"`
+	Score    nullable.Int64            `validate:"omitnull,lte=100"`
+	Consent  nullable.Bool             `validate:"required"`
+	Born     nullable.Date             `validate:"omitnull,ltfield=Now"`
+	Seen     nullable.Time             `validate:"omitnull,ltefield=Now"`
+	Income   nullable.Decimal          `validate:"omitnull,gt=0"`
+	ID       nullable.UUID             `validate:"omitnull,uuid4"`
+	Uinfin   nullable.Uinfin           `validate:"omitnull,len=9"`
+	Status   nullable.Enum[testStatus] `validate:"omitnull,oneof=draft submitted"`
+	Now      time.Time
+	Verified null.Bool `validate:"omitnull,eq=true"`
+}
+
+func valid() applicant {
+	now := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	return applicant{
+		Name:    nullable.OptionalFrom("Tan"),
+		Age:     nullable.From[int32](30),
+		Email:   null.StringFrom("tan@example.com"),
+		Phone:   nullable.From("91234567"),
+		Score:   nullable.Int64From(100),
+		Consent: nullable.BoolFrom(true),
+		Born:    nullable.NewDate(1990, time.May, 17),
+		Seen:    nullable.TimeFrom(now.Add(-time.Hour)),
+		Income:  nullable.DecimalFrom(decimal.RequireFromString("0.01")),
+		ID:      nullable.UUIDFrom(uuid.MustParse("9b2e1c7a-4b8f-4e3a-9d2c-1f0a6b5c4d3e")),
+		Uinfin:  nullable.UinfinFrom("S1234567D"),
+		Status:  nullable.EnumFrom[testStatus]("draft"),
+		Now:     now,
+	}
+}
+
+func TestRegister(t *testing.T) {
+	v := New()
+	RegisterEnum[testStatus](v)
+	tests := []struct {
+		name   string
+		modify func(*applicant)
+		want   forms.FieldErrors
+	}{
+		{name: "valid", modify: func(*applicant) {}},
+		{
+			name: "nulls skip omitnull tags",
+			modify: func(a *applicant) {
+				a.Married = nullable.OptionalNull[string]()
+				a.Age, a.Phone = nullable.Null[int32]{}, nullable.Null[string]{}
+				a.Score, a.Born, a.Seen = nullable.Int64{}, nullable.Date{}, nullable.Time{}
+				a.Income, a.ID, a.Uinfin, a.Status = nullable.Decimal{}, nullable.UUID{}, nullable.Uinfin{}, nullable.Enum[testStatus]{}
+			},
+		},
+		{name: "undefined passes required_if_defined", modify: func(a *applicant) { a.Name = nullable.Optional[string]{} }},
+		{
+			name: "null fails required_if_defined and required",
+			modify: func(a *applicant) {
+				a.Name, a.Email, a.Consent = nullable.OptionalNull[string](), null.String{}, nullable.Bool{}
+			},
+			want: forms.FieldErrors{"Name": {"required_if_defined"}, "Email": {"required"}, "Consent": {"required"}},
+		},
+		{
+			name: "tags apply to the values",
+			modify: func(a *applicant) {
+				a.Name, a.Married = nullable.OptionalFrom("T"), nullable.OptionalFrom("L")
+				a.Age, a.Email, a.Phone = nullable.From[int32](17), null.StringFrom("tan"), nullable.From("9123 4567")
+				a.Score, a.Verified = nullable.Int64From(101), null.BoolFrom(false)
+			},
+			want: forms.FieldErrors{
+				"Name":     {"min"},
+				"Married":  {"min"},
+				"Age":      {"gte"},
+				"Email":    {"email"},
+				"Phone":    {"numeric"},
+				"Score":    {"lte"},
+				"Verified": {"eq"},
+			},
+		},
+		{
+			name: "time, date, decimal, uuid, uinfin and enum values",
+			modify: func(a *applicant) {
+				a.Born = nullable.NewDate(2024, time.March, 1)
+				a.Seen = nullable.TimeFrom(a.Now.Add(time.Second))
+				a.Income = nullable.DecimalFrom(decimal.Zero)
+				a.ID = nullable.UUIDFrom(uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+				a.Uinfin = nullable.Uinfin{V: "S123", Valid: true}
+				a.Status = nullable.Enum[testStatus]{V: "archived", Valid: true}
+			},
+			want: forms.FieldErrors{
+				"Born":   {"ltfield"},
+				"Seen":   {"ltefield"},
+				"Income": {"gt"},
+				"ID":     {"uuid4"},
+				"Uinfin": {"len"},
+				"Status": {"oneof"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := valid()
+			tt.modify(&a)
+			err := Struct(v, &a)
+			if got := FieldErrors(err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FieldErrors(Struct) = %v, want %v (err %v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestStructPartial(t *testing.T) {
+	v := New()
+	a := valid()
+	a.Age = nullable.From[int32](1)
+	a.Email = null.String{}
+	err := StructPartial(v, &a, "Age")
+	if got, want := FieldErrors(err), (forms.FieldErrors{"Age": {"gte"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("FieldErrors(StructPartial) = %v, want %v", got, want)
+	}
+}
+
+func TestRegisterType(t *testing.T) {
+	type code uint8
+	v := validator.New()
+	Register(v)
+	RegisterType[code](v)
+	dto := struct {
+		Level nullable.Optional[code] `validate:"omitnull,max=3"`
+	}{nullable.OptionalFrom[code](4)}
+	if got, want := FieldErrors(v.Struct(dto)), (forms.FieldErrors{"Level": {"max"}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("FieldErrors = %v, want %v", got, want)
+	}
+}
+
+func TestFieldErrorsOfOtherErrors(t *testing.T) {
+	for _, err := range []error{nil, errors.New("boom")} {
+		if fe := FieldErrors(err); fe != nil {
+			t.Errorf("FieldErrors(%v) = %v, want nil", err, fe)
+		}
+	}
+}
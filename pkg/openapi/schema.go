@@ -0,0 +1,154 @@
+// Package openapi derives OpenAPI 3.1 schemas from nullable DTOs, so API docs
+// match the wire format: nullable fields accept null and Optional fields are not required.
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/guregu/null/v6"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// Schema is the subset of the OpenAPI 3.1 Schema Object that SchemaFor produces.
+type Schema struct {
+	Type                 Types              `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Types is the JSON Schema type keyword, encoded as a single string when it holds one type.
+type Types []string
+
+// MarshalJSON implements json.Marshaler.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// nullable returns a copy of s that also accepts null.
+func (s *Schema) nullable() *Schema {
+	c := *s
+	for _, t := range c.Type {
+		if t == "null" {
+			return &c
+		}
+	}
+	c.Type = append(append(Types{}, c.Type...), "null")
+	return &c
+}
+
+// SchemaFor returns the schema of T.
+func SchemaFor[T any]() *Schema {
+	return schemaOf(reflect.TypeFor[T](), make(map[reflect.Type]bool))
+}
+
+// knownTypes maps nullable types from other packages to their schema; all of them accept null.
+var knownTypes = map[reflect.Type]Schema{
+	reflect.TypeFor[null.String]():        {Type: Types{"string"}},
+	reflect.TypeFor[null.Int]():           {Type: Types{"integer"}, Format: "int64"},
+	reflect.TypeFor[null.Int32]():         {Type: Types{"integer"}, Format: "int32"},
+	reflect.TypeFor[null.Int16]():         {Type: Types{"integer"}, Format: "int32"},
+	reflect.TypeFor[null.Byte]():          {Type: Types{"integer"}, Format: "int32"},
+	reflect.TypeFor[null.Float]():         {Type: Types{"number"}, Format: "double"},
+	reflect.TypeFor[null.Bool]():          {Type: Types{"boolean"}},
+	reflect.TypeFor[null.Time]():          {Type: Types{"string"}, Format: "date-time"},
+	reflect.TypeFor[pgtype.Text]():        {Type: Types{"string"}},
+	reflect.TypeFor[pgtype.Int2]():        {Type: Types{"integer"}, Format: "int32"},
+	reflect.TypeFor[pgtype.Int4]():        {Type: Types{"integer"}, Format: "int32"},
+	reflect.TypeFor[pgtype.Int8]():        {Type: Types{"integer"}, Format: "int64"},
+	reflect.TypeFor[pgtype.Float4]():      {Type: Types{"number"}, Format: "float"},
+	reflect.TypeFor[pgtype.Float8]():      {Type: Types{"number"}, Format: "double"},
+	reflect.TypeFor[pgtype.Bool]():        {Type: Types{"boolean"}},
+	reflect.TypeFor[pgtype.Timestamptz](): {Type: Types{"string"}, Format: "date-time"},
+	reflect.TypeFor[pgtype.Timestamp]():   {Type: Types{"string"}, Format: "date-time"},
+	reflect.TypeFor[pgtype.Date]():        {Type: Types{"string"}, Format: "date"},
+	reflect.TypeFor[pgtype.UUID]():        {Type: Types{"string"}, Format: "uuid"},
+}
+
+func schemaOf(t reflect.Type, visiting map[reflect.Type]bool) *Schema {
+	if s, ok := knownTypes[t]; ok {
+		return s.nullable()
+	}
+	if inner, ok := nullreflect.Inner(t); ok {
+		return schemaOf(inner, visiting).nullable()
+	}
+	if t == reflect.TypeFor[time.Time]() {
+		return &Schema{Type: Types{"string"}, Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaOf(t.Elem(), visiting).nullable()
+	case reflect.String:
+		return &Schema{Type: Types{"string"}}
+	case reflect.Bool:
+		return &Schema{Type: Types{"boolean"}}
+	case reflect.Int64, reflect.Uint32, reflect.Uint64, reflect.Int, reflect.Uint:
+		return &Schema{Type: Types{"integer"}, Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: Types{"integer"}, Format: "int32"}
+	case reflect.Float32:
+		return &Schema{Type: Types{"number"}, Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: Types{"number"}, Format: "double"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: Types{"string"}, Format: "byte"}
+		}
+		return &Schema{Type: Types{"array"}, Items: schemaOf(t.Elem(), visiting)}
+	case reflect.Map:
+		return &Schema{Type: Types{"object"}, AdditionalProperties: schemaOf(t.Elem(), visiting)}
+	case reflect.Struct:
+		return structSchema(t, visiting)
+	}
+	return &Schema{}
+}
+
+func structSchema(t reflect.Type, visiting map[reflect.Type]bool) *Schema {
+	s := &Schema{Type: Types{"object"}}
+	if visiting[t] {
+		return s
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	s.Properties = make(map[string]*Schema)
+	for _, f := range nullreflect.Fields(t) {
+		name, ok := f.JSONName()
+		if !ok {
+			continue
+		}
+		s.Properties[name] = schemaOf(f.Type, visiting)
+		if isRequired(f) {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// isRequired reports whether f is always present in encoded JSON:
+// fields that can be undefined or that are tagged omitempty or omitzero may be left out.
+func isRequired(f nullreflect.Field) bool {
+	if reflect.PointerTo(f.Type).Implements(definerType) || f.Type.Implements(definerType) {
+		return false
+	}
+	_, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == "omitempty" || opt == "omitzero" {
+			return false
+		}
+	}
+	return true
+}
+
+var definerType = reflect.TypeFor[interface{ IsDefined() bool }]()
@@ -0,0 +1,71 @@
+// Package otelnull turns nullable DTOs into OpenTelemetry attributes, so tracing form
+// submissions doesn't require plumbing every field by hand.
+//
+//	span.SetAttributes(otelnull.Attributes(form, "name", "email")...)
+package otelnull
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// Attributes returns one attribute per present field of dto, a struct or pointer to one,
+// keyed by its JSON name. Null and undefined fields are left out, as are fields tagged
+// with `pii`, whatever its value, and fields that cannot be read or hold binary data.
+// If allowlist is not empty only the fields it names, by JSON or Go field name, are considered.
+//
+// Strings, integers, floats and booleans keep their type; times are formatted as RFC 3339.
+func Attributes(dto any, allowlist ...string) []attribute.KeyValue {
+	v := reflect.Indirect(reflect.ValueOf(dto))
+	if v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("otelnull: dto must be a struct, got %T", dto))
+	}
+
+	var attrs []attribute.KeyValue
+	for _, f := range nullreflect.Fields(v.Type()) {
+		name, ok := f.JSONName()
+		if !ok || f.Tag.Get("pii") != "" {
+			continue
+		}
+		if len(allowlist) > 0 && !slices.Contains(allowlist, name) && !slices.Contains(allowlist, f.Name) {
+			continue
+		}
+		val, state, err := nullreflect.Read(v.FieldByIndex(f.Index))
+		if err != nil || state != nullstate.Present {
+			continue
+		}
+		if kv, ok := attr(attribute.Key(name), val); ok {
+			attrs = append(attrs, kv)
+		}
+	}
+	return attrs
+}
+
+// attr converts driver value val into an attribute.
+func attr(key attribute.Key, val any) (attribute.KeyValue, bool) {
+	switch val := val.(type) {
+	case string:
+		return key.String(val), true
+	case int64:
+		return key.Int64(val), true
+	case float64:
+		return key.Float64(val), true
+	case bool:
+		return key.Bool(val), true
+	case time.Time:
+		return key.String(val.Format(time.RFC3339Nano)), true
+	case []byte:
+		if utf8.Valid(val) {
+			return key.String(string(val)), true
+		}
+	}
+	return attribute.KeyValue{}, false
+}
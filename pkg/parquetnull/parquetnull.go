@@ -0,0 +1,280 @@
+// Package parquetnull writes and reads Parquet files of nullable DTOs with
+// github.com/parquet-go/parquet-go.
+//
+// Fields of nullable types and pointers map to optional Parquet columns holding
+// their inner value, so null and empty strings stay apart in exports; other fields
+// map to required columns. Parquet has no notion of undefined: undefined fields are
+// written as null and read back as explicit nulls. Columns are named after the
+// `parquet` tag or the snake_cased field name; `parquet:"-"` skips a field.
+package parquetnull
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// column describes how a field maps to a Parquet column.
+type column struct {
+	name     string
+	index    []int
+	elem     reflect.Type // type of the value held by the field
+	optional bool
+}
+
+func columns(t reflect.Type) ([]column, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("parquetnull: %s is not a struct", t)
+	}
+	var cols []column
+	for _, f := range nullreflect.Fields(t) {
+		tag := f.Tag.Get("parquet")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = nullreflect.SnakeCase(f.Name)
+		}
+		c := column{name: name, index: f.Index, elem: f.Type}
+		if inner, ok := nullreflect.Inner(f.Type); ok {
+			c.elem, c.optional = inner, true
+		} else if f.Type.Kind() == reflect.Pointer {
+			c.elem, c.optional = f.Type.Elem(), true
+		}
+		cols = append(cols, c)
+	}
+	return cols, nil
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// leaf returns the Parquet node storing values of t.
+func leaf(t reflect.Type) (parquet.Node, error) {
+	if t == timeType {
+		return parquet.Timestamp(parquet.Microsecond), nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return parquet.String(), nil
+	case reflect.Bool:
+		return parquet.Leaf(parquet.BooleanType), nil
+	case reflect.Int, reflect.Int64:
+		return parquet.Int(64), nil
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return parquet.Int(t.Bits()), nil
+	case reflect.Uint, reflect.Uint64:
+		return parquet.Uint(64), nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return parquet.Uint(t.Bits()), nil
+	case reflect.Float32:
+		return parquet.Leaf(parquet.FloatType), nil
+	case reflect.Float64:
+		return parquet.Leaf(parquet.DoubleType), nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return parquet.Leaf(parquet.ByteArrayType), nil
+		}
+	}
+	return nil, fmt.Errorf("parquetnull: unsupported type %s", t)
+}
+
+// SchemaFor returns the Parquet schema of T, a struct.
+func SchemaFor[T any]() (*parquet.Schema, error) {
+	t := reflect.TypeFor[T]()
+	cols, err := columns(t)
+	if err != nil {
+		return nil, err
+	}
+	return schema(t, cols)
+}
+
+func schema(t reflect.Type, cols []column) (*parquet.Schema, error) {
+	group := make(parquet.Group, len(cols))
+	for _, c := range cols {
+		node, err := leaf(c.elem)
+		if err != nil {
+			return nil, fmt.Errorf("parquetnull: field %s: %w", c.name, err)
+		}
+		if c.optional {
+			node = parquet.Optional(node)
+		}
+		group[c.name] = node
+	}
+	return parquet.NewSchema(t.Name(), group), nil
+}
+
+// Write encodes rows as a Parquet file to w. opts configure the writer, such as its compression.
+func Write[T any](w io.Writer, rows []T, opts ...parquet.WriterOption) error {
+	t := reflect.TypeFor[T]()
+	cols, err := columns(t)
+	if err != nil {
+		return err
+	}
+	s, err := schema(t, cols)
+	if err != nil {
+		return err
+	}
+	leaves := make([]int, len(cols))
+	for i, c := range cols {
+		lc, _ := s.Lookup(c.name)
+		leaves[i] = lc.ColumnIndex
+	}
+
+	pw := parquet.NewWriter(w, append([]parquet.WriterOption{s}, opts...)...)
+	for n, row := range rows {
+		v := reflect.ValueOf(row)
+		out := make(parquet.Row, len(cols))
+		for i, c := range cols {
+			val, err := encode(v.FieldByIndex(c.index), c)
+			if err != nil {
+				return fmt.Errorf("parquetnull: row %d, column %s: %w", n, c.name, err)
+			}
+			def := 0
+			if c.optional && !val.IsNull() {
+				def = 1
+			}
+			out[leaves[i]] = val.Level(0, def, leaves[i])
+		}
+		if _, err := pw.WriteRows([]parquet.Row{out}); err != nil {
+			return fmt.Errorf("parquetnull: %w", err)
+		}
+	}
+	if err := pw.Close(); err != nil {
+		return fmt.Errorf("parquetnull: %w", err)
+	}
+	return nil
+}
+
+// encode converts the field fv into a Parquet value, null for null and undefined fields.
+func encode(fv reflect.Value, c column) (parquet.Value, error) {
+	dv, state, err := nullreflect.Read(fv)
+	if err != nil {
+		return parquet.Value{}, err
+	}
+	if state != nullable.StatePresent {
+		if !c.optional {
+			return parquet.Value{}, errors.New("null value in required column")
+		}
+		return parquet.NullValue(), nil
+	}
+	if c.elem == timeType {
+		tm, ok := dv.(time.Time)
+		if !ok {
+			return parquet.Value{}, fmt.Errorf("cannot encode %T as a timestamp", dv)
+		}
+		return parquet.Int64Value(tm.UnixMicro()), nil
+	}
+	switch x := dv.(type) {
+	case string:
+		return parquet.ByteArrayValue([]byte(x)), nil
+	case []byte:
+		return parquet.ByteArrayValue(x), nil
+	case bool:
+		return parquet.BooleanValue(x), nil
+	case int64:
+		if c.elem.Bits() <= 32 && c.elem.Kind() != reflect.Int && c.elem.Kind() != reflect.Uint {
+			return parquet.Int32Value(int32(x)), nil
+		}
+		return parquet.Int64Value(x), nil
+	case float64:
+		if c.elem.Kind() == reflect.Float32 {
+			return parquet.FloatValue(float32(x)), nil
+		}
+		return parquet.DoubleValue(x), nil
+	}
+	return parquet.Value{}, fmt.Errorf("cannot encode %T", dv)
+}
+
+// Read decodes every row of the Parquet file r, of the given size, into a []T.
+// Columns are matched to fields by name; fields whose column is missing from the
+// file keep their zero value, which is undefined for nullable.Optional.
+func Read[T any](r io.ReaderAt, size int64) ([]T, error) {
+	t := reflect.TypeFor[T]()
+	cols, err := columns(t)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parquet.OpenFile(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("parquetnull: %w", err)
+	}
+	type source struct {
+		column
+		leaf parquet.LeafColumn
+	}
+	var sources []source
+	for _, c := range cols {
+		if lc, ok := f.Schema().Lookup(c.name); ok {
+			sources = append(sources, source{c, lc})
+		}
+	}
+
+	pr := parquet.NewReader(f)
+	defer pr.Close()
+	var out []T
+	rows := make([]parquet.Row, 64)
+	for {
+		n, err := pr.ReadRows(rows)
+		for _, row := range rows[:n] {
+			var value T
+			v := reflect.ValueOf(&value).Elem()
+			for _, s := range sources {
+				if err := decode(v.FieldByIndex(s.index), row[s.leaf.ColumnIndex], s.leaf); err != nil {
+					return nil, fmt.Errorf("parquetnull: row %d, column %s: %w", len(out), s.name, err)
+				}
+			}
+			out = append(out, value)
+		}
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parquetnull: %w", err)
+		}
+	}
+}
+
+// decode stores the Parquet value val of column lc into fv.
+func decode(fv reflect.Value, val parquet.Value, lc parquet.LeafColumn) error {
+	if val.IsNull() {
+		return nullreflect.Write(fv, nil, nullable.StateNull)
+	}
+	var x any
+	switch val.Kind() {
+	case parquet.Boolean:
+		x = val.Boolean()
+	case parquet.Int32:
+		x = int64(val.Int32())
+	case parquet.Int64:
+		x = val.Int64()
+		if lt := lc.Node.Type().LogicalType(); lt != nil && lt.Timestamp != nil {
+			switch unit := lt.Timestamp.Unit; {
+			case unit.Millis != nil:
+				x = time.UnixMilli(val.Int64()).UTC()
+			case unit.Micros != nil:
+				x = time.UnixMicro(val.Int64()).UTC()
+			default:
+				x = time.Unix(0, val.Int64()).UTC()
+			}
+		}
+	case parquet.Float:
+		x = float64(val.Float())
+	case parquet.Double:
+		x = val.Double()
+	case parquet.ByteArray, parquet.FixedLenByteArray:
+		x = bytes.Clone(val.ByteArray())
+	default:
+		return fmt.Errorf("unsupported Parquet type %s", val.Kind())
+	}
+	return nullreflect.Write(fv, x, nullable.StatePresent)
+}
@@ -0,0 +1,144 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+type options struct {
+	removeState nullstate.State
+}
+
+// Option configures Apply.
+type Option func(*options)
+
+// RemoveAsUndefined makes remove operations reset fields to undefined instead of null,
+// for DTOs where removing a member means "no change" rather than "clear".
+func RemoveAsUndefined() Option {
+	return func(o *options) { o.removeState = nullstate.Undefined }
+}
+
+type operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// Apply applies the RFC 6902 JSON Patch document ops to target, a non-nil pointer to a struct.
+// Paths are JSON pointers resolved over the fields' JSON names, descending into nested
+// structs and allocating nil pointers on the way. The add, replace and remove operations
+// are supported: add and replace decode the value into the field, null making it null,
+// and remove makes the field null, or undefined with RemoveAsUndefined. Replace and remove
+// fail on undefined fields, which are absent from the document.
+// Operations are applied in order; if one fails, target is left unchanged.
+func Apply(target any, ops []byte, opts ...Option) error {
+	o := options{removeState: nullstate.Null}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("patch: target must be a non-nil pointer to a struct, got %T", target)
+	}
+	var doc []operation
+	if err := json.Unmarshal(ops, &doc); err != nil {
+		return fmt.Errorf("patch: invalid JSON Patch document: %w", err)
+	}
+
+	// Work on a copy so a failing operation leaves target untouched; resolve
+	// copies nested pointers before modifying what they point to.
+	work := reflect.New(v.Elem().Type()).Elem()
+	work.Set(v.Elem())
+	for i, op := range doc {
+		if err := apply(work, op, o); err != nil {
+			return fmt.Errorf("patch: operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	v.Elem().Set(work)
+	return nil
+}
+
+func apply(root reflect.Value, op operation, o options) error {
+	switch op.Op {
+	case "add", "replace", "remove":
+	case "move", "copy", "test":
+		return fmt.Errorf("unsupported op %q", op.Op)
+	default:
+		return fmt.Errorf("unknown op %q", op.Op)
+	}
+	fv, err := resolve(root, op.Path)
+	if err != nil {
+		return err
+	}
+	if op.Op != "add" && nullreflect.IsUndefined(fv) {
+		return errors.New("path does not exist")
+	}
+	if op.Op == "remove" {
+		return nullreflect.Write(fv, nil, o.removeState)
+	}
+	if op.Value == nil {
+		return errors.New("missing value")
+	}
+	if bytes.Equal(bytes.TrimSpace(op.Value), nullLiteral) {
+		return nullreflect.Write(fv, nil, nullstate.Null)
+	}
+	fv.SetZero()
+	return json.Unmarshal(op.Value, fv.Addr().Interface())
+}
+
+// resolve returns the field addressed by the JSON pointer path, which must name a field.
+func resolve(v reflect.Value, path string) (reflect.Value, error) {
+	if path == "" || path[0] != '/' {
+		return reflect.Value{}, fmt.Errorf("invalid path %q", path)
+	}
+	for _, token := range strings.Split(path[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+		if v.Kind() == reflect.Pointer {
+			// Copy on write, so the caller's value shares nothing that gets modified.
+			p := reflect.New(v.Type().Elem())
+			if !v.IsNil() {
+				p.Elem().Set(v.Elem())
+			}
+			v.Set(p)
+			v = p.Elem()
+		}
+		if v.Kind() != reflect.Struct || !isPlainStruct(v.Type()) {
+			return reflect.Value{}, fmt.Errorf("cannot descend into %s", v.Type())
+		}
+		f, ok := fieldByName(v.Type(), token)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("unknown field %q", token)
+		}
+		v = v.FieldByIndex(f.Index)
+	}
+	return v, nil
+}
+
+// fieldByName finds the field of struct type t with the JSON name name, preferring
+// an exact match and falling back to case-insensitive matching like encoding/json.
+func fieldByName(t reflect.Type, name string) (nullreflect.Field, bool) {
+	var fold *nullreflect.Field
+	for _, f := range nullreflect.Fields(t) {
+		n, ok := f.JSONName()
+		if !ok {
+			continue
+		}
+		if n == name {
+			return f, true
+		}
+		if fold == nil && strings.EqualFold(n, name) {
+			fold = &f
+		}
+	}
+	if fold == nil {
+		return nullreflect.Field{}, false
+	}
+	return *fold, true
+}
@@ -0,0 +1,91 @@
+package patch
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name   string
+		target testProfile
+		ops    string
+		opts   []Option
+		want   testProfile
+	}{
+		{
+			name: "add",
+			ops:  `[{"op":"add","path":"/name","value":"Tan"},{"op":"add","path":"/mailing/street","value":"Raffles Pl"}]`,
+			want: testProfile{Name: some("Tan"), Mailing: &testAddress{Street: some("Raffles Pl")}},
+		},
+		{
+			name:   "replace",
+			target: testProfile{Name: some("Tan"), Tags: []string{"a", "b"}},
+			ops:    `[{"op":"replace","path":"/name","value":null},{"op":"replace","path":"/tags","value":["c"]}]`,
+			want:   testProfile{Name: cleared, Tags: []string{"c"}},
+		},
+		{
+			name:   "remove",
+			target: testProfile{Name: some("Tan"), Address: testAddress{Street: some("Orchard Rd")}},
+			ops:    `[{"op":"remove","path":"/name"},{"op":"remove","path":"/address/street"}]`,
+			want:   testProfile{Name: cleared, Address: testAddress{Street: cleared}},
+		},
+		{
+			name:   "remove as undefined",
+			target: testProfile{Name: some("Tan")},
+			ops:    `[{"op":"remove","path":"/name"}]`,
+			opts:   []Option{RemoveAsUndefined()},
+			want:   testProfile{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.target
+			if err := Apply(&got, []byte(tt.ops), tt.opts...); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Apply(%s) = %#v, want %#v", tt.ops, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyUnescapesTokens(t *testing.T) {
+	var p testProfile
+	err := Apply(&p, []byte(`[{"op":"add","path":"/a~1b~0c","value":"x"}]`))
+	if err == nil || !strings.Contains(err.Error(), `unknown field "a/b~c"`) {
+		t.Errorf("Apply = %v, want an error naming the unescaped token", err)
+	}
+}
+
+func TestApplyErrorsLeaveTargetUnchanged(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  string
+	}{
+		{name: "replace undefined", ops: `[{"op":"replace","path":"/address/street","value":"x"}]`},
+		{name: "remove undefined", ops: `[{"op":"remove","path":"/address/street"}]`},
+		{name: "unsupported op", ops: `[{"op":"move","from":"/name","path":"/address/street"}]`},
+		{name: "unknown op", ops: `[{"op":"merge","path":"/name"}]`},
+		{name: "missing value", ops: `[{"op":"add","path":"/name"}]`},
+		{name: "invalid path", ops: `[{"op":"add","path":"name","value":"x"}]`},
+		{name: "descend into a value", ops: `[{"op":"add","path":"/birthday/year","value":1}]`},
+		{name: "bad document", ops: `{"op":"add"}`},
+		{name: "later operation fails", ops: `[{"op":"add","path":"/mailing/street","value":"x"},{"op":"add","path":"/age","value":"old"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mailing := &testAddress{Street: some("Raffles Pl")}
+			p := testProfile{Name: some("Tan"), Mailing: mailing}
+			if err := Apply(&p, []byte(tt.ops)); err == nil {
+				t.Fatal("Apply: want an error")
+			}
+			want := testProfile{Name: some("Tan"), Mailing: &testAddress{Street: some("Raffles Pl")}}
+			if !reflect.DeepEqual(p, want) || p.Mailing != mailing || !reflect.DeepEqual(*mailing, *want.Mailing) {
+				t.Errorf("target after a failed Apply = %#v, want it unchanged", p)
+			}
+		})
+	}
+}
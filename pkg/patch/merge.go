@@ -0,0 +1,121 @@
+// Package patch applies JSON patch documents to nullable DTOs, mapping their
+// "clear" and "keep" semantics onto the null and undefined states.
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+var nullLiteral = []byte("null")
+
+// MergePatch applies the RFC 7386 JSON merge patch to target, a non-nil pointer to a struct.
+// Keys are matched to fields like encoding/json does. Absent keys keep the field as is,
+// null clears it, which makes a nullable.Optional explicitly null, and other values
+// replace it. Objects patching plain struct fields are merged recursively, any other
+// field is replaced by decoding the value with encoding/json, arrays replacing slices
+// with their elements alone. Unknown keys are ignored.
+func MergePatch(target any, patch []byte) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("patch: target must be a non-nil pointer to a struct, got %T", target)
+	}
+	return mergeObject(v.Elem(), patch, "")
+}
+
+func mergeObject(v reflect.Value, data []byte, path string) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil || obj == nil {
+		return errors.New("patch: merge patch" + pathSuffix(path) + " must be a JSON object")
+	}
+	for _, f := range nullreflect.Fields(v.Type()) {
+		name, ok := f.JSONName()
+		if !ok {
+			continue
+		}
+		raw, ok := lookup(obj, name)
+		if !ok {
+			continue
+		}
+		if err := mergeValue(v.FieldByIndex(f.Index), raw, path+"/"+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mergeValue(fv reflect.Value, raw json.RawMessage, path string) error {
+	if bytes.Equal(bytes.TrimSpace(raw), nullLiteral) {
+		if err := nullreflect.Write(fv, nil, nullstate.Null); err != nil {
+			return fmt.Errorf("patch: %s: %w", path, err)
+		}
+		return nil
+	}
+	if isObject(raw) && isPlainStruct(fv.Type()) {
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			fv = fv.Elem()
+		}
+		return mergeObject(fv, raw, path)
+	}
+	// Other values replace the field, so decoding an array into a slice does not keep
+	// members of the elements it overwrites, nor an object into a map its other keys.
+	fv.SetZero()
+	if err := json.Unmarshal(raw, fv.Addr().Interface()); err != nil {
+		return fmt.Errorf("patch: %s: %w", path, err)
+	}
+	return nil
+}
+
+var (
+	unmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	scannerType     = reflect.TypeFor[interface{ Scan(any) error }]()
+)
+
+// isPlainStruct reports whether t, or the type t points to, is a struct decoded
+// field by field rather than a value type with its own JSON or SQL handling.
+func isPlainStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	pt := reflect.PointerTo(t)
+	return !pt.Implements(unmarshalerType) && !pt.Implements(scannerType)
+}
+
+func isObject(raw json.RawMessage) bool {
+	raw = bytes.TrimSpace(raw)
+	return len(raw) > 0 && raw[0] == '{'
+}
+
+// lookup finds name in obj the way encoding/json does, preferring an exact match
+// and falling back to case-insensitive matching.
+func lookup(obj map[string]json.RawMessage, name string) (json.RawMessage, bool) {
+	if raw, ok := obj[name]; ok {
+		return raw, true
+	}
+	for k, raw := range obj {
+		if strings.EqualFold(k, name) {
+			return raw, true
+		}
+	}
+	return nil, false
+}
+
+func pathSuffix(path string) string {
+	if path == "" {
+		return ""
+	}
+	return " at " + path
+}
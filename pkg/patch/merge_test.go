@@ -0,0 +1,135 @@
+package patch
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type testAddress struct {
+	Street     nullable.Optional[string] `json:"street"`
+	PostalCode nullable.Optional[string] `json:"postal_code"`
+}
+
+type testProfile struct {
+	Name     nullable.Optional[string] `json:"name"`
+	Age      nullable.Null[int32]      `json:"age"`
+	Tags     []string                  `json:"tags"`
+	Attrs    map[string]string         `json:"attrs"`
+	Address  testAddress               `json:"address"`
+	Mailing  *testAddress              `json:"mailing"`
+	Birthday nullable.Date             `json:"birthday"`
+	Secret   string                    `json:"-"`
+}
+
+var (
+	some    = nullable.OptionalFrom[string]
+	cleared = nullable.OptionalNull[string]()
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		target testProfile
+		patch  string
+		want   testProfile
+	}{
+		{
+			name:   "absent keys keep",
+			target: testProfile{Name: some("Tan"), Age: nullable.From[int32](30)},
+			patch:  `{}`,
+			want:   testProfile{Name: some("Tan"), Age: nullable.From[int32](30)},
+		},
+		{
+			name:   "null clears",
+			target: testProfile{Name: some("Tan"), Age: nullable.From[int32](30), Tags: []string{"a"}},
+			patch:  `{"name":null,"age":null,"tags":null}`,
+			want:   testProfile{Name: cleared},
+		},
+		{
+			name:   "values replace",
+			target: testProfile{Name: some("Tan")},
+			patch:  `{"name":"Lim","age":31,"birthday":"2000-01-31"}`,
+			want:   testProfile{Name: some("Lim"), Age: nullable.From[int32](31), Birthday: nullable.NewDate(2000, 1, 31)},
+		},
+		{
+			name:  "keys match case-insensitively",
+			patch: `{"NAME":"Lim"}`,
+			want:  testProfile{Name: some("Lim")},
+		},
+		{
+			name:   "arrays replace slices",
+			target: testProfile{Tags: []string{"a", "b", "c"}},
+			patch:  `{"tags":["z"]}`,
+			want:   testProfile{Tags: []string{"z"}},
+		},
+		{
+			name:   "objects replace maps",
+			target: testProfile{Attrs: map[string]string{"color": "red", "size": "M"}},
+			patch:  `{"attrs":{"size":"L"}}`,
+			want:   testProfile{Attrs: map[string]string{"size": "L"}},
+		},
+		{
+			name:   "objects merge recursively",
+			target: testProfile{Address: testAddress{Street: some("Orchard Rd"), PostalCode: some("238801")}},
+			patch:  `{"address":{"postal_code":null}}`,
+			want:   testProfile{Address: testAddress{Street: some("Orchard Rd"), PostalCode: cleared}},
+		},
+		{
+			name:  "nil pointers are allocated",
+			patch: `{"mailing":{"street":"Raffles Pl"}}`,
+			want:  testProfile{Mailing: &testAddress{Street: some("Raffles Pl")}},
+		},
+		{
+			name:   "null clears nested pointers",
+			target: testProfile{Mailing: &testAddress{Street: some("Raffles Pl")}},
+			patch:  `{"mailing":null}`,
+			want:   testProfile{},
+		},
+		{
+			name:   "unknown and skipped keys are ignored",
+			target: testProfile{Secret: "s"},
+			patch:  `{"nope":1,"Secret":"x","-":"y"}`,
+			want:   testProfile{Secret: "s"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.target
+			if err := MergePatch(&got, []byte(tt.patch)); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MergePatch(%s) = %#v, want %#v", tt.patch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergePatchErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		patch   string
+		wantErr string
+	}{
+		{name: "not an object", patch: `[1]`, wantErr: "must be a JSON object"},
+		{name: "null document", patch: `null`, wantErr: "must be a JSON object"},
+		{name: "wrong type", patch: `{"age":"old"}`, wantErr: "/age"},
+		{name: "nested wrong type", patch: `{"address":{"street":5}}`, wantErr: "/address/street"},
+		{name: "nested not an object", patch: `{"address":1}`, wantErr: "/address"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p testProfile
+			err := MergePatch(&p, []byte(tt.patch))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("MergePatch(%s) = %v, want an error mentioning %q", tt.patch, err, tt.wantErr)
+			}
+		})
+	}
+	if err := MergePatch(testProfile{}, []byte(`{}`)); err == nil {
+		t.Error("MergePatch into a struct value: want an error")
+	}
+}
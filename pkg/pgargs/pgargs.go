@@ -0,0 +1,42 @@
+// Package pgargs turns nullable DTOs into pgx named arguments.
+package pgargs
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// FromStruct converts the fields of dto, a struct or pointer to one, into pgx.NamedArgs
+// for queries written with @name placeholders. Undefined fields are omitted and null
+// fields map to nil; every other field is passed as is, so pgx encodes it through the
+// type's own pgx or driver.Valuer support. Arguments are named after the `db` tag or
+// the snake_cased field name; `db:"-"` skips a field.
+func FromStruct(dto any) pgx.NamedArgs {
+	v := reflect.Indirect(reflect.ValueOf(dto))
+	if v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("pgargs: dto must be a struct, got %T", dto))
+	}
+
+	args := make(pgx.NamedArgs)
+	for _, f := range nullreflect.Fields(v.Type()) {
+		name, ok := f.Column()
+		if !ok {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		if nullreflect.IsUndefined(fv) {
+			continue
+		}
+		if _, state, err := nullreflect.Read(fv); err == nil && state == nullstate.Null {
+			args[name] = nil
+			continue
+		}
+		args[name] = fv.Interface()
+	}
+	return args
+}
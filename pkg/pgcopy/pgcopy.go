@@ -0,0 +1,99 @@
+// Package pgcopy bulk inserts nullable DTOs with PostgreSQL's COPY protocol.
+package pgcopy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// Conn is implemented by *pgx.Conn, *pgxpool.Pool and pgx.Tx.
+type Conn interface {
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// FromDTOs copies rows into table, which may be schema qualified, and returns the
+// number of rows copied. See Source for how columns and values are chosen.
+func FromDTOs[T any](ctx context.Context, conn Conn, table string, rows []T) (int64, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	columns, src, err := Source(rows)
+	if err != nil {
+		return 0, err
+	}
+	return conn.CopyFrom(ctx, pgx.Identifier(strings.Split(table, ".")), columns, src)
+}
+
+// Source builds the column list and pgx.CopyFromSource for rows, structs or pointers to them.
+// Columns are named after the `db` tag or the snake_cased field name; `db:"-"` skips a field.
+// Null fields are copied as NULL. Fields that are undefined in every row are left out,
+// so PostgreSQL fills them with their column default; as COPY cannot apply a default to
+// individual rows, a field that is undefined in some rows but not others is an error.
+func Source[T any](rows []T) ([]string, pgx.CopyFromSource, error) {
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("pgcopy: rows must be structs, got %s", t)
+	}
+
+	type column struct {
+		name      string
+		index     []int
+		undefined int
+	}
+	var all []column
+	for _, f := range nullreflect.Fields(t) {
+		if name, ok := f.Column(); ok {
+			all = append(all, column{name: name, index: f.Index})
+		}
+	}
+
+	values := make([]reflect.Value, len(rows))
+	for i, row := range rows {
+		v := reflect.ValueOf(row)
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return nil, nil, fmt.Errorf("pgcopy: row %d is nil", i)
+			}
+			v = v.Elem()
+		}
+		values[i] = v
+		for c := range all {
+			if nullreflect.IsUndefined(v.FieldByIndex(all[c].index)) {
+				all[c].undefined++
+			}
+		}
+	}
+
+	var names []string
+	var used []column
+	for _, c := range all {
+		switch c.undefined {
+		case len(rows):
+			continue
+		case 0:
+		default:
+			return nil, nil, fmt.Errorf("pgcopy: column %s is undefined in %d of %d rows", c.name, c.undefined, len(rows))
+		}
+		names = append(names, c.name)
+		used = append(used, c)
+	}
+
+	copyRows := make([][]any, len(values))
+	for i, v := range values {
+		row := make([]any, len(used))
+		for c := range used {
+			row[c] = v.FieldByIndex(used[c].index).Interface()
+		}
+		copyRows[i] = row
+	}
+	return names, pgx.CopyFromRows(copyRows), nil
+}
@@ -0,0 +1,163 @@
+package pgcopy
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type applicant struct {
+	ID       int64                     `db:"-"`
+	Name     nullable.Optional[string] `db:"full_name"`
+	Married  nullable.Optional[string]
+	Age      nullable.Null[int32]
+	Status   nullable.Optional[string]
+	Internal string `db:"-"`
+}
+
+// collect drains src into its rows.
+func collect(t *testing.T, src pgx.CopyFromSource) [][]any {
+	t.Helper()
+	var rows [][]any
+	for src.Next() {
+		vals, err := src.Values()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows = append(rows, vals)
+	}
+	if err := src.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		name        string
+		rows        []*applicant
+		wantColumns []string
+		wantRows    [][]any
+	}{
+		{
+			name: "undefined in every row is left to the default",
+			rows: []*applicant{
+				{Name: nullable.OptionalFrom("Tan"), Married: nullable.OptionalNull[string](), Age: nullable.From[int32](30)},
+				{Name: nullable.OptionalNull[string](), Married: nullable.OptionalFrom("Lee")},
+			},
+			wantColumns: []string{"full_name", "married", "age"},
+			wantRows: [][]any{
+				{nullable.OptionalFrom("Tan"), nullable.OptionalNull[string](), nullable.From[int32](30)},
+				{nullable.OptionalNull[string](), nullable.OptionalFrom("Lee"), nullable.Null[int32]{}},
+			},
+		},
+		{
+			name:        "fields that cannot be undefined are always copied",
+			rows:        []*applicant{{}},
+			wantColumns: []string{"age"},
+			wantRows:    [][]any{{nullable.Null[int32]{}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, src, err := Source(tt.rows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(columns, tt.wantColumns) {
+				t.Errorf("columns = %q, want %q", columns, tt.wantColumns)
+			}
+			if got := collect(t, src); !reflect.DeepEqual(got, tt.wantRows) {
+				t.Errorf("rows = %#v, want %#v", got, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestSourceErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  func() error
+		wantErr string
+	}{
+		{
+			name: "undefined in some rows",
+			source: func() error {
+				_, _, err := Source([]applicant{{Status: nullable.OptionalFrom("new")}, {}, {}})
+				return err
+			},
+			wantErr: "column status is undefined in 2 of 3 rows",
+		},
+		{
+			name:    "nil row",
+			source:  func() error { _, _, err := Source([]*applicant{{}, nil}); return err },
+			wantErr: "row 1 is nil",
+		},
+		{
+			name:    "not structs",
+			source:  func() error { _, _, err := Source([]string{"a"}); return err },
+			wantErr: "rows must be structs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.source()
+			if err == nil || !strings.HasPrefix(err.Error(), "pgcopy: ") || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Source = %v, want an error mentioning %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type fakeConn struct {
+	table   pgx.Identifier
+	columns []string
+	rows    [][]any
+	err     error
+}
+
+func (c *fakeConn) CopyFrom(_ context.Context, table pgx.Identifier, columns []string, src pgx.CopyFromSource) (int64, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	c.table, c.columns = table, columns
+	for src.Next() {
+		vals, err := src.Values()
+		if err != nil {
+			return 0, err
+		}
+		c.rows = append(c.rows, vals)
+	}
+	return int64(len(c.rows)), src.Err()
+}
+
+func TestFromDTOs(t *testing.T) {
+	ctx := context.Background()
+	conn := &fakeConn{}
+	rows := []applicant{{Name: nullable.OptionalFrom("Tan")}, {Name: nullable.OptionalFrom("Lim")}}
+	n, err := FromDTOs(ctx, conn, "public.applicants", rows)
+	if err != nil || n != 2 {
+		t.Fatalf("FromDTOs = %d, %v, want 2", n, err)
+	}
+	if want := (pgx.Identifier{"public", "applicants"}); !reflect.DeepEqual(conn.table, want) {
+		t.Errorf("table = %q, want %q", conn.table, want)
+	}
+	if want := []string{"full_name", "age"}; !reflect.DeepEqual(conn.columns, want) {
+		t.Errorf("columns = %q, want %q", conn.columns, want)
+	}
+
+	empty := &fakeConn{err: errors.New("not called")}
+	if n, err := FromDTOs[applicant](ctx, empty, "applicants", nil); n != 0 || err != nil {
+		t.Errorf("FromDTOs of no rows = %d, %v, want 0, nil", n, err)
+	}
+	failing := &fakeConn{err: errors.New("connection reset")}
+	if _, err := FromDTOs(ctx, failing, "applicants", rows); err == nil {
+		t.Error("FromDTOs on a failing connection: want an error")
+	}
+}
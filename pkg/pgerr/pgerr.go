@@ -0,0 +1,116 @@
+// Package pgerr translates PostgreSQL constraint violations into errors on DTO fields,
+// so API responses can point at the offending field instead of returning a raw SQLSTATE:
+//
+//	if _, err := pool.Exec(ctx, sql, args...); err != nil {
+//		if fe := pgerr.ToFieldErrors(err, form); fe != nil {
+//			return fe
+//		}
+//		return err
+//	}
+package pgerr
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// Messages reported for each kind of violation.
+const (
+	Required   = "required"
+	Unique     = "unique"
+	Check      = "check"
+	ForeignKey = "foreign_key"
+)
+
+// FieldErrors maps field names to their error messages.
+type FieldErrors = forms.FieldErrors
+
+// ToFieldErrors maps the constraint violation wrapped by err onto the fields of dto,
+// a struct or pointer to one, whose columns it involves. Columns are matched against
+// the fields' `db` tags or snake_case names, and errors are keyed by JSON name.
+//
+//   - not_null_violation reports Required on the column PostgreSQL names
+//   - unique_violation and foreign_key_violation report Unique and ForeignKey on the
+//     columns listed in the error detail, e.g. Key (email)=(…) already exists
+//   - check_violation reports Check on the column in the constraint name, which
+//     PostgreSQL names <table>_<column>_check unless told otherwise
+//
+// It returns nil if err is not one of these violations or involves no field of dto.
+func ToFieldErrors(err error, dto any) FieldErrors {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+	t := reflect.TypeOf(dto)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var columns []string
+	var msg string
+	switch pgErr.Code {
+	case "23502": // not_null_violation
+		columns, msg = []string{pgErr.ColumnName}, Required
+	case "23505": // unique_violation
+		columns, msg = detailColumns(pgErr.Detail), Unique
+	case "23503": // foreign_key_violation
+		columns, msg = detailColumns(pgErr.Detail), ForeignKey
+	case "23514": // check_violation
+		columns, msg = []string{checkColumn(pgErr)}, Check
+	default:
+		return nil
+	}
+
+	fe := make(FieldErrors)
+	for _, f := range nullreflect.Fields(t) {
+		col, ok := f.Column()
+		if !ok || !slices.Contains(columns, col) {
+			continue
+		}
+		name, ok := f.JSONName()
+		if !ok {
+			name = f.Name
+		}
+		fe[name] = append(fe[name], msg)
+	}
+	if len(fe) == 0 {
+		return nil
+	}
+	return fe
+}
+
+// detailColumns extracts the column list of a detail such as `Key (a, b)=(1, 2) already exists.`
+func detailColumns(detail string) []string {
+	_, rest, ok := strings.Cut(detail, "Key (")
+	if !ok {
+		return nil
+	}
+	list, _, ok := strings.Cut(rest, ")=(")
+	if !ok {
+		return nil
+	}
+	cols := strings.Split(list, ",")
+	for i, c := range cols {
+		cols[i] = strings.Trim(strings.TrimSpace(c), `"`)
+	}
+	return cols
+}
+
+// checkColumn returns the column a check violation is about, either reported by
+// PostgreSQL or taken from a constraint named <table>_<column>_check.
+func checkColumn(pgErr *pgconn.PgError) string {
+	if pgErr.ColumnName != "" {
+		return pgErr.ColumnName
+	}
+	return strings.TrimPrefix(strings.TrimSuffix(pgErr.ConstraintName, "_check"), pgErr.TableName+"_")
+}
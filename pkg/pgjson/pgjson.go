@@ -0,0 +1,245 @@
+// Package pgjson wraps pgtype values for models that keep pgtype fields, such as
+// sqlc-generated ones, so they marshal to JSON exactly like the nullable package does:
+// decimals as strings honouring nullable.DecimalJSONNumber, times following
+// nullable.TimeOutputLayout and TimeLayouts, and null for invalid values.
+// Each wrapper embeds its pgtype value, so it has the same size and still works
+// with database/sql and pgx, and implements IsZero so omitzero drops nulls.
+//
+// Struct converts whole structs between pgtype and pgjson fields:
+//
+//	type UinfinNamesJSON struct {
+//		Uinfin nullable.Uinfin
+//		Name   pgjson.Text
+//		// ...
+//	}
+//
+//	var out UinfinNamesJSON
+//	err := pgjson.Struct(row, &out) // row is a dtos.PgUinfinNamesForm
+package pgjson
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Text is a pgtype.Text marshaling to JSON like nullable.Null[string].
+type Text struct{ pgtype.Text }
+
+// MarshalJSON implements json.Marshaler.
+func (t Text) MarshalJSON() ([]byte, error) {
+	return nullable.New(t.String, t.Valid).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Text) UnmarshalJSON(data []byte) error {
+	var n nullable.Null[string]
+	err := n.UnmarshalJSON(data)
+	t.Text = pgtype.Text{String: n.V, Valid: n.Valid}
+	return err
+}
+
+// IsZero returns true for null values.
+func (t Text) IsZero() bool {
+	return !t.Valid
+}
+
+// Int2 is a pgtype.Int2 marshaling to JSON like nullable.Int16.
+type Int2 struct{ pgtype.Int2 }
+
+// MarshalJSON implements json.Marshaler.
+func (i Int2) MarshalJSON() ([]byte, error) {
+	return nullable.Int16FromPg(i.Int2).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Int2) UnmarshalJSON(data []byte) error {
+	var n nullable.Int16
+	err := n.UnmarshalJSON(data)
+	i.Int2 = n.Pg()
+	return err
+}
+
+// IsZero returns true for null values.
+func (i Int2) IsZero() bool {
+	return !i.Valid
+}
+
+// Int4 is a pgtype.Int4 marshaling to JSON like nullable.Int32.
+type Int4 struct{ pgtype.Int4 }
+
+// MarshalJSON implements json.Marshaler.
+func (i Int4) MarshalJSON() ([]byte, error) {
+	return nullable.Int32FromPg(i.Int4).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Int4) UnmarshalJSON(data []byte) error {
+	var n nullable.Int32
+	err := n.UnmarshalJSON(data)
+	i.Int4 = n.Pg()
+	return err
+}
+
+// IsZero returns true for null values.
+func (i Int4) IsZero() bool {
+	return !i.Valid
+}
+
+// Int8 is a pgtype.Int8 marshaling to JSON like nullable.Int64.
+type Int8 struct{ pgtype.Int8 }
+
+// MarshalJSON implements json.Marshaler.
+func (i Int8) MarshalJSON() ([]byte, error) {
+	return nullable.Int64FromPg(i.Int8).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Int8) UnmarshalJSON(data []byte) error {
+	var n nullable.Int64
+	err := n.UnmarshalJSON(data)
+	i.Int8 = n.Pg()
+	return err
+}
+
+// IsZero returns true for null values.
+func (i Int8) IsZero() bool {
+	return !i.Valid
+}
+
+// Float8 is a pgtype.Float8 marshaling to JSON like nullable.Null[float64].
+type Float8 struct{ pgtype.Float8 }
+
+// MarshalJSON implements json.Marshaler.
+func (f Float8) MarshalJSON() ([]byte, error) {
+	return nullable.New(f.Float64, f.Valid).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *Float8) UnmarshalJSON(data []byte) error {
+	var n nullable.Null[float64]
+	err := n.UnmarshalJSON(data)
+	f.Float8 = pgtype.Float8{Float64: n.V, Valid: n.Valid}
+	return err
+}
+
+// IsZero returns true for null values.
+func (f Float8) IsZero() bool {
+	return !f.Valid
+}
+
+// Bool is a pgtype.Bool marshaling to JSON like nullable.Null[bool].
+type Bool struct{ pgtype.Bool }
+
+// MarshalJSON implements json.Marshaler.
+func (b Bool) MarshalJSON() ([]byte, error) {
+	return nullable.New(b.Bool.Bool, b.Valid).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	var n nullable.Null[bool]
+	err := n.UnmarshalJSON(data)
+	b.Bool = pgtype.Bool{Bool: n.V, Valid: n.Valid}
+	return err
+}
+
+// IsZero returns true for null values.
+func (b Bool) IsZero() bool {
+	return !b.Valid
+}
+
+// Numeric is a pgtype.Numeric marshaling to JSON like nullable.Decimal.
+// NaN and infinite numerics cannot be marshaled.
+type Numeric struct{ pgtype.Numeric }
+
+// MarshalJSON implements json.Marshaler.
+func (n Numeric) MarshalJSON() ([]byte, error) {
+	d, err := nullable.DecimalFromPg(n.Numeric)
+	if err != nil {
+		return nil, err
+	}
+	return d.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *Numeric) UnmarshalJSON(data []byte) error {
+	var d nullable.Decimal
+	err := d.UnmarshalJSON(data)
+	n.Numeric = d.Pg()
+	return err
+}
+
+// IsZero returns true for null values.
+func (n Numeric) IsZero() bool {
+	return !n.Valid
+}
+
+// Date is a pgtype.Date marshaling to JSON like nullable.Date.
+type Date struct{ pgtype.Date }
+
+// MarshalJSON implements json.Marshaler.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return nullable.DateFromPg(d.Date).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var n nullable.Date
+	err := n.UnmarshalJSON(data)
+	d.Date = n.Pg()
+	return err
+}
+
+// IsZero returns true for null values.
+func (d Date) IsZero() bool {
+	return !d.Valid
+}
+
+// Timestamptz is a pgtype.Timestamptz marshaling to JSON like nullable.Time.
+// Infinite timestamps cannot be marshaled.
+type Timestamptz struct{ pgtype.Timestamptz }
+
+// MarshalJSON implements json.Marshaler.
+func (t Timestamptz) MarshalJSON() ([]byte, error) {
+	if t.Valid && t.InfinityModifier != pgtype.Finite {
+		return nil, errors.New("pgjson: couldn't marshal infinite timestamp")
+	}
+	return nullable.NewTime(t.Time, t.Valid).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Timestamptz) UnmarshalJSON(data []byte) error {
+	var n nullable.Time
+	err := n.UnmarshalJSON(data)
+	t.Timestamptz = pgtype.Timestamptz{Time: n.V, Valid: n.Valid}
+	return err
+}
+
+// IsZero returns true for null values.
+func (t Timestamptz) IsZero() bool {
+	return !t.Valid
+}
+
+// UUID is a pgtype.UUID marshaling to JSON like nullable.UUID.
+type UUID struct{ pgtype.UUID }
+
+// MarshalJSON implements json.Marshaler.
+func (u UUID) MarshalJSON() ([]byte, error) {
+	return nullable.UUIDFromPg(u.UUID).MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (u *UUID) UnmarshalJSON(data []byte) error {
+	var n nullable.UUID
+	err := n.UnmarshalJSON(data)
+	u.UUID = n.Pg()
+	return err
+}
+
+// IsZero returns true for null values.
+func (u UUID) IsZero() bool {
+	return !u.Valid
+}
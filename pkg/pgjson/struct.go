@@ -0,0 +1,72 @@
+package pgjson
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// Struct copies fields of src into dst by field name, wrapping pgtype values into
+// their pgjson wrappers and unwrapping them again, so a sqlc model such as
+// dtos.PgUinfinNamesForm converts to a JSON-friendly twin and back without
+// per-field code. Other fields are copied when their types match and converted
+// through driver.Valuer and sql.Scanner otherwise, like convert.Struct does.
+//
+// src must be a struct or a pointer to one, dst must be a non-nil pointer to a struct.
+// Fields that do not exist in both structs are left untouched. Fields that cannot be
+// converted are reported together as forms.FieldErrors keyed by field name.
+func Struct(src, dst any) error {
+	sv := reflect.ValueOf(src)
+	if sv.Kind() == reflect.Pointer {
+		if sv.IsNil() {
+			return errors.New("pgjson: src is a nil pointer")
+		}
+		sv = sv.Elem()
+	}
+	if sv.Kind() != reflect.Struct {
+		return fmt.Errorf("pgjson: src must be a struct, got %T", src)
+	}
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("pgjson: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	dv = dv.Elem()
+
+	var fe forms.FieldErrors
+	for _, p := range nullreflect.Pairs(sv.Type(), dv.Type()) {
+		if err := copyField(sv.FieldByIndex(p.Src), dv.FieldByIndex(p.Dst)); err != nil {
+			fe.Add(p.Name, err.Error())
+		}
+	}
+	return fe.Err()
+}
+
+func copyField(from, to reflect.Value) error {
+	switch {
+	case from.Type() == to.Type():
+		to.Set(from)
+		return nil
+	case wraps(to.Type(), from.Type()):
+		to.Field(0).Set(from)
+		return nil
+	case wraps(from.Type(), to.Type()):
+		to.Set(from.Field(0))
+		return nil
+	}
+	val, state, err := nullreflect.Read(from)
+	if err != nil {
+		return err
+	}
+	return nullreflect.Write(to, val, state)
+}
+
+// wraps reports whether w is a pgjson wrapper embedding inner.
+func wraps(w, inner reflect.Type) bool {
+	return w.Kind() == reflect.Struct && w.PkgPath() == wrapperPkg &&
+		w.NumField() == 1 && w.Field(0).Anonymous && w.Field(0).Type == inner
+}
+
+var wrapperPkg = reflect.TypeFor[Text]().PkgPath()
@@ -0,0 +1,69 @@
+package protonull
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+)
+
+type contextKey struct{ t reflect.Type }
+
+// FromContext returns the DTO decoded by UnaryServerInterceptor.
+func FromContext[T any](ctx context.Context) (*T, bool) {
+	dto, ok := ctx.Value(contextKey{reflect.TypeFor[T]()}).(*T)
+	return dto, ok
+}
+
+// UnaryServerInterceptor decodes the requests of the methods in dtos with FromMessage.
+// dtos maps full method names, such as "/pkg.Service/Method", to a value of the DTO type
+// to decode into; the decoded DTO is stored in the context for FromContext. Requests that
+// fail to decode are answered with codes.InvalidArgument and an errdetails.BadRequest
+// listing the field violations, without calling the handler. Other methods pass through.
+func UnaryServerInterceptor(dtos map[string]any) grpc.UnaryServerInterceptor {
+	types := make(map[string]reflect.Type, len(dtos))
+	for method, dto := range dtos {
+		types[method] = reflect.Indirect(reflect.ValueOf(dto)).Type()
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		t, ok := types[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+		dto := reflect.New(t)
+		if err := FromMessage(msg, dto.Interface()); err != nil {
+			return nil, invalidArgument(err)
+		}
+		return handler(context.WithValue(ctx, contextKey{t}, dto.Interface()), req)
+	}
+}
+
+// invalidArgument converts a FromMessage error into a gRPC status error.
+func invalidArgument(err error) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+	var fe forms.FieldErrors
+	if !errors.As(err, &fe) {
+		return st.Err()
+	}
+	br := &errdetails.BadRequest{}
+	for _, field := range fe.Fields() {
+		for _, msg := range fe[field] {
+			br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: field, Description: msg})
+		}
+	}
+	if detailed, derr := st.WithDetails(br); derr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
@@ -0,0 +1,175 @@
+// Package protonull reads protobuf messages into nullable DTOs, so gRPC and REST
+// handlers can share the same domain structs. Field presence carries the tri-state:
+// an unset field with presence (proto3 optional, proto2 and message fields) is
+// undefined, or null for DTO fields that cannot be undefined, and a set field is a value.
+//
+//	s := grpc.NewServer(grpc.UnaryInterceptor(protonull.UnaryServerInterceptor(map[string]any{
+//		"/applicants.v1.Applicants/Update": dtos.UinfinNamesPatch{},
+//	})))
+//
+//	func (s *server) Update(ctx context.Context, req *pb.UpdateRequest) (*pb.Applicant, error) {
+//		patch, _ := protonull.FromContext[dtos.UinfinNamesPatch](ctx)
+//		// ...
+//	}
+package protonull
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// FromMessage copies the fields of msg into dst, a non-nil pointer to a struct.
+//
+// DTO fields are matched to proto fields by the `proto` tag, or by their snake_cased name
+// or JSON name; `proto:"-"` skips a field, and proto fields without a DTO field are ignored.
+// Scalars are written like database values are scanned, enums as their name unless the
+// DTO field is an integer, google.protobuf.Timestamp as a time.Time, the well-known
+// wrapper messages as their value and other messages into struct fields recursively.
+// Fields without presence, such as plain proto3 scalars, always hold a value. Repeated
+// and map fields are not supported and are left untouched.
+//
+// Fields that cannot be written are reported together as forms.FieldErrors keyed by
+// proto field name, with nested fields separated by dots.
+func FromMessage(msg proto.Message, dst any) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("protonull: dst must be a non-nil pointer to a struct, got %T", dst)
+	}
+	var fe forms.FieldErrors
+	fromMessage(msg.ProtoReflect(), v.Elem(), "", &fe)
+	return fe.Err()
+}
+
+func fromMessage(m protoreflect.Message, v reflect.Value, prefix string, fe *forms.FieldErrors) {
+	fds := m.Descriptor().Fields()
+	for _, f := range nullreflect.Fields(v.Type()) {
+		fd := lookup(fds, f)
+		if fd == nil || fd.IsList() || fd.IsMap() {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		name := prefix + string(fd.Name())
+		if fd.HasPresence() && !m.Has(fd) {
+			state := nullstate.Null
+			if nullreflect.CanBeUndefined(fv.Type()) {
+				state = nullstate.Undefined
+			}
+			if err := nullreflect.Write(fv, nil, state); err != nil {
+				fe.Add(name, err.Error())
+			}
+			continue
+		}
+		if err := writeValue(fv, fd, m.Get(fd), name, fe); err != nil {
+			fe.Add(name, err.Error())
+		}
+	}
+}
+
+// lookup returns the field of fds matching DTO field f.
+func lookup(fds protoreflect.FieldDescriptors, f nullreflect.Field) protoreflect.FieldDescriptor {
+	if tag := f.Tag.Get("proto"); tag != "" {
+		if tag == "-" {
+			return nil
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		return fds.ByName(protoreflect.Name(name))
+	}
+	if fd := fds.ByName(protoreflect.Name(nullreflect.SnakeCase(f.Name))); fd != nil {
+		return fd
+	}
+	if name, ok := f.JSONName(); ok {
+		if fd := fds.ByJSONName(name); fd != nil {
+			return fd
+		}
+		return fds.ByName(protoreflect.Name(name))
+	}
+	return nil
+}
+
+var timestampName = (&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName()
+
+// writeValue writes the set value pv of field fd into fv.
+func writeValue(fv reflect.Value, fd protoreflect.FieldDescriptor, pv protoreflect.Value, name string, fe *forms.FieldErrors) error {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		m := pv.Message()
+		switch full := m.Descriptor().FullName(); {
+		case full == timestampName:
+			return nullreflect.Write(fv, m.Interface().(*timestamppb.Timestamp).AsTime(), nullstate.Present)
+		case full.Parent() == "google.protobuf" && strings.HasSuffix(string(full.Name()), "Value"):
+			if inner := m.Descriptor().Fields().ByName("value"); inner != nil && !inner.IsList() {
+				return writeValue(fv, inner, m.Get(inner), name, fe)
+			}
+		}
+		t := fv.Type()
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct || reflect.PointerTo(t).Implements(scannerType) || t == timeType {
+			return fmt.Errorf("cannot write message %s into %s", m.Descriptor().FullName(), fv.Type())
+		}
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				fv.Set(reflect.New(t))
+			}
+			fv = fv.Elem()
+		}
+		fromMessage(m, fv, name+".", fe)
+		return nil
+	case protoreflect.EnumKind:
+		n := pv.Enum()
+		if isInteger(fv.Type()) {
+			return nullreflect.Write(fv, int64(n), nullstate.Present)
+		}
+		if ev := fd.Enum().Values().ByNumber(n); ev != nil {
+			return nullreflect.WriteString(fv, string(ev.Name()))
+		}
+		return fmt.Errorf("unknown enum number %d", n)
+	}
+	return nullreflect.Write(fv, scalar(pv.Interface()), nullstate.Present)
+}
+
+// scalar widens proto scalars to the types database values are scanned from.
+func scalar(x any) any {
+	switch x := x.(type) {
+	case int32:
+		return int64(x)
+	case uint32:
+		return int64(x)
+	case float32:
+		return float64(x)
+	}
+	return x
+}
+
+// isInteger reports whether t, or the value held by pointer or nullable type t, is an integer.
+func isInteger(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if inner, ok := nullreflect.Inner(t); ok {
+		t = inner
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	timeType    = reflect.TypeFor[time.Time]()
+)
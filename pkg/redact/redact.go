@@ -0,0 +1,97 @@
+// Package redact scrubs sensitive fields of nullable DTOs before they are
+// persisted, e.g. as form snapshots in audit storage.
+//
+// Fields are selected with the `redact` struct tag:
+//
+//	type Form struct {
+//		Uinfin nullable.Optional[string] `redact:"hash"`
+//		Notes  nullable.Null[string]     `redact:"drop"`
+//	}
+//
+// Only fields holding a value are touched, so null and undefined states survive redaction.
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// Policy configures redaction.
+type Policy struct {
+	// Key keys the HMAC-SHA256 used by `redact:"hash"`, so hashes cannot be
+	// reversed by brute-forcing small value spaces such as NRIC numbers.
+	// Without a key, plain SHA-256 is used.
+	Key []byte
+}
+
+// Hash returns the hex encoded hash of s under p.
+func (p Policy) Hash(s string) string {
+	if len(p.Key) == 0 {
+		sum := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(sum[:])
+	}
+	mac := hmac.New(sha256.New, p.Key)
+	mac.Write([]byte(s))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Struct redacts dto, a non-nil pointer to a struct, in place.
+// `redact:"hash"` replaces a value with its hash and requires a string-like field,
+// `redact:"drop"` replaces it with the zero value of its type.
+func Struct(dto any, policy Policy) error {
+	v := reflect.ValueOf(dto)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("redact: dto must be a non-nil pointer to a struct, got %T", dto)
+	}
+	v = v.Elem()
+
+	for _, f := range nullreflect.Fields(v.Type()) {
+		mode := f.Tag.Get("redact")
+		if mode == "" {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		val, state, err := nullreflect.Read(fv)
+		if err != nil {
+			return fmt.Errorf("redact: field %s: %w", f.Name, err)
+		}
+		if state != nullable.StatePresent {
+			continue
+		}
+
+		switch mode {
+		case "hash":
+			var s string
+			switch x := val.(type) {
+			case string:
+				s = x
+			case []byte:
+				s = string(x)
+			default:
+				return fmt.Errorf("redact: field %s: cannot hash %T value", f.Name, val)
+			}
+			val = policy.Hash(s)
+		case "drop":
+			val = zeroValue(val)
+		default:
+			return fmt.Errorf("redact: field %s: unknown mode %q", f.Name, mode)
+		}
+		if err := nullreflect.Write(fv, val, nullable.StatePresent); err != nil {
+			return fmt.Errorf("redact: field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func zeroValue(v any) any {
+	if _, ok := v.([]byte); ok {
+		return []byte{}
+	}
+	return reflect.Zero(reflect.TypeOf(v)).Interface()
+}
@@ -0,0 +1,151 @@
+// Package rules checks cross-field constraints on nullable DTOs, the kind that
+// per-field validate tags cannot express:
+//
+//	err := rules.Check(form,
+//		rules.Require("Aliasnme").When("Name").Present(),
+//		rules.Require("HanyupinAliasname").When("HanyupinName").Defined(),
+//	)
+//
+// Fields are named by their Go field name, like validator does.
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/forms"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// Rule requires a field to hold a value when another field is in a given state.
+// Rules are built with Require.
+type Rule struct {
+	field, when string
+	cond        string
+	test        func(nullstate.State) bool
+}
+
+// Requirement is the first half of a Rule, naming the required field.
+type Requirement struct {
+	field string
+}
+
+// Require starts a rule requiring field to hold a value, that is to be neither null nor undefined.
+func Require(field string) Requirement {
+	return Requirement{field: field}
+}
+
+// When names the field whose state triggers the requirement.
+func (r Requirement) When(field string) Condition {
+	return Condition{field: r.field, when: field}
+}
+
+// Condition is a Requirement with its triggering field, completed by choosing the triggering state.
+type Condition struct {
+	field, when string
+}
+
+// Defined applies the rule when the field was sent, even as an explicit null.
+func (c Condition) Defined() Rule {
+	return c.rule("defined", func(s nullstate.State) bool { return s != nullstate.Undefined })
+}
+
+// Present applies the rule when the field holds a value.
+func (c Condition) Present() Rule {
+	return c.rule("present", func(s nullstate.State) bool { return s == nullstate.Present })
+}
+
+// Null applies the rule when the field is null, including explicitly null Optionals.
+func (c Condition) Null() Rule {
+	return c.rule("null", func(s nullstate.State) bool { return s == nullstate.Null })
+}
+
+// Undefined applies the rule when the field was not sent.
+func (c Condition) Undefined() Rule {
+	return c.rule("undefined", func(s nullstate.State) bool { return s == nullstate.Undefined })
+}
+
+func (c Condition) rule(cond string, test func(nullstate.State) bool) Rule {
+	return Rule{field: c.field, when: c.when, cond: cond, test: test}
+}
+
+// String describes r, e.g. "Aliasnme is required when Name is present".
+func (r Rule) String() string {
+	return fmt.Sprintf("%s is required when %s is %s", r.field, r.when, r.cond)
+}
+
+// Violation reports a Rule that dto broke.
+type Violation struct {
+	Field string
+	Rule  Rule
+}
+
+func (v Violation) Error() string {
+	return v.Rule.String()
+}
+
+// Violations lists every rule broken by a DTO, in the order the rules were given.
+type Violations []Violation
+
+func (vs Violations) Error() string {
+	msgs := make([]string, len(vs))
+	for i, v := range vs {
+		msgs[i] = v.Error()
+	}
+	return "rules: " + strings.Join(msgs, "; ")
+}
+
+// FieldErrors returns vs as forms.FieldErrors, reporting "required" on each field.
+func (vs Violations) FieldErrors() forms.FieldErrors {
+	var fe forms.FieldErrors
+	for _, v := range vs {
+		fe.Add(v.Field, "required")
+	}
+	return fe
+}
+
+// Check evaluates rules against dto, a struct or pointer to one, and returns
+// Violations if any of them is broken. Rules naming fields that dto does not
+// have make Check fail with a plain error instead.
+func Check(dto any, rules ...Rule) error {
+	v := reflect.Indirect(reflect.ValueOf(dto))
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("rules: dto must be a struct, got %T", dto)
+	}
+	var violations Violations
+	for _, r := range rules {
+		if r.test == nil {
+			return errors.New("rules: incomplete rule, build rules with Require")
+		}
+		when, err := state(v, r.when)
+		if err != nil {
+			return err
+		}
+		st, err := state(v, r.field)
+		if err != nil {
+			return err
+		}
+		if r.test(when) && st != nullstate.Present {
+			violations = append(violations, Violation{Field: r.field, Rule: r})
+		}
+	}
+	if violations != nil {
+		return violations
+	}
+	return nil
+}
+
+func state(v reflect.Value, name string) (nullstate.State, error) {
+	fv := v.FieldByName(name)
+	if !fv.IsValid() {
+		return 0, fmt.Errorf("rules: %s has no field %s", v.Type(), name)
+	}
+	_, st, err := nullreflect.Read(fv)
+	if err != nil {
+		return 0, fmt.Errorf("rules: field %s: %w", name, err)
+	}
+	return st, nil
+}
@@ -0,0 +1,95 @@
+// Package scan hydrates nullable DTOs from pgx query results.
+package scan
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+var columnsCache sync.Map // map[reflect.Type]map[string][]int
+
+// columns maps column names to field indexes of struct type t.
+func columns(t reflect.Type) map[string][]int {
+	if cached, ok := columnsCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+	cols := make(map[string][]int)
+	for _, f := range nullreflect.Fields(t) {
+		if name, ok := f.Column(); ok {
+			cols[name] = f.Index
+		}
+	}
+	cached, _ := columnsCache.LoadOrStore(t, cols)
+	return cached.(map[string][]int)
+}
+
+// RowToNullStruct scans row into a new T, a struct whose fields are matched to columns by
+// their `db` tag or snake_cased name. It is intended to be used with pgx.CollectRows
+// and pgx.CollectOneRow, like pgx.RowToStructByName.
+//
+// Unlike pgx.RowToStructByName, fields without a matching column are allowed and keep their
+// zero value, so nullable.Optional fields left out of the SELECT stay undefined while selected
+// NULL columns become explicit nulls. Every column must match a field.
+func RowToNullStruct[T any](row pgx.CollectableRow) (T, error) {
+	var value T
+	v := reflect.ValueOf(&value).Elem()
+	if v.Kind() != reflect.Struct {
+		return value, fmt.Errorf("scan: %T is not a struct", value)
+	}
+	cols := columns(v.Type())
+	fds := row.FieldDescriptions()
+	targets := make([]any, len(fds))
+	for i, fd := range fds {
+		idx, ok := cols[fd.Name]
+		if !ok {
+			return value, fmt.Errorf("scan: no field of %s matches column %q", v.Type(), fd.Name)
+		}
+		targets[i] = v.FieldByIndex(idx).Addr().Interface()
+	}
+	if err := row.Scan(targets...); err != nil {
+		return value, fmt.Errorf("scan: %w", err)
+	}
+	return value, nil
+}
+
+// Rows collects every row of rows into a []T, matching fields like RowToNullStruct.
+// The column to field plan and the scan targets are built once and reused across rows,
+// instead of per row as pgx.CollectRows with RowToNullStruct does. rows is always closed.
+func Rows[T any](rows pgx.Rows) ([]T, error) {
+	defer rows.Close()
+
+	var value T
+	v := reflect.ValueOf(&value).Elem()
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("scan: %T is not a struct", value)
+	}
+	cols := columns(v.Type())
+	fds := rows.FieldDescriptions()
+	targets := make([]any, len(fds))
+	for i, fd := range fds {
+		idx, ok := cols[fd.Name]
+		if !ok {
+			return nil, fmt.Errorf("scan: no field of %s matches column %q", v.Type(), fd.Name)
+		}
+		targets[i] = v.FieldByIndex(idx).Addr().Interface()
+	}
+
+	var out []T
+	for rows.Next() {
+		// Reset value so no scanner reuses memory still referenced by a collected row.
+		v.SetZero()
+		if err := rows.Scan(targets...); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		out = append(out, value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+	return out, nil
+}
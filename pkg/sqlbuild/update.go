@@ -0,0 +1,70 @@
+// Package sqlbuild builds SQL statements from nullable DTOs, so stepped form
+// persistence only touches the columns a step actually defined.
+package sqlbuild
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/convert"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+)
+
+// UpdateSet builds an UPDATE statement for table from the fields of dto, a struct or pointer to one.
+// Fields reporting themselves as undefined (such as nullable.Optional) are skipped,
+// null fields are written as NULL and every other field is written as is.
+// Columns are named after the `db` tag or the snake_cased field name; `db:"-"` skips a field.
+// Fields of types with an adapter in convert.DefaultRegistry are written as converted by it,
+// and nullable.Nullable types that aren't driver.Valuers as the value they hold.
+//
+// The statement uses $n placeholders and has no WHERE clause, the caller appends one
+// starting at placeholder len(args)+1. If no field is defined, sql is empty.
+func UpdateSet(table string, dto any) (sql string, args []any) {
+	return updateSet(table, dto, "")
+}
+
+// updateSet implements UpdateSet, leaving out the column named skip.
+func updateSet(table string, dto any, skip string) (sql string, args []any) {
+	v := reflect.Indirect(reflect.ValueOf(dto))
+	if v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("sqlbuild: dto must be a struct, got %T", dto))
+	}
+
+	var sets []string
+	for _, f := range nullreflect.Fields(v.Type()) {
+		col, ok := f.Column()
+		if !ok || col == skip {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		if nullreflect.IsUndefined(fv) {
+			continue
+		}
+		args = append(args, arg(fv))
+		sets = append(sets, fmt.Sprintf("%s = $%d", col, len(args)))
+	}
+	if len(sets) == 0 {
+		return "", nil
+	}
+	return "UPDATE " + table + " SET " + strings.Join(sets, ", "), args
+}
+
+// arg returns the SQL argument for field fv: its value converted by the adapters of
+// convert.DefaultRegistry, or the value held by a nullable.Nullable that the driver
+// cannot encode because it isn't a driver.Valuer.
+func arg(fv reflect.Value) any {
+	x := convert.DefaultRegistry.Value(fv.Interface())
+	if _, ok := x.(driver.Valuer); ok {
+		return x
+	}
+	// Nullable's Set has a pointer receiver, only Get is needed on field values.
+	if g, ok := x.(interface{ Get() (any, bool) }); ok {
+		if v, ok := g.Get(); ok {
+			return v
+		}
+		return nil
+	}
+	return x
+}
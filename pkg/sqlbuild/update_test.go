@@ -0,0 +1,126 @@
+package sqlbuild
+
+import (
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/guregu/null/v6"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/convert"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// sealed is stored reversed, through an adapter in convert.DefaultRegistry.
+type sealed string
+
+func init() {
+	convert.Register(convert.DefaultRegistry, func(s sealed) (string, error) {
+		if s == "" {
+			return "", errors.New("empty")
+		}
+		b := []byte(s)
+		for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+			b[i], b[j] = b[j], b[i]
+		}
+		return string(b), nil
+	})
+}
+
+type testPatch struct {
+	ID          int64                     `db:"-"`
+	Name        nullable.Optional[string] `db:"full_name"`
+	MarriedName nullable.Optional[string]
+	Age         nullable.Null[int32]
+	Email       null.String
+	Phone       pgtype.Text
+	Secret      sealed
+	Version     nullable.Optional[int64]
+}
+
+// driverValues resolves args to the values the driver would send.
+func driverValues(t *testing.T, args []any) []any {
+	t.Helper()
+	out := make([]any, len(args))
+	for i, a := range args {
+		v, err := driver.DefaultParameterConverter.ConvertValue(a)
+		if err != nil {
+			t.Fatalf("args[%d]: %v", i, err)
+		}
+		out[i] = v
+	}
+	return out
+}
+
+func TestUpdateSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		dto      any
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "undefined skipped",
+			dto:      testPatch{Name: nullable.OptionalFrom("Tan"), Secret: "abc"},
+			wantSQL:  "UPDATE people SET full_name = $1, age = $2, email = $3, phone = $4, secret = $5",
+			wantArgs: []any{"Tan", nil, nil, nil, "cba"},
+		},
+		{
+			name: "null written as NULL",
+			dto: &testPatch{
+				MarriedName: nullable.OptionalNull[string](),
+				Age:         nullable.From[int32](30),
+				Email:       null.StringFrom("tan@example.com"),
+				Phone:       pgtype.Text{String: "+65", Valid: true},
+				Secret:      "x",
+			},
+			wantSQL:  "UPDATE people SET married_name = $1, age = $2, email = $3, phone = $4, secret = $5",
+			wantArgs: []any{nil, int64(30), "tan@example.com", "+65", "x"},
+		},
+		{
+			name:    "nothing defined",
+			dto:     struct{ Name nullable.Optional[string] }{},
+			wantSQL: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := UpdateSet("people", tt.dto)
+			if sql != tt.wantSQL {
+				t.Errorf("sql = %q, want %q", sql, tt.wantSQL)
+			}
+			if got := driverValues(t, args); len(got) != len(tt.wantArgs) || (len(got) > 0 && !reflect.DeepEqual(got, tt.wantArgs)) {
+				t.Errorf("args = %#v, want %#v", got, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestUpdateSetAdapterError(t *testing.T) {
+	_, args := UpdateSet("people", struct{ Secret sealed }{})
+	v, ok := args[0].(driver.Valuer)
+	if !ok {
+		t.Fatalf("args[0] = %T, want a driver.Valuer deferring the adapter error", args[0])
+	}
+	if _, err := v.Value(); err == nil {
+		t.Error("Value(): want the adapter error")
+	}
+}
+
+// wantPanic fails t unless f panics with a sqlbuild message.
+func wantPanic(t *testing.T, f func()) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r == nil || !strings.HasPrefix(r.(string), "sqlbuild: ") {
+			t.Errorf("recover() = %v, want a sqlbuild panic", r)
+		}
+	}()
+	f()
+}
+
+func TestUpdateSetPanicsOnNonStruct(t *testing.T) {
+	wantPanic(t, func() { UpdateSet("people", 1) })
+}
@@ -0,0 +1,36 @@
+package sqlbuild
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrStaleVersion reports that an optimistic-locking update matched no row,
+// because the row was changed by someone else since it was read.
+var ErrStaleVersion = errors.New("sqlbuild: stale version")
+
+// UpdateSetWithVersion builds an UPDATE statement like UpdateSet that also increments
+// versionCol and only matches rows still at expectedVersion. A versionCol field of dto
+// is ignored, the column is only ever bumped by the statement itself.
+//
+// The statement ends with "WHERE versionCol = $n"; the caller appends further conditions,
+// such as the primary key, with AND starting at placeholder len(args)+1, and passes the
+// number of affected rows to CheckVersion. If no field is defined, sql is empty.
+func UpdateSetWithVersion(table string, dto any, versionCol string, expectedVersion int64) (sql string, args []any) {
+	sql, args = updateSet(table, dto, versionCol)
+	if sql == "" {
+		return "", nil
+	}
+	args = append(args, expectedVersion)
+	sql += fmt.Sprintf(", %s = %s + 1 WHERE %s = $%d", versionCol, versionCol, versionCol, len(args))
+	return sql, args
+}
+
+// CheckVersion returns ErrStaleVersion if an UpdateSetWithVersion statement affected no rows.
+// It takes the count from sql.Result.RowsAffected or pgconn.CommandTag.RowsAffected.
+func CheckVersion(rowsAffected int64) error {
+	if rowsAffected == 0 {
+		return ErrStaleVersion
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package sqlbuild
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+func TestUpdateSetWithVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		dto      any
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "version field ignored",
+			dto:      struct{ Name, Version nullable.Optional[string] }{nullable.OptionalFrom("Tan"), nullable.OptionalFrom("99")},
+			wantSQL:  "UPDATE people SET name = $1, version = version + 1 WHERE version = $2",
+			wantArgs: []any{"Tan", int64(4)},
+		},
+		{
+			name:    "nothing defined",
+			dto:     struct{ Version nullable.Optional[string] }{nullable.OptionalFrom("99")},
+			wantSQL: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := UpdateSetWithVersion("people", tt.dto, "version", 4)
+			if sql != tt.wantSQL {
+				t.Errorf("sql = %q, want %q", sql, tt.wantSQL)
+			}
+			if got := driverValues(t, args); len(got) != len(tt.wantArgs) || (len(got) > 0 && !reflect.DeepEqual(got, tt.wantArgs)) {
+				t.Errorf("args = %#v, want %#v", got, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	if err := CheckVersion(0); !errors.Is(err, ErrStaleVersion) {
+		t.Errorf("CheckVersion(0) = %v, want ErrStaleVersion", err)
+	}
+	if err := CheckVersion(1); err != nil {
+		t.Errorf("CheckVersion(1) = %v, want nil", err)
+	}
+}
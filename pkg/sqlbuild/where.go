@@ -0,0 +1,74 @@
+package sqlbuild
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// WhereDefined appends a predicate to sb for every filter set in dto, a struct or pointer
+// to one, so search endpoints only filter by what the user filled in.
+// Present values compare with =, explicitly null Optional fields match IS NULL,
+// and undefined fields are skipped. Fields that cannot be undefined, such as nullable.Null
+// or pointers, are skipped when null. Slices other than []byte, such as nullable.Slice,
+// match any of their elements with IN, as bound from repeated keys by bind.Query.
+// Columns are named and values adapted like UpdateSet does.
+func WhereDefined(sb squirrel.SelectBuilder, dto any) squirrel.SelectBuilder {
+	v := reflect.Indirect(reflect.ValueOf(dto))
+	if v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("sqlbuild: dto must be a struct, got %T", dto))
+	}
+
+	for _, f := range nullreflect.Fields(v.Type()) {
+		col, ok := f.Column()
+		if !ok {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		if nullreflect.IsUndefined(fv) {
+			continue
+		}
+		if elems, ok := sliceElems(fv); ok {
+			if elems != nil {
+				sb = sb.Where(squirrel.Eq{col: elems})
+			}
+			continue
+		}
+		a := arg(fv)
+		_, state, err := nullreflect.Read(reflect.ValueOf(&a).Elem())
+		switch {
+		case err != nil:
+			// Leave the conversion error to the driver.
+			sb = sb.Where(col+" = ?", a)
+		case state == nullstate.Null:
+			if nullreflect.CanBeUndefined(fv.Type()) {
+				sb = sb.Where(col + " IS NULL")
+			}
+		default:
+			sb = sb.Where(col+" = ?", a)
+		}
+	}
+	return sb
+}
+
+// sliceElems reports whether fv is a slice, or a nullable type holding one, other than []byte,
+// and returns its elements, or nil if it is null.
+func sliceElems(fv reflect.Value) (any, bool) {
+	if inner, ok := nullreflect.Inner(fv.Type()); ok && inner.Kind() == reflect.Slice && inner.Elem().Kind() != reflect.Uint8 {
+		if !fv.FieldByName("Valid").Bool() {
+			return nil, true
+		}
+		return fv.FieldByName("V").Interface(), true
+	}
+	if fv.Kind() != reflect.Slice || fv.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	if fv.IsNil() {
+		return nil, true
+	}
+	return fv.Interface(), true
+}
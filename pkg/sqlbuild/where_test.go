@@ -0,0 +1,72 @@
+package sqlbuild
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type testFilter struct {
+	Name    nullable.Optional[string]
+	Deleted nullable.Optional[string] `db:"deleted_at"`
+	Age     nullable.Null[int32]
+	Status  nullable.Slice[string]
+	IDs     []int64 `db:"id"`
+	Ref     *string
+	Raw     []byte `db:"-"`
+}
+
+func TestWhereDefined(t *testing.T) {
+	ref := "r1"
+	tests := []struct {
+		name     string
+		dto      any
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:    "nothing set",
+			dto:     testFilter{},
+			wantSQL: "SELECT * FROM people",
+		},
+		{
+			name:     "values compare with =",
+			dto:      testFilter{Name: nullable.OptionalFrom("Tan"), Age: nullable.From[int32](30), Ref: &ref},
+			wantSQL:  "SELECT * FROM people WHERE name = $1 AND age = $2 AND ref = $3",
+			wantArgs: []any{"Tan", int64(30), "r1"},
+		},
+		{
+			name:    "explicit null matches IS NULL",
+			dto:     &testFilter{Deleted: nullable.OptionalNull[string]()},
+			wantSQL: "SELECT * FROM people WHERE deleted_at IS NULL",
+		},
+		{
+			name:     "slices match with IN",
+			dto:      testFilter{Status: nullable.SliceFrom([]string{"a", "b"}), IDs: []int64{1}},
+			wantSQL:  "SELECT * FROM people WHERE status IN ($1,$2) AND id IN ($3)",
+			wantArgs: []any{"a", "b", int64(1)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sb := squirrel.Select("*").From("people").PlaceholderFormat(squirrel.Dollar)
+			sql, args, err := WhereDefined(sb, tt.dto).ToSql()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("sql = %q, want %q", sql, tt.wantSQL)
+			}
+			if got := driverValues(t, args); len(got) != len(tt.wantArgs) || (len(got) > 0 && !reflect.DeepEqual(got, tt.wantArgs)) {
+				t.Errorf("args = %#v, want %#v", got, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestWhereDefinedPanicsOnNonStruct(t *testing.T) {
+	wantPanic(t, func() { WhereDefined(squirrel.Select("*"), "x") })
+}
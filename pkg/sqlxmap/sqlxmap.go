@@ -0,0 +1,61 @@
+// Package sqlxmap adapts nullable DTOs to github.com/jmoiron/sqlx.
+//
+// The nullable types implement sql.Scanner, so sqlx's Get, Select and StructScan fill
+// them like any other column; sqlxmap covers the other direction, turning partial DTOs
+// into named parameters for NamedExec.
+package sqlxmap
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// NamedArgs converts the fields of dto, a struct or pointer to one, into named
+// parameters for sqlx.NamedExec. Undefined fields are omitted, so a statement built
+// from the keys only touches what the caller defined; null fields map to nil.
+// Parameters are named after the `db` tag or sqlx.NameMapper, like sqlx does when
+// binding structs; `db:"-"` skips a field.
+func NamedArgs(dto any) map[string]any {
+	v := reflect.Indirect(reflect.ValueOf(dto))
+	if v.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("sqlxmap: dto must be a struct, got %T", dto))
+	}
+
+	args := make(map[string]any)
+	for _, f := range nullreflect.Fields(v.Type()) {
+		name, ok := paramName(f)
+		if !ok {
+			continue
+		}
+		fv := v.FieldByIndex(f.Index)
+		dv, state, err := nullreflect.Read(fv)
+		switch {
+		case err != nil:
+			// Leave the conversion error to the driver.
+			args[name] = fv.Interface()
+		case state == nullstate.Undefined:
+		case state == nullstate.Null:
+			args[name] = nil
+		default:
+			args[name] = dv
+		}
+	}
+	return args
+}
+
+func paramName(f nullreflect.Field) (string, bool) {
+	tag := f.Tag.Get("db")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return sqlx.NameMapper(f.Name), true
+}
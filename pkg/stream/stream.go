@@ -0,0 +1,98 @@
+// Package stream reads and writes newline-delimited JSON (NDJSON) of nullable DTOs one
+// record at a time, so backfills over millions of rows run in constant memory:
+//
+//	enc := stream.NewEncoder[dtos.UinfinNamesPatch](out, httpnull.EncodePolicy{})
+//	for patch, err := range stream.Decode[dtos.UinfinNamesPatch](in) {
+//		if err != nil {
+//			log.Print(err)
+//			continue
+//		}
+//		if err := enc.Encode(correct(patch)); err != nil {
+//			return err
+//		}
+//	}
+//	return enc.Flush()
+//
+// Records keep the tri-state of their fields across a round trip: the encoder leaves
+// undefined fields out and the decoder leaves fields of absent keys undefined.
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/httpnull"
+)
+
+// Decode returns an iterator over the records of the NDJSON stream r, each decoded into a
+// new T with encoding/json. Blank lines are skipped. A line that fails to decode yields
+// its error, prefixed with the line number, and decoding continues with the next line, so
+// one bad record does not abort a backfill; an error reading r ends the iteration.
+func Decode[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		br := bufio.NewReader(r)
+		for line := 1; ; line++ {
+			data, err := br.ReadBytes('\n')
+			if len(bytes.TrimSpace(data)) > 0 {
+				var v T
+				if derr := json.Unmarshal(data, &v); derr != nil {
+					var zero T
+					if !yield(zero, fmt.Errorf("stream: line %d: %w", line, derr)) {
+						return
+					}
+				} else if !yield(v, nil) {
+					return
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				var zero T
+				yield(zero, fmt.Errorf("stream: %w", err))
+				return
+			}
+		}
+	}
+}
+
+// Encoder writes records of type T to an NDJSON stream through a buffer,
+// so call Flush once done.
+type Encoder[T any] struct {
+	w   *bufio.Writer
+	p   httpnull.EncodePolicy
+	buf []byte // reused across records
+}
+
+// NewEncoder creates an Encoder writing to w, rendering nulls according to p
+// like httpnull.Marshal does.
+func NewEncoder[T any](w io.Writer, p httpnull.EncodePolicy) *Encoder[T] {
+	return &Encoder[T]{w: bufio.NewWriter(w), p: p}
+}
+
+// Encode writes v as a single line.
+func (e *Encoder[T]) Encode(v T) error {
+	data, err := httpnull.EncodeAppend(e.buf[:0], v, e.p)
+	if err != nil {
+		return fmt.Errorf("stream: %w", err)
+	}
+	// encoding/json never emits raw newlines, so each record stays on its line.
+	e.buf = append(data, '\n')
+	if _, err := e.w.Write(e.buf); err != nil {
+		return fmt.Errorf("stream: %w", err)
+	}
+	return nil
+}
+
+// Flush writes any buffered records to the underlying writer.
+func (e *Encoder[T]) Flush() error {
+	if err := e.w.Flush(); err != nil {
+		return fmt.Errorf("stream: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package stream
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/httpnull"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+type record struct {
+	ID      int64                     `json:"id"`
+	Name    nullable.Optional[string] `json:"name"`
+	Married nullable.Optional[string] `json:"married"`
+	Age     nullable.Null[int32]      `json:"age"`
+}
+
+// result is a decoded record or its error, flattened for comparison.
+type result struct {
+	rec record
+	err string
+}
+
+func collect(r io.Reader) []result {
+	var out []result
+	for rec, err := range Decode[record](r) {
+		res := result{rec: rec}
+		if err != nil {
+			res.err = err.Error()
+		}
+		out = append(out, res)
+	}
+	return out
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []result
+	}{
+		{name: "empty", input: "", want: nil},
+		{
+			name:  "states",
+			input: `{"id":1,"name":"Tan","married":null}` + "\n" + `{"id":2}` + "\n",
+			want: []result{
+				{rec: record{ID: 1, Name: nullable.OptionalFrom("Tan"), Married: nullable.OptionalNull[string]()}},
+				{rec: record{ID: 2}},
+			},
+		},
+		{
+			name:  "blank lines and no final newline",
+			input: "\n" + `{"id":1}` + "\n  \r\n" + `{"id":2,"age":30}`,
+			want:  []result{{rec: record{ID: 1}}, {rec: record{ID: 2, Age: nullable.From[int32](30)}}},
+		},
+		{
+			name:  "bad lines do not stop decoding",
+			input: `{"id":1}` + "\n" + `{"id":` + "\n" + `{"id":"x"}` + "\n" + `{"id":4}` + "\n",
+			want: []result{
+				{rec: record{ID: 1}},
+				{err: "stream: line 2: unexpected end of JSON input"},
+				{err: "stream: line 3: json: cannot unmarshal string into Go struct field record.id of type int64"},
+				{rec: record{ID: 4}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collect(strings.NewReader(tt.input))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode(%q) =\n%#v\nwant\n%#v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeReadError(t *testing.T) {
+	r := io.MultiReader(strings.NewReader(`{"id":1}`+"\n"), iotest.ErrReader(errors.New("reset")))
+	got := collect(r)
+	want := []result{{rec: record{ID: 1}}, {err: "stream: reset"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Decode = %#v, want %#v", got, want)
+	}
+}
+
+func TestDecodeStopsEarly(t *testing.T) {
+	n := 0
+	for range Decode[record](strings.NewReader("{}\n{}\n{}\n")) {
+		n++
+		if n == 2 {
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("iterated %d records after break, want 2", n)
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	records := []record{
+		{ID: 1, Name: nullable.OptionalFrom("Tan"), Married: nullable.OptionalNull[string]()},
+		{ID: 2, Age: nullable.From[int32](30)},
+	}
+	tests := []struct {
+		name   string
+		policy httpnull.EncodePolicy
+		want   string
+	}{
+		{
+			name:   "emit null",
+			policy: httpnull.EncodePolicy{},
+			want:   `{"id":1,"name":"Tan","married":null,"age":null}` + "\n" + `{"id":2,"age":30}` + "\n",
+		},
+		{
+			name:   "omit null",
+			policy: httpnull.EncodePolicy{NullAs: httpnull.Omit},
+			want:   `{"id":1,"name":"Tan"}` + "\n" + `{"id":2,"age":30}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := NewEncoder[record](&buf, tt.policy)
+			for _, r := range records {
+				if err := enc.Encode(r); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := enc.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("encoded\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	records := []record{
+		{ID: 1, Name: nullable.OptionalFrom("line\nbreak"), Married: nullable.OptionalNull[string]()},
+		{ID: 2},
+		{ID: 3, Age: nullable.From[int32](-1)},
+	}
+	var buf bytes.Buffer
+	enc := NewEncoder[record](&buf, httpnull.EncodePolicy{})
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	var got []record
+	for r, err := range Decode[record](&buf) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, r)
+	}
+	if !reflect.DeepEqual(got, records) {
+		t.Errorf("round trip = %#v, want %#v", got, records)
+	}
+}
+
+func TestEncoderWriteError(t *testing.T) {
+	enc := NewEncoder[record](errWriter{}, httpnull.EncodePolicy{})
+	if err := enc.Encode(record{ID: 1}); err != nil {
+		t.Fatalf("Encode: %v, want the write buffered", err)
+	}
+	if err := enc.Flush(); err == nil || !strings.HasPrefix(err.Error(), "stream: ") {
+		t.Errorf("Flush = %v, want a stream error", err)
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
@@ -0,0 +1,105 @@
+// Package tmplnull provides template functions that understand nullable values,
+// so server-rendered pages print a placeholder instead of struct internals like {false}:
+//
+//	t := template.New("review").Funcs(tmplnull.FuncMap())
+//
+//	<dd>{{ orDash .Aliasnme }}</dd>
+//	{{ if isSet .MarriedName }}<dd>{{ value .MarriedName }}</dd>{{ end }}
+//
+// The functions accept nullable.Null and Optional, the concrete nullable types, guregu
+// null types, pgtype types, pointers and plain values alike. Present values are
+// unwrapped through their ValueOrZero method when they have one, so times, decimals
+// and UUIDs keep their own formatting.
+package tmplnull
+
+import (
+	"database/sql/driver"
+	"html/template"
+	"reflect"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+)
+
+// Dash is what orDash renders for null and undefined values.
+const Dash = "—"
+
+// FuncMap returns the template functions, usable with both html/template and text/template:
+//
+//   - value x: the inner value of x, or nil if x is null or undefined
+//   - isSet x: whether x holds a value
+//   - isNull x: whether x is null, including explicitly null Optionals
+//   - isUndefined x: whether x is an undefined Optional
+//   - orEmpty x: the inner value of x, or "" if it holds none
+//   - orDash x: the inner value of x, or Dash if it holds none
+//   - orDefault def x: the inner value of x, or def if it holds none,
+//     written as {{ .Name | orDefault "anonymous" }} in pipelines
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"value":       Value,
+		"isSet":       IsSet,
+		"isNull":      func(x any) bool { return state(x) == nullstate.Null },
+		"isUndefined": func(x any) bool { return state(x) == nullstate.Undefined },
+		"orEmpty":     func(x any) any { return Or("", x) },
+		"orDash":      func(x any) any { return Or(Dash, x) },
+		"orDefault":   Or,
+	}
+}
+
+// Value returns the inner value of x, or nil if x holds none.
+func Value(x any) any {
+	v, ok := unwrap(x)
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+// IsSet reports whether x holds a value, that is it is neither null nor undefined.
+func IsSet(x any) bool {
+	_, ok := unwrap(x)
+	return ok
+}
+
+// Or returns the inner value of x, or def if x holds none.
+func Or(def, x any) any {
+	v, ok := unwrap(x)
+	if !ok {
+		return def
+	}
+	return v
+}
+
+var valuerType = reflect.TypeFor[driver.Valuer]()
+
+func state(x any) nullstate.State {
+	v := reflect.ValueOf(x)
+	if !v.IsValid() {
+		return nullstate.Null
+	}
+	_, st, err := nullreflect.Read(v)
+	if err != nil {
+		// Not a database value, such as a struct or a map, so it is simply present.
+		return nullstate.Present
+	}
+	return st
+}
+
+func unwrap(x any) (any, bool) {
+	if state(x) != nullstate.Present {
+		return nil, false
+	}
+	v := reflect.ValueOf(x)
+	for v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if m := v.MethodByName("ValueOrZero"); m.IsValid() && m.Type().NumIn() == 0 && m.Type().NumOut() == 1 {
+		return m.Call(nil)[0].Interface(), true
+	}
+	if v.Type().Implements(valuerType) {
+		// pgtype and sql.Null types hold their value in a type-specific field.
+		dv, _, _ := nullreflect.Read(v)
+		return dv, true
+	}
+	return v.Interface(), true
+}
@@ -0,0 +1,184 @@
+// Package wizard drives stepped forms over a single nullable DTO.
+//
+// A Wizard declares its steps as subsets of the DTO's fields. Each submission is
+// merged into the accumulated state like forms.Merge does, restricted to the current
+// step's fields, and validated against those fields only:
+//
+//	w, err := wizard.New[dtos.UinfinNamesPatch](nil,
+//		wizard.Step{Name: "identity", Fields: []string{"Uinfin", "Name"}},
+//		wizard.Step{Name: "aliases", Fields: []string{"Aliasnme", "HanyupinAliasname"}},
+//	)
+//	err = w.Submit(&state, "identity", submission)
+//	if next, ok := w.Next(state); ok {
+//		// render next
+//	}
+package wizard
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullstate"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullvalidate"
+)
+
+// ErrUnknownStep is returned when a step name isn't declared by the Wizard.
+var ErrUnknownStep = errors.New("wizard: unknown step")
+
+// Step is a named subset of the DTO's fields, listed by Go field name.
+type Step struct {
+	Name   string
+	Fields []string
+}
+
+// Status reports whether a step is complete, see Wizard.Complete.
+type Status struct {
+	Step     string
+	Complete bool
+}
+
+// Wizard validates and accumulates the steps of a form whose state is a T.
+type Wizard[T any] struct {
+	steps    []Step
+	validate *validator.Validate
+}
+
+// New creates a Wizard for struct type T. Validation uses v, or a validator from
+// nullvalidate.New if v is nil. Step names must be unique and every field must exist in T.
+func New[T any](v *validator.Validate, steps ...Step) (*Wizard[T], error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("wizard: state must be a struct, got %s", t)
+	}
+	names := make(map[string]bool)
+	for _, f := range nullreflect.Fields(t) {
+		names[f.Name] = true
+	}
+	seen := make(map[string]bool)
+	for _, s := range steps {
+		if seen[s.Name] {
+			return nil, fmt.Errorf("wizard: duplicate step %q", s.Name)
+		}
+		seen[s.Name] = true
+		for _, f := range s.Fields {
+			if !names[f] {
+				return nil, fmt.Errorf("wizard: step %q: %s has no field %s", s.Name, t, f)
+			}
+		}
+	}
+	if v == nil {
+		v = nullvalidate.New()
+	}
+	return &Wizard[T]{steps: steps, validate: v}, nil
+}
+
+// Steps returns the declared steps in order.
+func (w *Wizard[T]) Steps() []Step {
+	return slices.Clone(w.steps)
+}
+
+func (w *Wizard[T]) step(name string) (Step, error) {
+	for _, s := range w.steps {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return Step{}, fmt.Errorf("%w %q", ErrUnknownStep, name)
+}
+
+// Submit merges the defined fields of input belonging to the named step into state.
+// input is a struct or pointer to one, either a T or any DTO sharing field names with it;
+// its fields outside the step are ignored. Undefined fields keep their previous answer
+// and null fields clear it. The merged step fields are then validated, and state is
+// only updated if they pass; validation failures are returned as validator.ValidationErrors.
+func (w *Wizard[T]) Submit(state *T, step string, input any) error {
+	s, err := w.step(step)
+	if err != nil {
+		return err
+	}
+	iv := reflect.Indirect(reflect.ValueOf(input))
+	if iv.Kind() != reflect.Struct {
+		return fmt.Errorf("wizard: input must be a struct, got %T", input)
+	}
+
+	next := *state
+	nv := reflect.ValueOf(&next).Elem()
+	for _, p := range nullreflect.Pairs(iv.Type(), nv.Type()) {
+		if !slices.Contains(s.Fields, p.Name) {
+			continue
+		}
+		val, st, err := nullreflect.Read(iv.FieldByIndex(p.Src))
+		if err != nil {
+			return fmt.Errorf("wizard: field %s: %w", p.Name, err)
+		}
+		if st == nullstate.Undefined {
+			continue
+		}
+		if err := nullreflect.Write(nv.FieldByIndex(p.Dst), val, st); err != nil {
+			return fmt.Errorf("wizard: field %s: %w", p.Name, err)
+		}
+	}
+	if err := w.validateStep(&next, s); err != nil {
+		return err
+	}
+	*state = next
+	return nil
+}
+
+func (w *Wizard[T]) validateStep(state *T, s Step) error {
+	if len(s.Fields) == 0 {
+		return nil
+	}
+	return nullvalidate.StructPartial(w.validate, state, s.Fields...)
+}
+
+// Complete reports whether the named step is answered in state: none of its fields is
+// undefined and they all pass validation. Fields that cannot be undefined, such as
+// nullable.Null or plain values, only need to pass validation.
+func (w *Wizard[T]) Complete(state T, step string) (bool, error) {
+	s, err := w.step(step)
+	if err != nil {
+		return false, err
+	}
+	return w.complete(&state, s), nil
+}
+
+func (w *Wizard[T]) complete(state *T, s Step) bool {
+	v := reflect.ValueOf(state).Elem()
+	for _, name := range s.Fields {
+		if nullreflect.IsUndefined(v.FieldByName(name)) {
+			return false
+		}
+	}
+	return w.validateStep(state, s) == nil
+}
+
+// Status reports the completion of every step, in order.
+func (w *Wizard[T]) Status(state T) []Status {
+	statuses := make([]Status, len(w.steps))
+	for i, s := range w.steps {
+		statuses[i] = Status{Step: s.Name, Complete: w.complete(&state, s)}
+	}
+	return statuses
+}
+
+// Next returns the first incomplete step, or false if every step is complete.
+func (w *Wizard[T]) Next(state T) (Step, bool) {
+	for _, s := range w.steps {
+		if !w.complete(&state, s) {
+			return s, true
+		}
+	}
+	return Step{}, false
+}
+
+// Done reports whether every step is complete.
+func (w *Wizard[T]) Done(state T) bool {
+	_, ok := w.Next(state)
+	return !ok
+}
@@ -0,0 +1,149 @@
+// Package xlsxnull reads spreadsheets of nullable DTOs with github.com/xuri/excelize/v2.
+//
+// The first row of a sheet is its header. Empty cells are null, columns missing
+// from the sheet leave their fields undefined and every other cell is parsed into
+// the field's type, as package bind does for form input. Cells are read as they are
+// displayed; parse dates or other formatted cells with a Parser:
+//
+//	err := xlsxnull.Read(f, "Corrections", &forms,
+//		xlsxnull.Parser("Date of Birth", func(cell string) (any, error) {
+//			return time.Parse("02/01/2006", cell)
+//		}),
+//	)
+package xlsxnull
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/internal/nullreflect"
+	"github.com/nadhifikbarw/x-go-painless-null/pkg/nullable"
+)
+
+// ParseFunc parses a non-empty cell. Returning nil stores null.
+type ParseFunc func(cell string) (any, error)
+
+type options struct {
+	parsers map[string]ParseFunc
+}
+
+// Option configures reading.
+type Option func(*options)
+
+// Parser parses the cells of the named column with parse instead of the default rules.
+// The column is matched like header cells are matched to fields.
+func Parser(column string, parse ParseFunc) Option {
+	return func(o *options) {
+		o.parsers[normalize(column)] = parse
+	}
+}
+
+// normalize folds a header for matching, so "Hanyupin Name", "hanyupin_name"
+// and "HanyupinName" all refer to the same field.
+func normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '_' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(s)))
+}
+
+// Read appends a T for every non-empty row of sheet in f to dst. Header cells are
+// matched to the `xlsx` tag or name of T's fields, ignoring case, spaces and underscores;
+// `xlsx:"-"` skips a field. Columns matching no field are ignored, as uploaded sheets
+// often carry notes or helper columns. Errors mention the cell at fault.
+func Read[T any](f *excelize.File, sheet string, dst *[]T, opts ...Option) error {
+	o := options{parsers: make(map[string]ParseFunc)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("xlsxnull: %s is not a struct", t)
+	}
+
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return fmt.Errorf("xlsxnull: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	byName := make(map[string]nullreflect.Field)
+	for _, fd := range nullreflect.Fields(t) {
+		tag := fd.Tag.Get("xlsx")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = fd.Name
+		}
+		byName[normalize(name)] = fd
+	}
+	type column struct {
+		index []int
+		parse ParseFunc
+	}
+	header := rows[0]
+	cols := make([]*column, len(header))
+	for i, name := range header {
+		if fd, ok := byName[normalize(name)]; ok {
+			cols[i] = &column{index: fd.Index, parse: o.parsers[normalize(name)]}
+		}
+	}
+
+	for r, row := range rows[1:] {
+		if isEmpty(row) {
+			continue
+		}
+		var value T
+		v := reflect.ValueOf(&value).Elem()
+		for i, c := range cols {
+			if c == nil {
+				continue
+			}
+			var cell string
+			if i < len(row) {
+				cell = row[i]
+			}
+			if err := writeCell(v.FieldByIndex(c.index), cell, c.parse); err != nil {
+				name, _ := excelize.CoordinatesToCellName(i+1, r+2)
+				return fmt.Errorf("xlsxnull: %s!%s: %w", sheet, name, err)
+			}
+		}
+		*dst = append(*dst, value)
+	}
+	return nil
+}
+
+func writeCell(fv reflect.Value, cell string, parse ParseFunc) error {
+	if strings.TrimSpace(cell) == "" {
+		return nullreflect.Write(fv, nil, nullable.StateNull)
+	}
+	if parse == nil {
+		return nullreflect.WriteString(fv, cell)
+	}
+	val, err := parse(cell)
+	if err != nil {
+		return err
+	}
+	if val == nil {
+		return nullreflect.Write(fv, nil, nullable.StateNull)
+	}
+	return nullreflect.Write(fv, val, nullable.StatePresent)
+}
+
+func isEmpty(row []string) bool {
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}